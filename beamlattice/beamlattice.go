@@ -11,26 +11,46 @@ import (
 // Namespace is the canonical name of this extension.
 const Namespace = "http://schemas.microsoft.com/3dmanufacturing/beamlattice/2017/02"
 
+// BallsNamespace is the canonical name of the beam lattice balls extension.
+const BallsNamespace = "http://schemas.microsoft.com/3dmanufacturing/beamlattice/balls/2020/07"
+
 var DefaultExtension = go3mf.Extension{
 	Namespace:  Namespace,
 	LocalName:  "b",
 	IsRequired: false,
 }
 
+// BallsExtension must be added to the model extensions
+// when encoding beam lattices with balls.
+var BallsExtension = go3mf.Extension{
+	Namespace:  BallsNamespace,
+	LocalName:  "b2",
+	IsRequired: false,
+}
+
 var (
 	ErrLatticeObjType       = errors.New("MUST only be added to a mesh object of type model or solidsupport")
 	ErrLatticeClippedNoMesh = errors.New("if clipping mode is not equal to none, a clippingmesh resource MUST be specified")
 	ErrLatticeInvalidMesh   = errors.New("the clippingmesh and representationmesh MUST be a mesh object of type model and MUST NOT contain a beamlattice")
 	ErrLatticeSameVertex    = errors.New("a beam MUST consist of two distinct vertex indices")
 	ErrLatticeBeamR2        = errors.New("r2 MUST not be defined, if r1 is not defined")
+	ErrLatticeBallVertex    = errors.New("a ball MUST be placed at a vertex referenced by at least one beam")
+	ErrLatticeSameBall      = errors.New("a vertex MUST NOT be referenced by more than one ball")
+	ErrLatticeBallRadius    = errors.New("ball radius MUST be positive")
+	ErrNoBeamLattice        = errors.New("object does not contain a beam lattice")
 )
 
 func init() {
 	go3mf.Register(Namespace, Spec{})
+	go3mf.Register(BallsNamespace, BallsSpec{})
 }
 
 type Spec struct{}
 
+// BallsSpec declares the support of the balls extension,
+// whose elements and attributes are handled by Spec.
+type BallsSpec struct{}
+
 // ClipMode defines the clipping modes for the beam lattices.
 type ClipMode uint8
 
@@ -85,6 +105,33 @@ func (b CapMode) String() string {
 	}[b]
 }
 
+// BallMode defines the ball modes for the beam lattices.
+type BallMode uint8
+
+// Supported ball modes.
+const (
+	BallModeNone BallMode = iota
+	BallModeMixed
+	BallModeAll
+)
+
+func newBallMode(s string) (b BallMode, ok bool) {
+	b, ok = map[string]BallMode{
+		"none":  BallModeNone,
+		"mixed": BallModeMixed,
+		"all":   BallModeAll,
+	}[s]
+	return
+}
+
+func (b BallMode) String() string {
+	return map[BallMode]string{
+		BallModeNone:  "none",
+		BallModeMixed: "mixed",
+		BallModeAll:   "all",
+	}[b]
+}
+
 // BeamLattice defines the Model Mesh BeamLattice Attributes class and is part of the BeamLattice extension to 3MF.
 type BeamLattice struct {
 	ClipMode             ClipMode
@@ -94,6 +141,9 @@ type BeamLattice struct {
 	BeamSets             []BeamSet
 	MinLength, Radius    float32
 	CapMode              CapMode
+	BallMode             BallMode
+	BallRadius           float32
+	Balls                []Ball
 }
 
 func GetBeamLattice(mesh *go3mf.Mesh) *BeamLattice {
@@ -105,9 +155,31 @@ func GetBeamLattice(mesh *go3mf.Mesh) *BeamLattice {
 	return nil
 }
 
-// BeamSet defines a set of beams.
+// References returns the IDs of the clipping and representation meshes.
+func (b *BeamLattice) References() []uint32 {
+	var refs []uint32
+	for _, id := range []uint32{b.ClippingMeshID, b.RepresentationMeshID} {
+		if id != 0 {
+			refs = append(refs, id)
+		}
+	}
+	return refs
+}
+
+// Remap replaces the IDs of the clipping and representation meshes with the ones returned by fn.
+func (b *BeamLattice) Remap(fn func(path string, id uint32) (string, uint32)) {
+	if b.ClippingMeshID != 0 {
+		_, b.ClippingMeshID = fn("", b.ClippingMeshID)
+	}
+	if b.RepresentationMeshID != 0 {
+		_, b.RepresentationMeshID = fn("", b.RepresentationMeshID)
+	}
+}
+
+// BeamSet defines a set of beams and balls.
 type BeamSet struct {
 	Refs       []uint32
+	BallRefs   []uint32
 	Name       string
 	Identifier string
 }
@@ -119,6 +191,12 @@ type Beam struct {
 	CapMode [2]CapMode // Capping mode.
 }
 
+// Ball defines a sphere placed at a beam vertex.
+type Ball struct {
+	Index  uint32  // Index of the vertex where the ball is placed.
+	Radius float32 // Radius of the ball.
+}
+
 const (
 	attrBeamLattice        = "beamlattice"
 	attrRadius             = "radius"
@@ -144,4 +222,11 @@ const (
 	attrIdentifier         = "identifier"
 	attrRef                = "ref"
 	attrIndex              = "index"
+	attrBallMode           = "ballmode"
+	attrBallRadius         = "ballradius"
+	attrBalls              = "balls"
+	attrBall               = "ball"
+	attrVIndex             = "vindex"
+	attrR                  = "r"
+	attrBallRef            = "ballref"
 )
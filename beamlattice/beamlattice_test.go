@@ -95,3 +95,64 @@ func TestClipMode_String(t *testing.T) {
 		})
 	}
 }
+
+func Test_newBallMode(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantB  BallMode
+		wantOk bool
+	}{
+		{"none", BallModeNone, true},
+		{"mixed", BallModeMixed, true},
+		{"all", BallModeAll, true},
+		{"empty", BallModeNone, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotB, gotOk := newBallMode(tt.name)
+			if !reflect.DeepEqual(gotB, tt.wantB) {
+				t.Errorf("newBallMode() gotB = %v, want %v", gotB, tt.wantB)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("newBallMode() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestBallMode_String(t *testing.T) {
+	tests := []struct {
+		name string
+		b    BallMode
+	}{
+		{"none", BallModeNone},
+		{"mixed", BallModeMixed},
+		{"all", BallModeAll},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.String(); got != tt.name {
+				t.Errorf("BallMode.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func TestBeamLattice_References(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *BeamLattice
+		want []uint32
+	}{
+		{"empty", new(BeamLattice), nil},
+		{"clipping", &BeamLattice{ClippingMeshID: 2}, []uint32{2}},
+		{"both", &BeamLattice{ClippingMeshID: 2, RepresentationMeshID: 3}, []uint32{2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.References(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BeamLattice.References() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
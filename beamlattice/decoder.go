@@ -23,6 +23,14 @@ func (Spec) CreateElementDecoder(parent interface{}, name string) spec.ElementDe
 	return nil
 }
 
+func (BallsSpec) DecodeAttribute(interface{}, spec.Attr) error {
+	return nil
+}
+
+func (BallsSpec) CreateElementDecoder(interface{}, string) spec.ElementDecoder {
+	return nil
+}
+
 type beamLatticeDecoder struct {
 	baseDecoder
 	mesh *go3mf.Mesh
@@ -33,6 +41,10 @@ func (d *beamLatticeDecoder) Start(attrs []spec.Attr) error {
 	beamLattice := new(BeamLattice)
 	d.mesh.Any = append(d.mesh.Any, beamLattice)
 	for _, a := range attrs {
+		if a.Name.Space == BallsNamespace {
+			errs = specerr.Append(errs, decodeBallsAttr(beamLattice, a))
+			continue
+		}
 		if a.Name.Space != "" {
 			continue
 		}
@@ -81,6 +93,24 @@ func (d *beamLatticeDecoder) Start(attrs []spec.Attr) error {
 	return nil
 }
 
+func decodeBallsAttr(beamLattice *BeamLattice, a spec.Attr) error {
+	switch a.Name.Local {
+	case attrBallMode:
+		var ok bool
+		beamLattice.BallMode, ok = newBallMode(string(a.Value))
+		if !ok {
+			return specerr.NewParseAttrError(a.Name.Local, false)
+		}
+	case attrBallRadius:
+		val, err := strconv.ParseFloat(string(a.Value), 32)
+		if err != nil {
+			return specerr.NewParseAttrError(a.Name.Local, false)
+		}
+		beamLattice.BallRadius = float32(val)
+	}
+	return nil
+}
+
 func (d *beamLatticeDecoder) Wrap(err error) error {
 	return specerr.Wrap(err, GetBeamLattice(d.mesh))
 }
@@ -92,6 +122,8 @@ func (d *beamLatticeDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 		} else if name.Local == attrBeamSets {
 			child = &beamSetsDecoder{mesh: d.mesh}
 		}
+	} else if name.Space == BallsNamespace && name.Local == attrBalls {
+		child = &ballsDecoder{mesh: d.mesh}
 	}
 	return
 }
@@ -188,6 +220,64 @@ func (d *beamDecoder) Start(attrs []spec.Attr) error {
 	return nil
 }
 
+type ballsDecoder struct {
+	baseDecoder
+	mesh        *go3mf.Mesh
+	ballDecoder ballDecoder
+}
+
+func (d *ballsDecoder) Start(_ []spec.Attr) error {
+	d.ballDecoder.mesh = d.mesh
+	return nil
+}
+
+func (d *ballsDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == BallsNamespace && name.Local == attrBall {
+		child = &d.ballDecoder
+	}
+	return
+}
+
+type ballDecoder struct {
+	baseDecoder
+	mesh *go3mf.Mesh
+}
+
+func (d *ballDecoder) Start(attrs []spec.Attr) error {
+	var (
+		ball Ball
+		errs error
+	)
+	beamLattice := GetBeamLattice(d.mesh)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrVIndex:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			ball.Index = uint32(val)
+		case attrR:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			ball.Radius = float32(val)
+		}
+	}
+	if ball.Radius == 0 {
+		ball.Radius = beamLattice.BallRadius
+	}
+	beamLattice.Balls = append(beamLattice.Balls, ball)
+	if errs != nil {
+		return specerr.WrapIndex(errs, ball, len(beamLattice.Balls)-1)
+	}
+	return nil
+}
+
 type beamSetsDecoder struct {
 	baseDecoder
 	mesh *go3mf.Mesh
@@ -205,6 +295,7 @@ type beamSetDecoder struct {
 	mesh           *go3mf.Mesh
 	beamSet        BeamSet
 	beamRefDecoder beamRefDecoder
+	ballRefDecoder beamRefDecoder
 }
 
 func (d *beamSetDecoder) End() {
@@ -213,7 +304,8 @@ func (d *beamSetDecoder) End() {
 }
 
 func (d *beamSetDecoder) Start(attrs []spec.Attr) error {
-	d.beamRefDecoder.beamSet = &d.beamSet
+	d.beamRefDecoder.refs = &d.beamSet.Refs
+	d.ballRefDecoder.refs = &d.beamSet.BallRefs
 	for _, a := range attrs {
 		if a.Name.Space != "" {
 			continue
@@ -235,13 +327,15 @@ func (d *beamSetDecoder) Wrap(err error) error {
 func (d *beamSetDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 	if name.Space == Namespace && name.Local == attrRef {
 		child = &d.beamRefDecoder
+	} else if name.Space == BallsNamespace && name.Local == attrBallRef {
+		child = &d.ballRefDecoder
 	}
 	return
 }
 
 type beamRefDecoder struct {
 	baseDecoder
-	beamSet *BeamSet
+	refs *[]uint32
 }
 
 func (d *beamRefDecoder) Start(attrs []spec.Attr) error {
@@ -259,9 +353,9 @@ func (d *beamRefDecoder) Start(attrs []spec.Attr) error {
 			break
 		}
 	}
-	d.beamSet.Refs = append(d.beamSet.Refs, uint32(val))
+	*d.refs = append(*d.refs, uint32(val))
 	if errs != nil {
-		return specerr.WrapIndex(errs, uint32(0), len(d.beamSet.Refs)-1)
+		return specerr.WrapIndex(errs, uint32(0), len(*d.refs)-1)
 	}
 	return nil
 }
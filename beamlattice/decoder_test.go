@@ -195,3 +195,109 @@ func TestDecode_warns(t *testing.T) {
 		}
 	})
 }
+
+func TestDecode_balls(t *testing.T) {
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:b="http://schemas.microsoft.com/3dmanufacturing/beamlattice/2017/02" xmlns:b2="http://schemas.microsoft.com/3dmanufacturing/beamlattice/balls/2020/07">
+		<resources>
+			<object id="15" name="Box" type="model">
+				<mesh>
+					<vertices>
+						<vertex x="0" y="0" z="0"/>
+						<vertex x="10" y="0" z="0"/>
+						<vertex x="10" y="10" z="0"/>
+					</vertices>
+					<b:beamlattice radius="1" minlength="0.0001" b2:ballmode="mixed" b2:ballradius="2">
+						<b:beams>
+							<b:beam v1="0" v2="1"/>
+							<b:beam v1="1" v2="2"/>
+						</b:beams>
+						<b2:balls>
+							<b2:ball vindex="0"/>
+							<b2:ball vindex="2" r="3.5"/>
+						</b2:balls>
+						<b:beamsets>
+							<b:beamset name="test">
+								<b:ref index="1"/>
+								<b2:ballref index="1"/>
+							</b:beamset>
+						</b:beamsets>
+					</b:beamlattice>
+				</mesh>
+			</object>
+		</resources>
+		<build/>
+		</model>
+		`
+	want := &BeamLattice{
+		MinLength: 0.0001, Radius: 1, BallMode: BallModeMixed, BallRadius: 2,
+		Beams: []Beam{
+			{Indices: [2]uint32{0, 1}, Radius: [2]float32{1, 1}},
+			{Indices: [2]uint32{1, 2}, Radius: [2]float32{1, 1}},
+		},
+		Balls:    []Ball{{Index: 0, Radius: 2}, {Index: 2, Radius: 3.5}},
+		BeamSets: []BeamSet{{Name: "test", Refs: []uint32{1}, BallRefs: []uint32{1}}},
+	}
+	got := &go3mf.Model{Path: "/3D/3dmodel.model"}
+	if err := go3mf.UnmarshalModel([]byte(rootFile), got); err != nil {
+		t.Fatalf("UnmarshalModel() unexpected error = %v", err)
+	}
+	if diff := deep.Equal(got.Extensions, []go3mf.Extension{DefaultExtension, BallsExtension}); diff != nil {
+		t.Errorf("UnmarshalModel() extensions = %v", diff)
+	}
+	if diff := deep.Equal(GetBeamLattice(got.Resources.Objects[0].Mesh), want); diff != nil {
+		t.Errorf("UnmarshalModel() = %v", diff)
+	}
+}
+
+func TestDecode_ballsWarns(t *testing.T) {
+	want := []string{
+		fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice: %v", errors.NewParseAttrError("ballmode", false)),
+		fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice: %v", errors.NewParseAttrError("ballradius", false)),
+		fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice@Ball#0: %v", errors.NewParseAttrError("vindex", true)),
+		fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice@Ball#1: %v", errors.NewParseAttrError("r", false)),
+		fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice@BeamSet#0@uint32#0: %v", errors.NewParseAttrError("index", true)),
+	}
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:b="http://schemas.microsoft.com/3dmanufacturing/beamlattice/2017/02" xmlns:b2="http://schemas.microsoft.com/3dmanufacturing/beamlattice/balls/2020/07">
+		<resources>
+			<object id="15" name="Box" type="model">
+				<mesh>
+					<vertices>
+						<vertex x="0" y="0" z="0"/>
+						<vertex x="10" y="0" z="0"/>
+						<vertex x="10" y="10" z="0"/>
+					</vertices>
+					<b:beamlattice radius="1" minlength="0.0001" b2:ballmode="invalid" b2:ballradius="a">
+						<b:beams>
+							<b:beam v1="0" v2="1"/>
+						</b:beams>
+						<b2:balls>
+							<b2:ball vindex="a"/>
+							<b2:ball vindex="1" r="b"/>
+						</b2:balls>
+						<b:beamsets>
+							<b:beamset>
+								<b2:ballref index="a"/>
+							</b:beamset>
+						</b:beamsets>
+					</b:beamlattice>
+				</mesh>
+			</object>
+		</resources>
+		<build/>
+		</model>
+		`
+	got := &go3mf.Model{Path: "/3D/3dmodel.model"}
+	err := go3mf.UnmarshalModel([]byte(rootFile), got)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	var errs []string
+	for _, err := range err.(*errors.List).Errors {
+		errs = append(errs, err.Error())
+	}
+	if diff := deep.Equal(errs, want); diff != nil {
+		t.Errorf("UnmarshalModel_warn() = %v", diff)
+	}
+}
@@ -34,9 +34,21 @@ func (m *BeamLattice) Marshal3MF(x spec.Encoder) error {
 	if m.CapMode != CapModeSphere {
 		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrCap}, Value: m.CapMode.String()})
 	}
+	if m.BallMode != BallModeNone {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Space: BallsNamespace, Local: attrBallMode}, Value: m.BallMode.String()})
+	}
+	if m.BallRadius != 0 {
+		xs.Attr = append(xs.Attr, xml.Attr{
+			Name:  xml.Name{Space: BallsNamespace, Local: attrBallRadius},
+			Value: strconv.FormatFloat(float64(m.BallRadius), 'f', x.FloatPresicion(), 32),
+		})
+	}
 	x.EncodeToken(xs)
 
 	marshalBeams(x, m)
+	if len(m.Balls) != 0 {
+		marshalBalls(x, m)
+	}
 	marshalBeamsets(x, m)
 
 	x.EncodeToken(xs.End())
@@ -61,6 +73,11 @@ func marshalBeamsets(x spec.Encoder, m *BeamLattice) {
 				{Name: xml.Name{Local: attrIndex}, Value: strconv.FormatUint(uint64(ref), 10)},
 			}})
 		}
+		for _, ref := range bs.BallRefs {
+			x.EncodeToken(xml.StartElement{Name: xml.Name{Space: BallsNamespace, Local: attrBallRef}, Attr: []xml.Attr{
+				{Name: xml.Name{Local: attrIndex}, Value: strconv.FormatUint(uint64(ref), 10)},
+			}})
+		}
 		x.SetAutoClose(false)
 		x.EncodeToken(xbs.End())
 	}
@@ -101,3 +118,25 @@ func marshalBeams(x spec.Encoder, m *BeamLattice) {
 	x.SetAutoClose(false)
 	x.EncodeToken(xb.End())
 }
+
+func marshalBalls(x spec.Encoder, m *BeamLattice) {
+	xb := xml.StartElement{Name: xml.Name{Space: BallsNamespace, Local: attrBalls}}
+	x.EncodeToken(xb)
+	x.SetAutoClose(true)
+	x.SetSkipAttrEscape(true)
+	for _, b := range m.Balls {
+		xball := xml.StartElement{Name: xml.Name{Space: BallsNamespace, Local: attrBall}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrVIndex}, Value: strconv.FormatUint(uint64(b.Index), 10)},
+		}}
+		if b.Radius > 0 && b.Radius != m.BallRadius {
+			xball.Attr = append(xball.Attr, xml.Attr{
+				Name:  xml.Name{Local: attrR},
+				Value: strconv.FormatFloat(float64(b.Radius), 'f', x.FloatPresicion(), 32),
+			})
+		}
+		x.EncodeToken(xball)
+	}
+	x.SetSkipAttrEscape(false)
+	x.SetAutoClose(false)
+	x.EncodeToken(xb.End())
+}
@@ -31,7 +31,10 @@ func TestMarshalModel(t *testing.T) {
 		{55, 45, 55},
 		{55, 45, 45},
 	}...)
-	beamLattice.BeamSets = append(beamLattice.BeamSets, BeamSet{Name: "test", Identifier: "set_id", Refs: []uint32{1}})
+	beamLattice.BallMode = BallModeMixed
+	beamLattice.BallRadius = 2
+	beamLattice.Balls = []Ball{{Index: 0, Radius: 2}, {Index: 6, Radius: 2.5}}
+	beamLattice.BeamSets = append(beamLattice.BeamSets, BeamSet{Name: "test", Identifier: "set_id", Refs: []uint32{1}, BallRefs: []uint32{1}})
 	beamLattice.Beams = append(beamLattice.Beams, []Beam{
 		{Indices: [2]uint32{0, 1}, Radius: [2]float32{1.5, 1.6}, CapMode: [2]CapMode{CapModeSphere, CapModeButt}},
 		{Indices: [2]uint32{2, 0}, Radius: [2]float32{3, 1.5}, CapMode: [2]CapMode{CapModeSphere, CapModeHemisphere}},
@@ -49,7 +52,7 @@ func TestMarshalModel(t *testing.T) {
 
 	m := &go3mf.Model{
 		Path:       "/3D/3dmodel.model",
-		Extensions: []go3mf.Extension{DefaultExtension},
+		Extensions: []go3mf.Extension{DefaultExtension, BallsExtension},
 		Resources: go3mf.Resources{
 			Objects: []*go3mf.Object{meshLattice},
 		},
@@ -0,0 +1,207 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package generator
+
+// Cell defines the periodic unit cells supported by the generator.
+type Cell uint8
+
+// Supported cells.
+const (
+	CellCubic Cell = iota
+	CellBCC
+	CellFCC
+	CellOctet
+	CellKelvin
+	CellDiamond
+)
+
+func (c Cell) String() string {
+	return map[Cell]string{
+		CellCubic:   "cubic",
+		CellBCC:     "bcc",
+		CellFCC:     "fcc",
+		CellOctet:   "octet",
+		CellKelvin:  "kelvin",
+		CellDiamond: "diamond",
+	}[c]
+}
+
+// cellSteps is the number of steps in which the cell edges are split
+// to place the cell nodes on integer coordinates.
+const cellSteps = 4
+
+// node is a cell node in steps of cellSteps per cell.
+type node [3]int
+
+func (n node) add(o node) node {
+	return node{n[0] + o[0], n[1] + o[1], n[2] + o[2]}
+}
+
+// strut is a beam between two cell nodes.
+type strut [2]node
+
+// struts returns the struts of the cell, all of them
+// contained in the cube [0, cellSteps]^3.
+// Struts on the cell faces are shared with the neighbour cells.
+func (c Cell) struts() []strut {
+	switch c {
+	case CellCubic:
+		return cubicStruts()
+	case CellBCC:
+		return bccStruts()
+	case CellFCC:
+		return fccStruts()
+	case CellOctet:
+		return append(fccStruts(), octahedronStruts()...)
+	case CellKelvin:
+		return kelvinStruts()
+	case CellDiamond:
+		return diamondStruts()
+	}
+	return nil
+}
+
+func corners() []node {
+	nodes := make([]node, 0, 8)
+	for _, x := range []int{0, cellSteps} {
+		for _, y := range []int{0, cellSteps} {
+			for _, z := range []int{0, cellSteps} {
+				nodes = append(nodes, node{x, y, z})
+			}
+		}
+	}
+	return nodes
+}
+
+func faceCenters() []node {
+	const h = cellSteps / 2
+	return []node{{0, h, h}, {cellSteps, h, h}, {h, 0, h}, {h, cellSteps, h}, {h, h, 0}, {h, h, cellSteps}}
+}
+
+func cubicStruts() []strut {
+	var struts []strut
+	for _, a := range corners() {
+		for axis := 0; axis < 3; axis++ {
+			if a[axis] == 0 {
+				b := a
+				b[axis] = cellSteps
+				struts = append(struts, strut{a, b})
+			}
+		}
+	}
+	return struts
+}
+
+func bccStruts() []strut {
+	center := node{cellSteps / 2, cellSteps / 2, cellSteps / 2}
+	var struts []strut
+	for _, c := range corners() {
+		struts = append(struts, strut{center, c})
+	}
+	return struts
+}
+
+// fccStruts returns the face diagonals, split at the face centers.
+func fccStruts() []strut {
+	var struts []strut
+	for _, f := range faceCenters() {
+		for _, c := range corners() {
+			if c[0] == f[0] || c[1] == f[1] || c[2] == f[2] {
+				struts = append(struts, strut{f, c})
+			}
+		}
+	}
+	return struts
+}
+
+// octahedronStruts connects the face centers which are not opposite.
+func octahedronStruts() []strut {
+	fc := faceCenters()
+	var struts []strut
+	for i := range fc {
+		for j := i + 1; j < len(fc); j++ {
+			if i/2 != j/2 {
+				struts = append(struts, strut{fc[i], fc[j]})
+			}
+		}
+	}
+	return struts
+}
+
+// kelvinStruts returns the edges of the truncated octahedra centered at the
+// cell center and at the cell corners which lie inside the cell.
+func kelvinStruts() []strut {
+	var offsets []node
+	for _, p := range [][3]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}} {
+		for _, sx := range []int{-1, 1} {
+			for _, sy := range []int{-1, 1} {
+				for _, sz := range []int{-1, 1} {
+					o := node{p[0] * sx, p[1] * sy, p[2] * sz}
+					if !containsNode(offsets, o) {
+						offsets = append(offsets, o)
+					}
+				}
+			}
+		}
+	}
+	var struts []strut
+	centers := append(corners(), node{cellSteps / 2, cellSteps / 2, cellSteps / 2})
+	for _, c := range centers {
+		for i := range offsets {
+			a := c.add(offsets[i])
+			for j := i + 1; j < len(offsets); j++ {
+				b := c.add(offsets[j])
+				if distance2(a, b) == 2 && inCell(a) && inCell(b) {
+					struts = append(struts, strut{a, b})
+				}
+			}
+		}
+	}
+	return struts
+}
+
+// diamondStruts connects the four tetrahedral sites of the diamond cubic
+// cell with their closest face centered cubic sites.
+func diamondStruts() []strut {
+	var struts []strut
+	for _, a := range []node{{1, 1, 1}, {1, 3, 3}, {3, 1, 3}, {3, 3, 1}} {
+		for _, sx := range []int{-1, 1} {
+			for _, sy := range []int{-1, 1} {
+				for _, sz := range []int{-1, 1} {
+					b := a.add(node{sx, sy, sz})
+					if (b[0]+b[1]+b[2])%4 == 0 {
+						struts = append(struts, strut{a, b})
+					}
+				}
+			}
+		}
+	}
+	return struts
+}
+
+func containsNode(nodes []node, n node) bool {
+	for _, o := range nodes {
+		if o == n {
+			return true
+		}
+	}
+	return false
+}
+
+func distance2(a, b node) int {
+	var d int
+	for i := range a {
+		d += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return d
+}
+
+func inCell(n node) bool {
+	for _, v := range n {
+		if v < 0 || v > cellSteps {
+			return false
+		}
+	}
+	return true
+}
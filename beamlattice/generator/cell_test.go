@@ -0,0 +1,75 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package generator
+
+import (
+	"testing"
+)
+
+func TestCell_String(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Cell
+	}{
+		{"cubic", CellCubic},
+		{"bcc", CellBCC},
+		{"fcc", CellFCC},
+		{"octet", CellOctet},
+		{"kelvin", CellKelvin},
+		{"diamond", CellDiamond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.name {
+				t.Errorf("Cell.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func TestCell_struts(t *testing.T) {
+	tests := []struct {
+		c       Cell
+		struts  int
+		valence int
+	}{
+		{CellCubic, 12, 3},
+		{CellBCC, 8, 0},
+		{CellFCC, 24, 0},
+		{CellOctet, 36, 0},
+		{CellKelvin, 36, 3},
+		{CellDiamond, 16, 0},
+		{Cell(100), 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.c.String(), func(t *testing.T) {
+			unique := make(map[[2]node]struct{})
+			valence := make(map[node]int)
+			for _, s := range tt.c.struts() {
+				if !inCell(s[0]) || !inCell(s[1]) || s[0] == s[1] {
+					t.Fatalf("Cell.struts() invalid strut %v", s)
+				}
+				if less(s[1], s[0]) {
+					s = strut{s[1], s[0]}
+				}
+				if _, ok := unique[s]; !ok {
+					valence[s[0]]++
+					valence[s[1]]++
+				}
+				unique[s] = struct{}{}
+			}
+			if len(unique) != tt.struts {
+				t.Errorf("Cell.struts() = %v, want %v", len(unique), tt.struts)
+			}
+			if tt.valence == 0 {
+				return
+			}
+			for n, v := range valence {
+				if v != tt.valence {
+					t.Errorf("Cell.struts() node %v valence = %v, want %v", n, v, tt.valence)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package generator
+
+import (
+	"math"
+
+	"github.com/MosaicManufacturing/go3mf"
+)
+
+const epsilon = 1e-9
+
+// crosses returns true if any end of the segment ab is inside mesh
+// or the segment intersects its surface.
+func crosses(mesh *go3mf.Mesh, a, b go3mf.Point3D) bool {
+	o, d := newVec(a), newVec(b).sub(newVec(a))
+	if inside(mesh, o) || inside(mesh, newVec(b)) {
+		return true
+	}
+	for _, tri := range mesh.Triangles {
+		if s, ok := intersect(mesh, o, d, tri); ok && s >= 0 && s <= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// inside returns true if p is inside mesh,
+// counting the crossings of a ray with an arbitrary direction.
+func inside(mesh *go3mf.Mesh, p vec) bool {
+	dir := vec{0.5773, 0.5779, 0.5767}
+	var crossings int
+	for _, tri := range mesh.Triangles {
+		if s, ok := intersect(mesh, p, dir, tri); ok && s > epsilon {
+			crossings++
+		}
+	}
+	return crossings%2 == 1
+}
+
+// intersect returns the ray parameter where the ray from o with direction dir
+// crosses the mesh triangle, using the Möller–Trumbore algorithm.
+func intersect(mesh *go3mf.Mesh, o, dir vec, tri go3mf.Triangle) (float64, bool) {
+	n := uint32(len(mesh.Vertices))
+	if tri.V1 >= n || tri.V2 >= n || tri.V3 >= n {
+		return 0, false
+	}
+	v1, v2, v3 := newVec(mesh.Vertices[tri.V1]), newVec(mesh.Vertices[tri.V2]), newVec(mesh.Vertices[tri.V3])
+	e1, e2 := v2.sub(v1), v3.sub(v1)
+	p := dir.cross(e2)
+	det := e1.dot(p)
+	if math.Abs(det) < epsilon {
+		return 0, false
+	}
+	inv := 1 / det
+	s := o.sub(v1)
+	u := s.dot(p) * inv
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+	q := s.cross(e1)
+	v := dir.dot(q) * inv
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+	return e2.dot(q) * inv, true
+}
+
+type vec [3]float64
+
+func newVec(p go3mf.Point3D) vec {
+	return vec{float64(p[0]), float64(p[1]), float64(p[2])}
+}
+
+func (v vec) sub(o vec) vec {
+	return vec{v[0] - o[0], v[1] - o[1], v[2] - o[2]}
+}
+
+func (v vec) dot(o vec) float64 {
+	return v[0]*o[0] + v[1]*o[1] + v[2]*o[2]
+}
+
+func (v vec) cross(o vec) vec {
+	return vec{v[1]*o[2] - v[2]*o[1], v[2]*o[0] - v[0]*o[2], v[0]*o[1] - v[1]*o[0]}
+}
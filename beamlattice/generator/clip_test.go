@@ -0,0 +1,32 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+)
+
+func Test_crosses(t *testing.T) {
+	mesh := octahedron(1).Mesh
+	tests := []struct {
+		name string
+		a, b go3mf.Point3D
+		want bool
+	}{
+		{"inside", go3mf.Point3D{0, 0, 0}, go3mf.Point3D{0.1, 0.1, 0.1}, true},
+		{"oneEnd", go3mf.Point3D{0, 0, 0}, go3mf.Point3D{2, 2, 2}, true},
+		{"through", go3mf.Point3D{-2, 0.1, 0}, go3mf.Point3D{2, 0.1, 0}, true},
+		{"outside", go3mf.Point3D{1, 1, 1}, go3mf.Point3D{2, 2, 2}, false},
+		{"miss", go3mf.Point3D{-2, 1, 1}, go3mf.Point3D{2, 1, 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := crosses(mesh, tt.a, tt.b); got != tt.want {
+				t.Errorf("crosses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+// Package generator fills boxes and closed meshes with periodic beam lattices.
+//
+// The generated objects contain a beamlattice.BeamLattice and pass its validation,
+// provided that beamlattice.DefaultExtension is added to the model extensions.
+package generator
+
+import (
+	"errors"
+	"math"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/beamlattice"
+)
+
+// DefaultMinLength is the beam lattice minimum length used
+// when Options.MinLength is not defined.
+const DefaultMinLength = 0.0001
+
+var (
+	ErrCellSize = errors.New("cell size MUST be positive")
+	ErrRadius   = errors.New("beam radius MUST be positive")
+	ErrEmpty    = errors.New("the lattice does not contain any beam")
+)
+
+// RadiusFunc returns the beam radius at a point of the lattice.
+type RadiusFunc func(p go3mf.Point3D) float32
+
+// Uniform returns a RadiusFunc with a constant radius.
+func Uniform(r float32) RadiusFunc {
+	return func(go3mf.Point3D) float32 {
+		return r
+	}
+}
+
+// LinearGrading returns a RadiusFunc that interpolates linearly from r1 to r2
+// along the direction from p1 to p2, clamping the radius outside of them.
+func LinearGrading(p1, p2 go3mf.Point3D, r1, r2 float32) RadiusFunc {
+	d := sub(p2, p1)
+	l2 := dot(d, d)
+	return func(p go3mf.Point3D) float32 {
+		if l2 == 0 {
+			return r1
+		}
+		return lerp(r1, r2, dot(sub(p, p1), d)/l2)
+	}
+}
+
+// RadialGrading returns a RadiusFunc that interpolates linearly from r1 at center
+// to r2 at the given distance from it, clamping the radius further away.
+func RadialGrading(center go3mf.Point3D, distance, r1, r2 float32) RadiusFunc {
+	return func(p go3mf.Point3D) float32 {
+		if distance == 0 {
+			return r2
+		}
+		d := sub(p, center)
+		return lerp(r1, r2, float32(math.Sqrt(float64(dot(d, d))))/distance)
+	}
+}
+
+// A Region groups the beams whose midpoint it contains into a beam set.
+type Region struct {
+	Name       string
+	Identifier string
+	Contains   func(p go3mf.Point3D) bool
+}
+
+// BoxRegion returns a Region containing the points inside box.
+func BoxRegion(name, identifier string, box go3mf.Box) Region {
+	return Region{Name: name, Identifier: identifier, Contains: func(p go3mf.Point3D) bool {
+		for i := range p {
+			if p[i] < box.Min[i] || p[i] > box.Max[i] {
+				return false
+			}
+		}
+		return true
+	}}
+}
+
+// Options defines the generated lattice.
+type Options struct {
+	Cell Cell
+	// CellSize is the target size of the unit cell along each axis.
+	// The number of cells is rounded so the cells fill the box exactly.
+	CellSize go3mf.Point3D
+	// Radius is the default radius of the lattice.
+	Radius float32
+	// Grading overrides the radius at each beam end. Optional.
+	Grading   RadiusFunc
+	CapMode   beamlattice.CapMode
+	MinLength float32
+	// Regions defines the beam sets, in order. A beam can belong to several regions.
+	Regions []Region
+}
+
+// FillBox returns a mesh object with the given id whose beam lattice fills box.
+func FillBox(id uint32, box go3mf.Box, opts Options) (*go3mf.Object, error) {
+	g, err := newGrid(box, opts)
+	if err != nil {
+		return nil, err
+	}
+	return g.object(id, nil)
+}
+
+// FillMesh returns a mesh object with the given id whose beam lattice fills
+// the bounding box of clip and is clipped to its inside.
+// Only the beams that are inside or cross the surface of clip are generated,
+// the exact trimming is left to the consumers through the clipping mesh.
+// clip must be a closed mesh object of type model and
+// belong to the same resources as the generated object.
+func FillMesh(id uint32, clip *go3mf.Object, opts Options) (*go3mf.Object, error) {
+	if clip.Mesh == nil || clip.Type != go3mf.ObjectTypeModel || beamlattice.GetBeamLattice(clip.Mesh) != nil {
+		return nil, beamlattice.ErrLatticeInvalidMesh
+	}
+	g, err := newGrid(clip.Mesh.BoundingBox(), opts)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := g.object(id, clip.Mesh)
+	if err != nil {
+		return nil, err
+	}
+	bl := beamlattice.GetBeamLattice(obj.Mesh)
+	bl.ClipMode = beamlattice.ClipInside
+	bl.ClippingMeshID = clip.ID
+	return obj, nil
+}
+
+type grid struct {
+	opts   Options
+	origin go3mf.Point3D
+	step   go3mf.Point3D
+	count  [3]int
+}
+
+func newGrid(box go3mf.Box, opts Options) (*grid, error) {
+	if opts.Radius <= 0 {
+		return nil, ErrRadius
+	}
+	g := &grid{opts: opts, origin: box.Min}
+	for i := range g.count {
+		if opts.CellSize[i] <= 0 {
+			return nil, ErrCellSize
+		}
+		size := box.Max[i] - box.Min[i]
+		g.count[i] = int(math.Round(float64(size / opts.CellSize[i])))
+		if g.count[i] < 1 {
+			g.count[i] = 1
+		}
+		g.step[i] = size / float32(g.count[i]*cellSteps)
+		if g.step[i] <= 0 {
+			return nil, ErrCellSize
+		}
+	}
+	return g, nil
+}
+
+// object generates the lattice object, only keeping
+// the beams that are inside or cross clip, if not nil.
+func (g *grid) object(id uint32, clip *go3mf.Mesh) (*go3mf.Object, error) {
+	var (
+		vertices []go3mf.Point3D
+		indices  = make(map[node]uint32)
+		beams    = make(map[[2]node]struct{})
+		bl       = &beamlattice.BeamLattice{
+			Radius:    g.opts.Radius,
+			CapMode:   g.opts.CapMode,
+			MinLength: g.opts.MinLength,
+		}
+	)
+	if bl.MinLength == 0 {
+		bl.MinLength = DefaultMinLength
+	}
+	vertex := func(n node) uint32 {
+		if i, ok := indices[n]; ok {
+			return i
+		}
+		vertices = append(vertices, g.point(n))
+		indices[n] = uint32(len(vertices)) - 1
+		return indices[n]
+	}
+	struts := g.opts.Cell.struts()
+	for x := 0; x < g.count[0]; x++ {
+		for y := 0; y < g.count[1]; y++ {
+			for z := 0; z < g.count[2]; z++ {
+				offset := node{x * cellSteps, y * cellSteps, z * cellSteps}
+				for _, s := range struts {
+					a, b := s[0].add(offset), s[1].add(offset)
+					key := [2]node{a, b}
+					if less(b, a) {
+						key = [2]node{b, a}
+					}
+					if _, ok := beams[key]; ok {
+						continue
+					}
+					beams[key] = struct{}{}
+					if clip != nil && !crosses(clip, g.point(a), g.point(b)) {
+						continue
+					}
+					bl.Beams = append(bl.Beams, g.beam(vertex(key[0]), vertex(key[1]), vertices))
+				}
+			}
+		}
+	}
+	if len(bl.Beams) == 0 {
+		return nil, ErrEmpty
+	}
+	for _, r := range g.opts.Regions {
+		set := beamlattice.BeamSet{Name: r.Name, Identifier: r.Identifier}
+		for i, b := range bl.Beams {
+			if r.Contains(midpoint(vertices[b.Indices[0]], vertices[b.Indices[1]])) {
+				set.Refs = append(set.Refs, uint32(i))
+			}
+		}
+		bl.BeamSets = append(bl.BeamSets, set)
+	}
+	return &go3mf.Object{
+		ID:   id,
+		Type: go3mf.ObjectTypeModel,
+		Mesh: &go3mf.Mesh{Vertices: vertices, Any: go3mf.Any{bl}},
+	}, nil
+}
+
+func (g *grid) beam(v1, v2 uint32, vertices []go3mf.Point3D) beamlattice.Beam {
+	b := beamlattice.Beam{
+		Indices: [2]uint32{v1, v2},
+		Radius:  [2]float32{g.opts.Radius, g.opts.Radius},
+		CapMode: [2]beamlattice.CapMode{g.opts.CapMode, g.opts.CapMode},
+	}
+	if g.opts.Grading != nil {
+		b.Radius[0] = g.opts.Grading(vertices[v1])
+		b.Radius[1] = g.opts.Grading(vertices[v2])
+	}
+	return b
+}
+
+func (g *grid) point(n node) go3mf.Point3D {
+	var p go3mf.Point3D
+	for i := range p {
+		p[i] = g.origin[i] + float32(n[i])*g.step[i]
+	}
+	return p
+}
+
+func less(a, b node) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func midpoint(a, b go3mf.Point3D) go3mf.Point3D {
+	return go3mf.Point3D{(a[0] + b[0]) / 2, (a[1] + b[1]) / 2, (a[2] + b[2]) / 2}
+}
+
+func sub(a, b go3mf.Point3D) go3mf.Point3D {
+	return go3mf.Point3D{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func dot(a, b go3mf.Point3D) float32 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func lerp(r1, r2, t float32) float32 {
+	if t <= 0 {
+		return r1
+	}
+	if t >= 1 {
+		return r2
+	}
+	return r1 + (r2-r1)*t
+}
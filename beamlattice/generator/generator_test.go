@@ -0,0 +1,210 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package generator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/beamlattice"
+	"github.com/go-test/deep"
+)
+
+func validate(t *testing.T, objs ...*go3mf.Object) {
+	t.Helper()
+	m := &go3mf.Model{
+		Extensions: []go3mf.Extension{beamlattice.DefaultExtension},
+		Resources:  go3mf.Resources{Objects: objs},
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func octahedron(id uint32) *go3mf.Object {
+	return &go3mf.Object{ID: id, Mesh: &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}},
+		Triangles: []go3mf.Triangle{
+			{V1: 0, V2: 2, V3: 4}, {V1: 2, V2: 1, V3: 4}, {V1: 1, V2: 3, V3: 4}, {V1: 3, V2: 0, V3: 4},
+			{V1: 2, V2: 0, V3: 5}, {V1: 1, V2: 2, V3: 5}, {V1: 3, V2: 1, V3: 5}, {V1: 0, V2: 3, V3: 5},
+		},
+	}}
+}
+
+func TestFillBox(t *testing.T) {
+	box := go3mf.Box{Min: go3mf.Point3D{0, 0, 0}, Max: go3mf.Point3D{2, 2, 2}}
+	tests := []struct {
+		cell     Cell
+		size     float32
+		vertices int
+		beams    int
+	}{
+		{CellCubic, 1, 27, 54},
+		{CellCubic, 2.9, 8, 12},
+		{CellBCC, 1, 35, 64},
+		{CellFCC, 2, 14, 24},
+		{CellOctet, 2, 14, 36},
+		{CellKelvin, 2, 24, 36},
+		{CellDiamond, 2, 14, 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cell.String(), func(t *testing.T) {
+			got, err := FillBox(5, box, Options{
+				Cell: tt.cell, CellSize: go3mf.Point3D{tt.size, tt.size, tt.size}, Radius: 0.1,
+			})
+			if err != nil {
+				t.Fatalf("FillBox() error = %v", err)
+			}
+			bl := beamlattice.GetBeamLattice(got.Mesh)
+			if len(got.Mesh.Vertices) != tt.vertices || len(bl.Beams) != tt.beams {
+				t.Errorf("FillBox() = %d vertices and %d beams, want %d and %d",
+					len(got.Mesh.Vertices), len(bl.Beams), tt.vertices, tt.beams)
+			}
+			if got.ID != 5 || bl.Radius != 0.1 || bl.MinLength != DefaultMinLength || bl.ClipMode != beamlattice.ClipNone {
+				t.Errorf("FillBox() = %v", bl)
+			}
+			for _, v := range got.Mesh.Vertices {
+				for i := range v {
+					if v[i] < box.Min[i] || v[i] > box.Max[i] {
+						t.Fatalf("FillBox() vertex %v outside of the box", v)
+					}
+				}
+			}
+			validate(t, got)
+		})
+	}
+}
+
+func TestFillBox_options(t *testing.T) {
+	box := go3mf.Box{Min: go3mf.Point3D{0, 0, 0}, Max: go3mf.Point3D{2, 1, 1}}
+	got, err := FillBox(1, box, Options{
+		CellSize: go3mf.Point3D{1, 1, 1}, Radius: 0.2, MinLength: 0.01, CapMode: beamlattice.CapModeButt,
+		Grading: LinearGrading(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{2, 0, 0}, 0.1, 0.3),
+		Regions: []Region{
+			BoxRegion("left", "l", go3mf.Box{Min: go3mf.Point3D{0, 0, 0}, Max: go3mf.Point3D{1, 1, 1}}),
+			{Name: "none", Contains: func(go3mf.Point3D) bool { return false }},
+		},
+	})
+	if err != nil {
+		t.Fatalf("FillBox() error = %v", err)
+	}
+	bl := beamlattice.GetBeamLattice(got.Mesh)
+	if bl.MinLength != 0.01 || bl.CapMode != beamlattice.CapModeButt {
+		t.Errorf("FillBox() = %v", bl)
+	}
+	for _, b := range bl.Beams {
+		for i, v := range b.Indices {
+			if want := 0.1 + got.Mesh.Vertices[v].X()*0.1; math.Abs(float64(b.Radius[i]-want)) > 1e-6 {
+				t.Errorf("FillBox() radius = %v, want %v", b.Radius[i], want)
+			}
+		}
+		if b.CapMode != [2]beamlattice.CapMode{beamlattice.CapModeButt, beamlattice.CapModeButt} {
+			t.Errorf("FillBox() cap = %v", b.CapMode)
+		}
+	}
+	if len(bl.BeamSets) != 2 {
+		t.Fatalf("FillBox() beam sets = %v", bl.BeamSets)
+	}
+	if set := bl.BeamSets[0]; set.Name != "left" || set.Identifier != "l" || len(set.Refs) != 12 {
+		t.Errorf("FillBox() beam set = %v", set)
+	}
+	if set := bl.BeamSets[1]; set.Name != "none" || len(set.Refs) != 0 {
+		t.Errorf("FillBox() beam set = %v", set)
+	}
+	validate(t, got)
+}
+
+func TestFillMesh(t *testing.T) {
+	clip := octahedron(1)
+	got, err := FillMesh(2, clip, Options{Cell: CellCubic, CellSize: go3mf.Point3D{0.5, 0.5, 0.5}, Radius: 0.05})
+	if err != nil {
+		t.Fatalf("FillMesh() error = %v", err)
+	}
+	bl := beamlattice.GetBeamLattice(got.Mesh)
+	if bl.ClipMode != beamlattice.ClipInside || bl.ClippingMeshID != 1 {
+		t.Errorf("FillMesh() = %v", bl)
+	}
+	full, _ := FillBox(2, clip.Mesh.BoundingBox(), Options{Cell: CellCubic, CellSize: go3mf.Point3D{0.5, 0.5, 0.5}, Radius: 0.05})
+	if n := len(bl.Beams); n == 0 || n >= len(beamlattice.GetBeamLattice(full.Mesh).Beams) {
+		t.Errorf("FillMesh() beams = %v", n)
+	}
+	for _, b := range bl.Beams {
+		if !crosses(clip.Mesh, got.Mesh.Vertices[b.Indices[0]], got.Mesh.Vertices[b.Indices[1]]) {
+			t.Errorf("FillMesh() beam %v outside of the clipping mesh", b)
+		}
+	}
+	for _, v := range got.Mesh.Vertices {
+		if v == (go3mf.Point3D{1, 1, 1}) {
+			t.Errorf("FillMesh() unused vertex %v", v)
+		}
+	}
+	validate(t, clip, got)
+}
+
+func TestFill_error(t *testing.T) {
+	box := go3mf.Box{Max: go3mf.Point3D{1, 1, 1}}
+	opts := Options{CellSize: go3mf.Point3D{1, 1, 1}, Radius: 1}
+	tests := []struct {
+		name string
+		fill func() (*go3mf.Object, error)
+		want error
+	}{
+		{"radius", func() (*go3mf.Object, error) {
+			return FillBox(1, box, Options{CellSize: opts.CellSize})
+		}, ErrRadius},
+		{"cellSize", func() (*go3mf.Object, error) {
+			return FillBox(1, box, Options{CellSize: go3mf.Point3D{1, 0, 1}, Radius: 1})
+		}, ErrCellSize},
+		{"emptyBox", func() (*go3mf.Object, error) {
+			return FillBox(1, go3mf.Box{Max: go3mf.Point3D{1, 0, 1}}, opts)
+		}, ErrCellSize},
+		{"noMesh", func() (*go3mf.Object, error) {
+			return FillMesh(1, &go3mf.Object{ID: 2}, opts)
+		}, beamlattice.ErrLatticeInvalidMesh},
+		{"lattice", func() (*go3mf.Object, error) {
+			return FillMesh(1, &go3mf.Object{ID: 2, Mesh: &go3mf.Mesh{Any: go3mf.Any{&beamlattice.BeamLattice{}}}}, opts)
+		}, beamlattice.ErrLatticeInvalidMesh},
+		{"empty", func() (*go3mf.Object, error) {
+			clip := octahedron(2)
+			clip.Mesh.Vertices[0] = go3mf.Point3D{-1, 1, 1}
+			clip.Mesh.Triangles = clip.Mesh.Triangles[:0]
+			return FillMesh(1, clip, opts)
+		}, ErrEmpty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fill()
+			if err != tt.want || got != nil {
+				t.Errorf("Fill() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrading(t *testing.T) {
+	tests := []struct {
+		name string
+		f    RadiusFunc
+		p    go3mf.Point3D
+		want float32
+	}{
+		{"uniform", Uniform(2), go3mf.Point3D{5, 5, 5}, 2},
+		{"linearStart", LinearGrading(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{0, 0, 4}, 1, 3), go3mf.Point3D{1, 1, -1}, 1},
+		{"linearMiddle", LinearGrading(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{0, 0, 4}, 1, 3), go3mf.Point3D{1, 1, 2}, 2},
+		{"linearEnd", LinearGrading(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{0, 0, 4}, 1, 3), go3mf.Point3D{1, 1, 8}, 3},
+		{"linearDegenerate", LinearGrading(go3mf.Point3D{1, 1, 1}, go3mf.Point3D{1, 1, 1}, 1, 3), go3mf.Point3D{1, 1, 8}, 1},
+		{"radialCenter", RadialGrading(go3mf.Point3D{1, 1, 1}, 2, 3, 1), go3mf.Point3D{1, 1, 1}, 3},
+		{"radialMiddle", RadialGrading(go3mf.Point3D{1, 1, 1}, 2, 3, 1), go3mf.Point3D{1, 2, 1}, 2},
+		{"radialOut", RadialGrading(go3mf.Point3D{1, 1, 1}, 2, 3, 1), go3mf.Point3D{5, 1, 1}, 1},
+		{"radialDegenerate", RadialGrading(go3mf.Point3D{1, 1, 1}, 0, 3, 1), go3mf.Point3D{1, 1, 1}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(tt.f(tt.p), tt.want); diff != nil {
+				t.Errorf("RadiusFunc() = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,426 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package beamlattice
+
+import (
+	"math"
+	"sort"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+)
+
+// DefaultSegments is the number of segments used to approximate
+// the beam sections when no tolerance is defined.
+const DefaultSegments = 16
+
+const (
+	minSegments = 3
+	maxSegments = 256
+	epsilon     = 1e-9
+)
+
+// TessellateOptions defines how a beam lattice is converted into a mesh.
+type TessellateOptions struct {
+	// Tolerance is the maximum distance between the tessellated and the exact
+	// surfaces, which defines the number of segments of each beam section.
+	// If zero, DefaultSegments are used.
+	Tolerance float32
+	// UseRepresentation returns a copy of the representation mesh,
+	// if the lattice defines one, instead of tessellating the beams.
+	UseRepresentation bool
+}
+
+// Tessellate converts the beam lattice of obj into a mesh,
+// which also contains the vertices and triangles of the object mesh.
+//
+// Each beam is a truncated cone closed by its caps: butt caps are flat,
+// hemisphere caps are domes sharing the beam end vertices and sphere caps
+// close the beam with a flat disk covered by a sphere centered at the node.
+// Balls are spheres centered at the nodes according to BallMode.
+// Every beam and sphere is a separate closed and manifold shell,
+// overlapping shells are not merged.
+//
+// If the lattice is clipped, the beam axes are split where they cross
+// the clipping mesh and only the pieces inside, for ClipInside, or outside,
+// for ClipOutside, are kept, closed with butt caps at the cuts.
+// The cuts are perpendicular to the beam axes, so they deviate from
+// the clipping surface up to the beam radius.
+func Tessellate(m *go3mf.Model, path string, obj *go3mf.Object, opts TessellateOptions) (*go3mf.Mesh, error) {
+	if obj.Mesh == nil {
+		return nil, ErrNoBeamLattice
+	}
+	bl := GetBeamLattice(obj.Mesh)
+	if bl == nil {
+		return nil, ErrNoBeamLattice
+	}
+	if opts.UseRepresentation && bl.RepresentationMeshID != 0 {
+		rep, err := findRefMesh(m, path, bl.RepresentationMeshID)
+		if err != nil {
+			return nil, errors.Wrap(err, bl)
+		}
+		return copyMesh(rep), nil
+	}
+	t := tessellator{
+		tol:     float64(opts.Tolerance),
+		mesh:    copyMesh(obj.Mesh),
+		spheres: make(map[[4]float64]struct{}),
+	}
+	if bl.ClipMode != ClipNone {
+		clip, err := findRefMesh(m, path, bl.ClippingMeshID)
+		if err != nil {
+			return nil, errors.Wrap(err, bl)
+		}
+		t.clip, t.keepInside = clip, bl.ClipMode == ClipInside
+	}
+	vertices := obj.Mesh.Vertices
+	for i, b := range bl.Beams {
+		if int(b.Indices[0]) >= len(vertices) || int(b.Indices[1]) >= len(vertices) {
+			return nil, errors.Wrap(errors.WrapIndex(errors.ErrIndexOutOfBounds, b, i), bl)
+		}
+		t.beam(newVec(vertices[b.Indices[0]]), newVec(vertices[b.Indices[1]]), b)
+	}
+	if err := t.balls(vertices, bl); err != nil {
+		return nil, errors.Wrap(err, bl)
+	}
+	return t.mesh, nil
+}
+
+func findRefMesh(m *go3mf.Model, path string, id uint32) (*go3mf.Mesh, error) {
+	obj, ok := m.FindObject(path, id)
+	if !ok {
+		return nil, errors.ErrMissingResource
+	}
+	if obj.Mesh == nil {
+		return nil, ErrLatticeInvalidMesh
+	}
+	return obj.Mesh, nil
+}
+
+func copyMesh(mesh *go3mf.Mesh) *go3mf.Mesh {
+	return &go3mf.Mesh{
+		Vertices:  append([]go3mf.Point3D(nil), mesh.Vertices...),
+		Triangles: append([]go3mf.Triangle(nil), mesh.Triangles...),
+	}
+}
+
+type tessellator struct {
+	tol        float64
+	mesh       *go3mf.Mesh
+	clip       *go3mf.Mesh
+	keepInside bool
+	spheres    map[[4]float64]struct{}
+}
+
+// interval is a piece of a beam axis, in [0, 1] units.
+type interval struct {
+	t0, t1 float64
+}
+
+func (t *tessellator) beam(p1, p2 vec, b Beam) {
+	if p1 == p2 {
+		return
+	}
+	pieces := []interval{{0, 1}}
+	if t.clip != nil {
+		pieces = t.clipSegment(p1, p2)
+	}
+	r1, r2 := float64(b.Radius[0]), float64(b.Radius[1])
+	for _, pc := range pieces {
+		cap1, cap2 := CapModeButt, CapModeButt
+		if pc.t0 == 0 {
+			cap1 = b.CapMode[0]
+		}
+		if pc.t1 == 1 {
+			cap2 = b.CapMode[1]
+		}
+		t.frustum(p1.lerp(p2, pc.t0), p1.lerp(p2, pc.t1), r1+(r2-r1)*pc.t0, r1+(r2-r1)*pc.t1, cap1, cap2)
+		if cap1 == CapModeSphere {
+			t.sphere(p1, r1)
+		}
+		if cap2 == CapModeSphere {
+			t.sphere(p2, r2)
+		}
+	}
+}
+
+func (t *tessellator) balls(vertices []go3mf.Point3D, bl *BeamLattice) error {
+	switch bl.BallMode {
+	case BallModeMixed:
+		for i, b := range bl.Balls {
+			if int(b.Index) >= len(vertices) {
+				return errors.WrapIndex(errors.ErrIndexOutOfBounds, b, i)
+			}
+			r := b.Radius
+			if r == 0 {
+				r = bl.BallRadius
+			}
+			if c := newVec(vertices[b.Index]); t.keep(c) {
+				t.sphere(c, float64(r))
+			}
+		}
+	case BallModeAll:
+		radius := make(map[uint32]float32, len(bl.Balls))
+		for _, b := range bl.Balls {
+			if b.Radius != 0 {
+				radius[b.Index] = b.Radius
+			}
+		}
+		for _, b := range bl.Beams {
+			for _, idx := range b.Indices {
+				r, ok := radius[idx]
+				if !ok {
+					r = bl.BallRadius
+				}
+				if c := newVec(vertices[idx]); t.keep(c) {
+					t.sphere(c, float64(r))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// segments returns the number of segments needed to approximate
+// a circle of radius r within the tolerance.
+func (t *tessellator) segments(r float64) int {
+	if t.tol <= 0 || r <= 0 {
+		return DefaultSegments
+	}
+	if t.tol >= r {
+		return minSegments
+	}
+	n := int(math.Ceil(math.Pi / math.Acos(1-t.tol/r)))
+	if n < minSegments {
+		return minSegments
+	}
+	if n > maxSegments {
+		return maxSegments
+	}
+	return n
+}
+
+// frustum adds a truncated cone from a to b closed by the caps.
+func (t *tessellator) frustum(a, b vec, ra, rb float64, capA, capB CapMode) {
+	if ra <= 0 && rb <= 0 {
+		return
+	}
+	d := b.sub(a).normalize()
+	u, w := basis(d)
+	n := t.segments(math.Max(ra, rb))
+	ringA, ringB := t.ring(a, u, w, ra, n), t.ring(b, u, w, rb, n)
+	t.bridge(ringA, ringB)
+	t.cap(ringA, a, d.scale(-1), u, w, ra, n, capA, false)
+	t.cap(ringB, b, d, u, w, rb, n, capB, true)
+}
+
+// cap closes the ring centered at c, where d is the outward axis direction.
+// The rings are oriented along the beam axis, so the bridges are
+// reversed at the start of the beam.
+func (t *tessellator) cap(ring []uint32, c, d, u, w vec, r float64, n int, mode CapMode, end bool) {
+	if len(ring) == 1 {
+		return
+	}
+	link := func(prev, next []uint32) {
+		if end {
+			t.bridge(prev, next)
+		} else {
+			t.bridge(next, prev)
+		}
+	}
+	if mode != CapModeHemisphere {
+		link(ring, []uint32{t.vertex(c)})
+		return
+	}
+	steps := n / 4
+	if steps < 1 {
+		steps = 1
+	}
+	prev := ring
+	for k := 1; k < steps; k++ {
+		phi := float64(k) * math.Pi / 2 / float64(steps)
+		next := t.ring(c.add(d.scale(r*math.Sin(phi))), u, w, r*math.Cos(phi), n)
+		link(prev, next)
+		prev = next
+	}
+	link(prev, []uint32{t.vertex(c.add(d.scale(r)))})
+}
+
+// sphere adds a sphere centered at c, unless an equal one already exists.
+func (t *tessellator) sphere(c vec, r float64) {
+	key := [4]float64{c[0], c[1], c[2], r}
+	if _, ok := t.spheres[key]; ok || r <= 0 {
+		return
+	}
+	t.spheres[key] = struct{}{}
+	d, u, w := vec{0, 0, 1}, vec{1, 0, 0}, vec{0, 1, 0}
+	n := t.segments(r)
+	steps := n / 2
+	if steps < 2 {
+		steps = 2
+	}
+	prev := []uint32{t.vertex(c.sub(d.scale(r)))}
+	for k := 1; k < steps; k++ {
+		phi := -math.Pi/2 + float64(k)*math.Pi/float64(steps)
+		next := t.ring(c.add(d.scale(r*math.Sin(phi))), u, w, r*math.Cos(phi), n)
+		t.bridge(prev, next)
+		prev = next
+	}
+	t.bridge(prev, []uint32{t.vertex(c.add(d.scale(r)))})
+}
+
+// ring adds n vertices around c in the plane defined by u and w,
+// or a single vertex if the radius is not positive.
+func (t *tessellator) ring(c, u, w vec, r float64, n int) []uint32 {
+	if r <= 0 {
+		return []uint32{t.vertex(c)}
+	}
+	ring := make([]uint32, n)
+	for i := range ring {
+		theta := 2 * math.Pi * float64(i) / float64(n)
+		ring[i] = t.vertex(c.add(u.scale(r * math.Cos(theta))).add(w.scale(r * math.Sin(theta))))
+	}
+	return ring
+}
+
+// bridge connects two rings, the upper one being further along the axis
+// direction d, with u, w and d defining a right-handed basis.
+// Any of them can be a single vertex.
+func (t *tessellator) bridge(lower, upper []uint32) {
+	n := len(lower)
+	if len(upper) > n {
+		n = len(upper)
+	}
+	for i := 0; i < n; i++ {
+		l0, l1 := lower[i%len(lower)], lower[(i+1)%len(lower)]
+		u0, u1 := upper[i%len(upper)], upper[(i+1)%len(upper)]
+		if len(lower) > 1 {
+			t.mesh.Triangles = append(t.mesh.Triangles, go3mf.Triangle{V1: l0, V2: l1, V3: u0})
+		}
+		if len(upper) > 1 {
+			t.mesh.Triangles = append(t.mesh.Triangles, go3mf.Triangle{V1: l1, V2: u1, V3: u0})
+		}
+	}
+}
+
+func (t *tessellator) vertex(v vec) uint32 {
+	t.mesh.Vertices = append(t.mesh.Vertices, go3mf.Point3D{float32(v[0]), float32(v[1]), float32(v[2])})
+	return uint32(len(t.mesh.Vertices) - 1)
+}
+
+// clipSegment returns the pieces of the segment from p1 to p2 to keep.
+func (t *tessellator) clipSegment(p1, p2 vec) []interval {
+	ts := []float64{0, 1}
+	dir := p2.sub(p1)
+	for _, tri := range t.clip.Triangles {
+		if s, ok := t.intersect(p1, dir, tri); ok && s > 0 && s < 1 {
+			ts = append(ts, s)
+		}
+	}
+	sort.Float64s(ts)
+	var pieces []interval
+	for i := 1; i < len(ts); i++ {
+		t0, t1 := ts[i-1], ts[i]
+		if t1-t0 < epsilon || !t.keep(p1.lerp(p2, (t0+t1)/2)) {
+			continue
+		}
+		if len(pieces) != 0 && pieces[len(pieces)-1].t1 == t0 {
+			pieces[len(pieces)-1].t1 = t1
+		} else {
+			pieces = append(pieces, interval{t0, t1})
+		}
+	}
+	return pieces
+}
+
+// keep returns true if the point is not removed by the clipping mesh.
+func (t *tessellator) keep(p vec) bool {
+	return t.clip == nil || t.inside(p) == t.keepInside
+}
+
+// inside returns true if p is inside the clipping mesh,
+// counting the crossings of a ray with an arbitrary direction.
+func (t *tessellator) inside(p vec) bool {
+	dir := vec{0.5773, 0.5779, 0.5767}
+	var crossings int
+	for _, tri := range t.clip.Triangles {
+		if s, ok := t.intersect(p, dir, tri); ok && s > epsilon {
+			crossings++
+		}
+	}
+	return crossings%2 == 1
+}
+
+// intersect returns the ray parameter where the ray from o with direction dir
+// crosses the clipping mesh triangle, using the Möller–Trumbore algorithm.
+func (t *tessellator) intersect(o, dir vec, tri go3mf.Triangle) (float64, bool) {
+	n := uint32(len(t.clip.Vertices))
+	if tri.V1 >= n || tri.V2 >= n || tri.V3 >= n {
+		return 0, false
+	}
+	v1, v2, v3 := newVec(t.clip.Vertices[tri.V1]), newVec(t.clip.Vertices[tri.V2]), newVec(t.clip.Vertices[tri.V3])
+	e1, e2 := v2.sub(v1), v3.sub(v1)
+	p := dir.cross(e2)
+	det := e1.dot(p)
+	if math.Abs(det) < epsilon {
+		return 0, false
+	}
+	inv := 1 / det
+	s := o.sub(v1)
+	a := s.dot(p) * inv
+	if a < 0 || a > 1 {
+		return 0, false
+	}
+	q := s.cross(e1)
+	b := dir.dot(q) * inv
+	if b < 0 || a+b > 1 {
+		return 0, false
+	}
+	return e2.dot(q) * inv, true
+}
+
+type vec [3]float64
+
+func newVec(p go3mf.Point3D) vec {
+	return vec{float64(p[0]), float64(p[1]), float64(p[2])}
+}
+
+func (v vec) add(o vec) vec {
+	return vec{v[0] + o[0], v[1] + o[1], v[2] + o[2]}
+}
+
+func (v vec) sub(o vec) vec {
+	return vec{v[0] - o[0], v[1] - o[1], v[2] - o[2]}
+}
+
+func (v vec) scale(f float64) vec {
+	return vec{v[0] * f, v[1] * f, v[2] * f}
+}
+
+func (v vec) dot(o vec) float64 {
+	return v[0]*o[0] + v[1]*o[1] + v[2]*o[2]
+}
+
+func (v vec) cross(o vec) vec {
+	return vec{v[1]*o[2] - v[2]*o[1], v[2]*o[0] - v[0]*o[2], v[0]*o[1] - v[1]*o[0]}
+}
+
+func (v vec) normalize() vec {
+	return v.scale(1 / math.Sqrt(v.dot(v)))
+}
+
+func (v vec) lerp(o vec, f float64) vec {
+	return v.add(o.sub(v).scale(f))
+}
+
+// basis returns two unit vectors that form
+// a right-handed orthonormal basis with d.
+func basis(d vec) (vec, vec) {
+	a := vec{1, 0, 0}
+	if math.Abs(d[0]) > 0.9 {
+		a = vec{0, 1, 0}
+	}
+	u := a.sub(d.scale(a.dot(d))).normalize()
+	return u, d.cross(u)
+}
@@ -0,0 +1,263 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package beamlattice
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/go-test/deep"
+)
+
+// checkClosed verifies that every edge is shared by exactly two triangles
+// with opposite orientation and returns the enclosed volume.
+func checkClosed(t *testing.T, mesh *go3mf.Mesh) float64 {
+	t.Helper()
+	edges := make(map[[2]uint32]int)
+	var volume float64
+	for _, tri := range mesh.Triangles {
+		if tri.V1 == tri.V2 || tri.V2 == tri.V3 || tri.V1 == tri.V3 {
+			t.Fatalf("degenerate triangle %v", tri)
+		}
+		edges[[2]uint32{tri.V1, tri.V2}]++
+		edges[[2]uint32{tri.V2, tri.V3}]++
+		edges[[2]uint32{tri.V3, tri.V1}]++
+		a, b, c := newVec(mesh.Vertices[tri.V1]), newVec(mesh.Vertices[tri.V2]), newVec(mesh.Vertices[tri.V3])
+		volume += a.dot(b.cross(c)) / 6
+	}
+	for e, n := range edges {
+		if n != 1 || edges[[2]uint32{e[1], e[0]}] != 1 {
+			t.Fatalf("edge %v is not manifold", e)
+		}
+	}
+	return volume
+}
+
+func latticeModel(bl *BeamLattice, vertices ...go3mf.Point3D) (*go3mf.Model, *go3mf.Object) {
+	obj := &go3mf.Object{ID: 1, Mesh: &go3mf.Mesh{Vertices: vertices, Any: go3mf.Any{bl}}}
+	return &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{obj}}}, obj
+}
+
+func cube(id uint32, min, max float32) *go3mf.Object {
+	return &go3mf.Object{ID: id, Mesh: &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{
+			{min, min, min}, {max, min, min}, {max, max, min}, {min, max, min},
+			{min, min, max}, {max, min, max}, {max, max, max}, {min, max, max},
+		},
+		Triangles: []go3mf.Triangle{
+			{V1: 0, V2: 2, V3: 1}, {V1: 0, V2: 3, V3: 2}, {V1: 4, V2: 5, V3: 6}, {V1: 4, V2: 6, V3: 7},
+			{V1: 0, V2: 1, V3: 5}, {V1: 0, V2: 5, V3: 4}, {V1: 1, V2: 2, V3: 6}, {V1: 1, V2: 6, V3: 5},
+			{V1: 2, V2: 3, V3: 7}, {V1: 2, V2: 7, V3: 6}, {V1: 3, V2: 0, V3: 4}, {V1: 3, V2: 4, V3: 7},
+		},
+	}}
+}
+
+func TestTessellate(t *testing.T) {
+	const length, r = 10.0, 1.0
+	cylinder := math.Pi * r * r * length
+	sphere := 4.0 / 3 * math.Pi * r * r * r
+	tests := []struct {
+		name      string
+		caps      [2]CapMode
+		radius    [2]float32
+		ball      BallMode
+		vertices  int
+		triangles int
+		volume    float64
+	}{
+		{"butt", [2]CapMode{CapModeButt, CapModeButt}, [2]float32{r, r}, BallModeNone, 2 + 2*16 + 2, 4 * 16, cylinder},
+		{"hemisphere", [2]CapMode{CapModeHemisphere, CapModeHemisphere}, [2]float32{r, r}, BallModeNone, 2 + 2*4*16 + 2, 2*16 + 2*(2*3*16+16), cylinder + sphere},
+		{"sphere", [2]CapMode{CapModeSphere, CapModeButt}, [2]float32{r, r}, BallModeNone, 2 + 2*16 + 2 + 7*16 + 2, 4*16 + 2*16 + 2*6*16, cylinder + sphere},
+		{"cone", [2]CapMode{CapModeButt, CapModeButt}, [2]float32{r, 0}, BallModeNone, 2 + 16 + 1 + 1, 2 * 16, cylinder / 3},
+		{"balls", [2]CapMode{CapModeButt, CapModeButt}, [2]float32{r, r}, BallModeAll, 2 + 2*16 + 2 + 2*(7*16+2), 4*16 + 2*(2*16+6*2*16), cylinder + 2*sphere},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, obj := latticeModel(&BeamLattice{
+				Radius: r, BallMode: tt.ball, BallRadius: r,
+				Beams: []Beam{{Indices: [2]uint32{0, 1}, Radius: tt.radius, CapMode: tt.caps}},
+			}, go3mf.Point3D{0, 0, 0}, go3mf.Point3D{length, 0, 0})
+			got, err := Tessellate(m, "", obj, TessellateOptions{})
+			if err != nil {
+				t.Fatalf("Tessellate() error = %v", err)
+			}
+			if len(got.Vertices) != tt.vertices || len(got.Triangles) != tt.triangles {
+				t.Errorf("Tessellate() = %d vertices and %d triangles, want %d and %d",
+					len(got.Vertices), len(got.Triangles), tt.vertices, tt.triangles)
+			}
+			// Overlapping shells are counted twice, so the volume is only an upper bound.
+			if volume := checkClosed(t, got); volume <= 0 || volume > tt.volume*1.01 || volume < tt.volume*0.9 {
+				t.Errorf("Tessellate() volume = %v, want ~%v", volume, tt.volume)
+			}
+		})
+	}
+}
+
+func TestTessellate_keepsMesh(t *testing.T) {
+	m, obj := latticeModel(&BeamLattice{
+		Radius: 1, Beams: []Beam{{Indices: [2]uint32{0, 1}, Radius: [2]float32{1, 1}, CapMode: [2]CapMode{CapModeButt, CapModeButt}}},
+	}, go3mf.Point3D{0, 0, 0}, go3mf.Point3D{0, 0, 5}, go3mf.Point3D{5, 0, 0})
+	obj.Mesh.Triangles = []go3mf.Triangle{{V1: 0, V2: 1, V3: 2, PID: 3}}
+	got, err := Tessellate(m, "", obj, TessellateOptions{})
+	if err != nil {
+		t.Fatalf("Tessellate() error = %v", err)
+	}
+	if diff := deep.Equal(got.Vertices[:3], obj.Mesh.Vertices); diff != nil {
+		t.Errorf("Tessellate() vertices = %v", diff)
+	}
+	if diff := deep.Equal(got.Triangles[0], obj.Mesh.Triangles[0]); diff != nil {
+		t.Errorf("Tessellate() triangles = %v", diff)
+	}
+	if len(got.Any) != 0 {
+		t.Errorf("Tessellate() kept the beam lattice")
+	}
+	checkClosed(t, &go3mf.Mesh{Vertices: got.Vertices, Triangles: got.Triangles[1:]})
+}
+
+func TestTessellate_clip(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   ClipMode
+		ball   BallMode
+		pieces [][2]float32
+	}{
+		{"inside", ClipInside, BallModeNone, [][2]float32{{-1, 1}}},
+		{"outside", ClipOutside, BallModeNone, [][2]float32{{-5.5, -4.5}, {-5, -1}, {1, 5}, {4.5, 5.5}}},
+		{"insideBalls", ClipInside, BallModeAll, [][2]float32{{-1, 1}}},
+		{"outsideBalls", ClipOutside, BallModeAll, [][2]float32{{-5.5, -4.5}, {-5, -1}, {1, 5}, {4.5, 5.5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, obj := latticeModel(&BeamLattice{
+				Radius: 0.5, ClipMode: tt.mode, ClippingMeshID: 2, BallMode: tt.ball, BallRadius: 0.5,
+				Beams: []Beam{{Indices: [2]uint32{0, 1}, Radius: [2]float32{0.5, 0.5}, CapMode: [2]CapMode{CapModeSphere, CapModeSphere}}},
+			}, go3mf.Point3D{-5, 0, 0}, go3mf.Point3D{5, 0, 0})
+			m.Resources.Objects = append(m.Resources.Objects, cube(2, -1, 1))
+			got, err := Tessellate(m, "", obj, TessellateOptions{Tolerance: 0.01})
+			if err != nil {
+				t.Fatalf("Tessellate() error = %v", err)
+			}
+			checkClosed(t, got)
+			var pieces [][2]float32
+			visited := make(map[uint32]bool)
+			for _, tri := range got.Triangles {
+				if visited[tri.V1] {
+					continue
+				}
+				shell := connected(got, tri.V1, visited)
+				min, max := float32(math.Inf(1)), float32(math.Inf(-1))
+				for _, v := range shell {
+					x := got.Vertices[v].X()
+					if x < min {
+						min = x
+					}
+					if x > max {
+						max = x
+					}
+				}
+				pieces = append(pieces, [2]float32{min, max})
+			}
+			sort.Slice(pieces, func(i, j int) bool { return pieces[i][0] < pieces[j][0] })
+			if diff := deep.Equal(pieces, tt.pieces); diff != nil {
+				t.Errorf("Tessellate() = %v", diff)
+			}
+		})
+	}
+}
+
+// connected returns the vertices of the shell that contains v.
+func connected(mesh *go3mf.Mesh, v uint32, visited map[uint32]bool) []uint32 {
+	adj := make(map[uint32][]uint32)
+	for _, tri := range mesh.Triangles {
+		adj[tri.V1] = append(adj[tri.V1], tri.V2, tri.V3)
+		adj[tri.V2] = append(adj[tri.V2], tri.V1, tri.V3)
+		adj[tri.V3] = append(adj[tri.V3], tri.V1, tri.V2)
+	}
+	shell := []uint32{v}
+	visited[v] = true
+	for i := 0; i < len(shell); i++ {
+		for _, n := range adj[shell[i]] {
+			if !visited[n] {
+				visited[n] = true
+				shell = append(shell, n)
+			}
+		}
+	}
+	return shell
+}
+
+func TestTessellate_representation(t *testing.T) {
+	m, obj := latticeModel(&BeamLattice{Radius: 1, RepresentationMeshID: 2}, go3mf.Point3D{0, 0, 0}, go3mf.Point3D{1, 0, 0})
+	rep := cube(2, 0, 1)
+	m.Resources.Objects = append(m.Resources.Objects, rep)
+	got, err := Tessellate(m, "", obj, TessellateOptions{UseRepresentation: true})
+	if err != nil {
+		t.Fatalf("Tessellate() error = %v", err)
+	}
+	if diff := deep.Equal(got, rep.Mesh); diff != nil {
+		t.Errorf("Tessellate() = %v", diff)
+	}
+	got.Vertices[0] = go3mf.Point3D{5, 5, 5}
+	if rep.Mesh.Vertices[0] == got.Vertices[0] {
+		t.Error("Tessellate() must return a copy of the representation mesh")
+	}
+}
+
+func TestTessellate_error(t *testing.T) {
+	tests := []struct {
+		name string
+		bl   *BeamLattice
+		want error
+	}{
+		{"noLattice", nil, ErrNoBeamLattice},
+		{"beamIndex", &BeamLattice{Beams: []Beam{{Indices: [2]uint32{0, 2}}}},
+			fmt.Errorf("BeamLattice@Beam#0: %v", errors.ErrIndexOutOfBounds)},
+		{"ballIndex", &BeamLattice{BallMode: BallModeMixed, Balls: []Ball{{Index: 3}}},
+			fmt.Errorf("BeamLattice@Ball#0: %v", errors.ErrIndexOutOfBounds)},
+		{"clipping", &BeamLattice{ClipMode: ClipInside, ClippingMeshID: 5},
+			fmt.Errorf("BeamLattice: %v", errors.ErrMissingResource)},
+		{"representation", &BeamLattice{RepresentationMeshID: 3},
+			fmt.Errorf("BeamLattice: %v", ErrLatticeInvalidMesh)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, obj := latticeModel(tt.bl, go3mf.Point3D{0, 0, 0}, go3mf.Point3D{1, 0, 0})
+			if tt.bl == nil {
+				obj.Mesh.Any = nil
+			}
+			m.Resources.Objects = append(m.Resources.Objects, &go3mf.Object{ID: 3})
+			_, err := Tessellate(m, "", obj, TessellateOptions{UseRepresentation: true})
+			if err == nil || err.Error() != tt.want.Error() {
+				t.Errorf("Tessellate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tessellator_segments(t *testing.T) {
+	tests := []struct {
+		name string
+		tol  float64
+		r    float64
+		want int
+	}{
+		{"default", 0, 1, DefaultSegments},
+		{"zeroRadius", 0.1, 0, DefaultSegments},
+		{"coarse", 2, 1, minSegments},
+		{"half", 0.5, 1, minSegments},
+		{"fine", 0.01, 1, 23},
+		{"max", 1e-9, 1, maxSegments},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&tessellator{tol: tt.tol}).segments(tt.r); got != tt.want {
+				t.Errorf("tessellator.segments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -36,7 +36,7 @@ func validateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
 	if bl.Radius == 0 {
 		errs = errors.Append(errs, errors.NewMissingFieldError(attrRadius))
 	}
-	if bl.ClipMode == ClipNone && bl.ClippingMeshID == 0 {
+	if bl.ClipMode != ClipNone && bl.ClippingMeshID == 0 {
 		errs = errors.Append(errs, ErrLatticeClippedNoMesh)
 	}
 	if bl.ClippingMeshID != 0 {
@@ -55,13 +55,20 @@ func validateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
 				errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, b, i))
 			}
 		}
-		if b.Radius[0] != 0 && b.Radius[0] != bl.Radius && b.Radius[0] != b.Radius[1] {
+		if b.Radius[0] == 0 && b.Radius[1] != 0 {
 			errs = errors.Append(errs, errors.WrapIndex(ErrLatticeBeamR2, b, i))
 		}
 	}
+	errs = errors.Append(errs, validateBalls(obj.Mesh, bl))
 	for i, set := range bl.BeamSets {
 		for _, ref := range set.Refs {
-			if int(ref) >= len(set.Refs) {
+			if int(ref) >= len(bl.Beams) {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, set, i))
+				break
+			}
+		}
+		for _, ref := range set.BallRefs {
+			if int(ref) >= len(bl.Balls) {
 				errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, set, i))
 				break
 			}
@@ -73,6 +80,37 @@ func validateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
 	return errs
 }
 
+func validateBalls(mesh *go3mf.Mesh, bl *BeamLattice) error {
+	var errs error
+	if bl.BallMode != BallModeNone && bl.BallRadius == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrBallRadius))
+	}
+	if len(bl.Balls) == 0 {
+		return errs
+	}
+	beamVertices := make(map[uint32]struct{}, len(bl.Beams)*2)
+	for _, b := range bl.Beams {
+		beamVertices[b.Indices[0]] = struct{}{}
+		beamVertices[b.Indices[1]] = struct{}{}
+	}
+	balls := make(map[uint32]struct{}, len(bl.Balls))
+	for i, b := range bl.Balls {
+		if int(b.Index) >= len(mesh.Vertices) {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, b, i))
+		} else if _, ok := beamVertices[b.Index]; !ok {
+			errs = errors.Append(errs, errors.WrapIndex(ErrLatticeBallVertex, b, i))
+		}
+		if _, ok := balls[b.Index]; ok {
+			errs = errors.Append(errs, errors.WrapIndex(ErrLatticeSameBall, b, i))
+		}
+		balls[b.Index] = struct{}{}
+		if b.Radius <= 0 {
+			errs = errors.Append(errs, errors.WrapIndex(ErrLatticeBallRadius, b, i))
+		}
+	}
+	return errs
+}
+
 func validateRefMesh(m *go3mf.Model, path string, meshID, selfID uint32) error {
 	if meshID == selfID {
 		return errors.ErrRecursion
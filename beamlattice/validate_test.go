@@ -26,7 +26,6 @@ func TestValidate(t *testing.T) {
 			fmt.Sprintf("/other.model@Resources@Object#0@Mesh: %v", errors.ErrInsufficientVertices),
 			fmt.Sprintf("/other.model@Resources@Object#0@Mesh@BeamLattice: %v", &errors.MissingFieldError{Name: attrMinLength}),
 			fmt.Sprintf("/other.model@Resources@Object#0@Mesh@BeamLattice: %v", &errors.MissingFieldError{Name: attrRadius}),
-			fmt.Sprintf("/other.model@Resources@Object#0@Mesh@BeamLattice: %v", ErrLatticeClippedNoMesh),
 		}},
 		{"object without beamlattice", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
 			{ID: 1, Mesh: &go3mf.Mesh{}},
@@ -41,13 +40,13 @@ func TestValidate(t *testing.T) {
 		}},
 		{"object incorret type", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
 			{ID: 1, Type: go3mf.ObjectTypeOther, Mesh: &go3mf.Mesh{Any: go3mf.Any{&BeamLattice{
-				MinLength: 1, Radius: 1, ClipMode: ClipInside,
+				MinLength: 1, Radius: 1, ClipMode: ClipNone,
 			}}}},
 			{ID: 2, Type: go3mf.ObjectTypeSurface, Mesh: &go3mf.Mesh{Any: go3mf.Any{&BeamLattice{
-				MinLength: 1, Radius: 1, ClipMode: ClipInside,
+				MinLength: 1, Radius: 1, ClipMode: ClipNone,
 			}}}},
 			{ID: 3, Type: go3mf.ObjectTypeSupport, Mesh: &go3mf.Mesh{Any: go3mf.Any{&BeamLattice{
-				MinLength: 1, Radius: 1, ClipMode: ClipInside,
+				MinLength: 1, Radius: 1, ClipMode: ClipNone,
 			}}}},
 		}}}, []string{
 			fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice: %v", ErrLatticeObjType),
@@ -62,15 +61,19 @@ func TestValidate(t *testing.T) {
 			{ID: 3, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}}, Any: go3mf.Any{&BeamLattice{
 				MinLength: 1, Radius: 1, ClippingMeshID: 1, RepresentationMeshID: 2,
 			}}}},
+			{ID: 4, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}}, Any: go3mf.Any{&BeamLattice{
+				MinLength: 1, Radius: 1, ClipMode: ClipOutside,
+			}}}},
 		}}}, []string{
 			fmt.Sprintf("Resources@Object#1@Mesh@BeamLattice: %v", errors.ErrMissingResource),
 			fmt.Sprintf("Resources@Object#1@Mesh@BeamLattice: %v", errors.ErrRecursion),
 			fmt.Sprintf("Resources@Object#2@Mesh@BeamLattice: %v", ErrLatticeInvalidMesh),
+			fmt.Sprintf("Resources@Object#3@Mesh@BeamLattice: %v", ErrLatticeClippedNoMesh),
 		}},
 		{"incorrect beams", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
 			{ID: 2, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}}, Any: go3mf.Any{&BeamLattice{
-				MinLength: 1, Radius: 1, ClipMode: ClipInside, Beams: []Beam{
-					{}, {Indices: [2]uint32{1, 1}, Radius: [2]float32{0.5, 0}}, {Indices: [2]uint32{1, 3}},
+				MinLength: 1, Radius: 1, ClipMode: ClipNone, Beams: []Beam{
+					{}, {Indices: [2]uint32{1, 1}, Radius: [2]float32{0, 0.5}}, {Indices: [2]uint32{1, 3}},
 				},
 			}}}},
 		}}}, []string{
@@ -81,13 +84,37 @@ func TestValidate(t *testing.T) {
 		}},
 		{"incorrect beamseat", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
 			{ID: 2, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}}, Any: go3mf.Any{&BeamLattice{
-				MinLength: 1, Radius: 1, ClipMode: ClipInside, Beams: []Beam{
+				MinLength: 1, Radius: 1, ClipMode: ClipNone, Beams: []Beam{
 					{Indices: [2]uint32{1, 2}},
 				}, BeamSets: []BeamSet{{Refs: []uint32{0, 2, 3}}},
 			}}}},
 		}}}, []string{
 			fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice@BeamSet#0: %v", errors.ErrIndexOutOfBounds),
 		}},
+		{"incorrect balls", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 2, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}, {}}, Any: go3mf.Any{&BeamLattice{
+				MinLength: 1, Radius: 1, ClipMode: ClipNone, BallMode: BallModeMixed, Beams: []Beam{
+					{Indices: [2]uint32{1, 2}},
+				}, Balls: []Ball{
+					{Index: 1, Radius: 1}, {Index: 1, Radius: 1}, {Index: 3, Radius: 1}, {Index: 4, Radius: 1}, {Index: 2},
+				},
+			}}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice: %v", &errors.MissingFieldError{Name: attrBallRadius}),
+			fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice@Ball#1: %v", ErrLatticeSameBall),
+			fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice@Ball#2: %v", ErrLatticeBallVertex),
+			fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice@Ball#3: %v", errors.ErrIndexOutOfBounds),
+			fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice@Ball#4: %v", ErrLatticeBallRadius),
+		}},
+		{"incorrect ballref", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 2, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}}, Any: go3mf.Any{&BeamLattice{
+				MinLength: 1, Radius: 1, ClipMode: ClipNone, BallMode: BallModeAll, BallRadius: 1, Beams: []Beam{
+					{Indices: [2]uint32{1, 2}},
+				}, Balls: []Ball{{Index: 1, Radius: 1}}, BeamSets: []BeamSet{{Refs: []uint32{0}, BallRefs: []uint32{0, 1}}},
+			}}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#0@Mesh@BeamLattice@BeamSet#0: %v", errors.ErrIndexOutOfBounds),
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
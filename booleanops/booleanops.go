@@ -0,0 +1,152 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package booleanops
+
+import (
+	"errors"
+
+	"github.com/MosaicManufacturing/go3mf"
+)
+
+// Namespace is the canonical name of this extension.
+const Namespace = "http://schemas.3mf.io/3dmanufacturing/booleanoperations/2023/07"
+
+var DefaultExtension = go3mf.Extension{
+	Namespace:  Namespace,
+	LocalName:  "bo",
+	IsRequired: true,
+}
+
+var (
+	ErrBooleanObjType    = errors.New("MUST only be added to an object of type model")
+	ErrBooleanContent    = errors.New("MUST NOT contain a mesh or components")
+	ErrBooleanBaseObject = errors.New("base object MUST be a mesh or a boolean shape object of type model")
+	ErrBooleanOperand    = errors.New("operand objects MUST be mesh objects of type model")
+	ErrSingularTransform = errors.New("transform MUST be invertible")
+)
+
+func init() {
+	go3mf.Register(Namespace, Spec{})
+}
+
+type Spec struct{}
+
+// Operation defines the boolean operation applied between
+// the base object and the operands.
+type Operation uint8
+
+// Supported operations.
+const (
+	OperationUnion Operation = iota
+	OperationDifference
+	OperationIntersection
+)
+
+func newOperation(s string) (o Operation, ok bool) {
+	o, ok = map[string]Operation{
+		"union":        OperationUnion,
+		"difference":   OperationDifference,
+		"intersection": OperationIntersection,
+	}[s]
+	return
+}
+
+func (o Operation) String() string {
+	return map[Operation]string{
+		OperationUnion:        "union",
+		OperationDifference:   "difference",
+		OperationIntersection: "intersection",
+	}[o]
+}
+
+// BooleanShape defines an object as the result of applying
+// the operation between the base object and each operand, in order.
+// Path, if not empty, is the model part containing the base object
+// and requires the production extension.
+type BooleanShape struct {
+	ObjectID  uint32
+	Operation Operation
+	Transform go3mf.Matrix
+	Path      string
+	Operands  []Boolean
+}
+
+// Boolean is an operand of a BooleanShape.
+type Boolean struct {
+	ObjectID  uint32
+	Transform go3mf.Matrix
+	Path      string
+}
+
+func GetBooleanShape(obj *go3mf.Object) *BooleanShape {
+	for _, a := range obj.Any {
+		if a, ok := a.(*BooleanShape); ok {
+			return a
+		}
+	}
+	return nil
+}
+
+// References returns the IDs of the base object and the operands
+// that are in the same model file as b.
+func (b *BooleanShape) References() []uint32 {
+	var refs []uint32
+	if b.Path == "" && b.ObjectID != 0 {
+		refs = append(refs, b.ObjectID)
+	}
+	for _, o := range b.Operands {
+		if o.Path == "" && o.ObjectID != 0 {
+			refs = append(refs, o.ObjectID)
+		}
+	}
+	return refs
+}
+
+// Remap replaces the paths and IDs of the base object and the operands with the ones returned by fn.
+func (b *BooleanShape) Remap(fn func(path string, id uint32) (string, uint32)) {
+	b.Path, b.ObjectID = fn(b.Path, b.ObjectID)
+	for i, o := range b.Operands {
+		b.Operands[i].Path, b.Operands[i].ObjectID = fn(o.Path, o.ObjectID)
+	}
+}
+
+// IsShape returns true, as BooleanShape defines the geometry of its object.
+func (b *BooleanShape) IsShape() bool {
+	return true
+}
+
+// HasTransform returns true if the transform is different than the identity.
+func (b *BooleanShape) HasTransform() bool {
+	return b.Transform != go3mf.Matrix{} && b.Transform != go3mf.Identity()
+}
+
+// ObjectPath returns the path of the base object, or defaultPath if not defined.
+func (b *BooleanShape) ObjectPath(defaultPath string) string {
+	if b.Path == "" {
+		return defaultPath
+	}
+	return b.Path
+}
+
+// HasTransform returns true if the transform is different than the identity.
+func (b *Boolean) HasTransform() bool {
+	return b.Transform != go3mf.Matrix{} && b.Transform != go3mf.Identity()
+}
+
+// ObjectPath returns the path of the operand object, or defaultPath if not defined.
+func (b *Boolean) ObjectPath(defaultPath string) string {
+	if b.Path == "" {
+		return defaultPath
+	}
+	return b.Path
+}
+
+const (
+	attrBooleanShape = "booleanshape"
+	attrBoolean      = "boolean"
+	attrObjectID     = "objectid"
+	attrOperation    = "operation"
+	attrTransform    = "transform"
+	attrPath         = "path"
+)
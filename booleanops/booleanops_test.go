@@ -0,0 +1,137 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package booleanops
+
+import (
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/spec"
+	"github.com/go-test/deep"
+)
+
+var _ spec.Marshaler = new(BooleanShape)
+
+func TestOperation_String(t *testing.T) {
+	tests := []struct {
+		name string
+		o    Operation
+	}{
+		{"union", OperationUnion},
+		{"difference", OperationDifference},
+		{"intersection", OperationIntersection},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.String(); got != tt.name {
+				t.Errorf("Operation.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func Test_newOperation(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantO  Operation
+		wantOk bool
+	}{
+		{"union", OperationUnion, true},
+		{"difference", OperationDifference, true},
+		{"intersection", OperationIntersection, true},
+		{"empty", OperationUnion, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotO, gotOk := newOperation(tt.name)
+			if gotO != tt.wantO {
+				t.Errorf("newOperation() gotO = %v, want %v", gotO, tt.wantO)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("newOperation() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetBooleanShape(t *testing.T) {
+	bs := new(BooleanShape)
+	tests := []struct {
+		name string
+		obj  *go3mf.Object
+		want *BooleanShape
+	}{
+		{"empty", new(go3mf.Object), nil},
+		{"found", &go3mf.Object{Any: go3mf.Any{nil, bs}}, bs},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetBooleanShape(tt.obj); got != tt.want {
+				t.Errorf("GetBooleanShape() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBooleanShape_ObjectPath(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *BooleanShape
+		want string
+	}{
+		{"default", new(BooleanShape), "/3D/3dmodel.model"},
+		{"path", &BooleanShape{Path: "/3D/other.model"}, "/3D/other.model"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.ObjectPath("/3D/3dmodel.model"); got != tt.want {
+				t.Errorf("BooleanShape.ObjectPath() = %v, want %v", got, tt.want)
+			}
+			op := &Boolean{Path: tt.b.Path}
+			if got := op.ObjectPath("/3D/3dmodel.model"); got != tt.want {
+				t.Errorf("Boolean.ObjectPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isSingular(t *testing.T) {
+	tests := []struct {
+		name string
+		t    go3mf.Matrix
+		want bool
+	}{
+		{"empty", go3mf.Matrix{}, false},
+		{"identity", go3mf.Identity(), false},
+		{"translate", go3mf.Identity().Translate(1, 2, 3), false},
+		{"scale", go3mf.Matrix{2, 0, 0, 0, 0, 3, 0, 0, 0, 0, 4, 0, 0, 0, 0, 1}, false},
+		{"flat", go3mf.Matrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1, 2, 3, 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSingular(tt.t); got != tt.want {
+				t.Errorf("isSingular() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBooleanShape_References(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *BooleanShape
+		want []uint32
+	}{
+		{"empty", new(BooleanShape), nil},
+		{"sameFile", &BooleanShape{ObjectID: 1, Operands: []Boolean{{ObjectID: 2}, {ObjectID: 3}}}, []uint32{1, 2, 3}},
+		{"otherFile", &BooleanShape{ObjectID: 1, Path: "/a.model", Operands: []Boolean{{ObjectID: 2, Path: "/a.model"}, {ObjectID: 3}}}, []uint32{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(tt.b.References(), tt.want); diff != nil {
+				t.Errorf("BooleanShape.References() = %v", diff)
+			}
+		})
+	}
+}
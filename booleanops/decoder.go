@@ -0,0 +1,129 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package booleanops
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/production"
+	"github.com/MosaicManufacturing/go3mf/spec"
+)
+
+func (Spec) DecodeAttribute(interface{}, spec.Attr) error {
+	return nil
+}
+
+func (Spec) CreateElementDecoder(parent interface{}, name string) spec.ElementDecoder {
+	if name == attrBooleanShape {
+		if obj, ok := parent.(*go3mf.Object); ok {
+			return &booleanShapeDecoder{obj: obj}
+		}
+	}
+	return nil
+}
+
+type booleanShapeDecoder struct {
+	baseDecoder
+	obj   *go3mf.Object
+	shape *BooleanShape
+}
+
+func (d *booleanShapeDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.shape = new(BooleanShape)
+	d.obj.Any = append(d.obj.Any, d.shape)
+	for _, a := range attrs {
+		if a.Name.Space == production.Namespace && a.Name.Local == attrPath {
+			d.shape.Path = string(a.Value)
+			continue
+		}
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrObjectID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.shape.ObjectID = uint32(val)
+		case attrOperation:
+			var ok bool
+			d.shape.Operation, ok = newOperation(string(a.Value))
+			if !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrTransform:
+			var ok bool
+			d.shape.Transform, ok = spec.ParseMatrix(string(a.Value))
+			if !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		}
+	}
+	if errs != nil {
+		return specerr.Wrap(errs, d.shape)
+	}
+	return nil
+}
+
+func (d *booleanShapeDecoder) Wrap(err error) error {
+	return specerr.Wrap(err, d.shape)
+}
+
+func (d *booleanShapeDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrBoolean {
+		child = &booleanDecoder{shape: d.shape}
+	}
+	return
+}
+
+type booleanDecoder struct {
+	baseDecoder
+	shape *BooleanShape
+}
+
+func (d *booleanDecoder) Start(attrs []spec.Attr) error {
+	var (
+		operand Boolean
+		errs    error
+	)
+	for _, a := range attrs {
+		if a.Name.Space == production.Namespace && a.Name.Local == attrPath {
+			operand.Path = string(a.Value)
+			continue
+		}
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrObjectID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			operand.ObjectID = uint32(val)
+		case attrTransform:
+			var ok bool
+			operand.Transform, ok = spec.ParseMatrix(string(a.Value))
+			if !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		}
+	}
+	d.shape.Operands = append(d.shape.Operands, operand)
+	if errs != nil {
+		return specerr.WrapIndex(errs, operand, len(d.shape.Operands)-1)
+	}
+	return nil
+}
+
+type baseDecoder struct {
+}
+
+func (d *baseDecoder) Start([]spec.Attr) error { return nil }
+func (d *baseDecoder) End()                    {}
@@ -0,0 +1,103 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package booleanops
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/production"
+	"github.com/go-test/deep"
+)
+
+func TestDecode(t *testing.T) {
+	shape := &go3mf.Object{ID: 3, Name: "Shape", Any: go3mf.Any{&BooleanShape{
+		ObjectID:  1,
+		Operation: OperationDifference,
+		Transform: go3mf.Matrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 10, 0, 0, 1},
+		Operands: []Boolean{
+			{ObjectID: 2},
+			{ObjectID: 2, Transform: go3mf.Matrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 5, 0, 1}, Path: "/3D/other.model"},
+		},
+	}}}
+	want := &go3mf.Model{
+		Path:       "/3D/3dmodel.model",
+		Extensions: []go3mf.Extension{DefaultExtension, production.DefaultExtension},
+		Resources: go3mf.Resources{
+			Objects: []*go3mf.Object{shape},
+		},
+	}
+	got := &go3mf.Model{
+		Path: "/3D/3dmodel.model",
+	}
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:bo="http://schemas.3mf.io/3dmanufacturing/booleanoperations/2023/07" xmlns:p="http://schemas.microsoft.com/3dmanufacturing/production/2015/06" requiredextensions="bo p">
+		<resources>
+			<object id="3" name="Shape" type="model">
+				<bo:booleanshape objectid="1" operation="difference" transform="1 0 0 0 1 0 0 0 1 10 0 0">
+					<bo:boolean objectid="2"/>
+					<bo:other/>
+					<bo:boolean objectid="2" transform="1 0 0 0 1 0 0 0 1 0 5 0" p:path="/3D/other.model"/>
+				</bo:booleanshape>
+			</object>
+		</resources>
+		<build>
+		</build>
+		</model>
+		`
+
+	t.Run("base", func(t *testing.T) {
+		if err := go3mf.UnmarshalModel([]byte(rootFile), got); err != nil {
+			t.Errorf("DecodeRawModel() unexpected error = %v", err)
+			return
+		}
+		if diff := deep.Equal(got, want); diff != nil {
+			t.Errorf("DecodeRawModel() = %v", diff)
+			return
+		}
+	})
+}
+
+func TestDecode_warns(t *testing.T) {
+	want := []string{
+		fmt.Sprintf("Resources@Object#0@BooleanShape: %v", errors.NewParseAttrError("objectid", true)),
+		fmt.Sprintf("Resources@Object#0@BooleanShape: %v", errors.NewParseAttrError("operation", false)),
+		fmt.Sprintf("Resources@Object#0@BooleanShape: %v", errors.NewParseAttrError("transform", false)),
+		fmt.Sprintf("Resources@Object#0@BooleanShape@Boolean#0: %v", errors.NewParseAttrError("objectid", true)),
+		fmt.Sprintf("Resources@Object#0@BooleanShape@Boolean#1: %v", errors.NewParseAttrError("transform", false)),
+	}
+	got := new(go3mf.Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:bo="http://schemas.3mf.io/3dmanufacturing/booleanoperations/2023/07">
+		<resources>
+			<object id="3" type="model">
+				<bo:booleanshape qm:mq="other" objectid="a" operation="xor" transform="0 0">
+					<bo:boolean objectid="b"/>
+					<bo:boolean objectid="2" transform="a"/>
+				</bo:booleanshape>
+			</object>
+		</resources>
+		<build>
+		</build>
+		</model>
+		`
+
+	t.Run("base", func(t *testing.T) {
+		err := go3mf.UnmarshalModel([]byte(rootFile), got)
+		if err == nil {
+			t.Fatal("error expected")
+		}
+		var errs []string
+		for _, err := range err.(*errors.List).Errors {
+			errs = append(errs, err.Error())
+		}
+		if diff := deep.Equal(errs, want); diff != nil {
+			t.Errorf("UnmarshalModel_warn() = %v", diff)
+			return
+		}
+	})
+}
@@ -0,0 +1,45 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package booleanops
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/MosaicManufacturing/go3mf/production"
+	"github.com/MosaicManufacturing/go3mf/spec"
+)
+
+// Marshal3MF encodes the resource.
+func (b *BooleanShape) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrBooleanShape}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrObjectID}, Value: strconv.FormatUint(uint64(b.ObjectID), 10)},
+	}}
+	if b.Operation != OperationUnion {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrOperation}, Value: b.Operation.String()})
+	}
+	if b.HasTransform() {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTransform}, Value: b.Transform.String()})
+	}
+	if b.Path != "" {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Space: production.Namespace, Local: attrPath}, Value: b.Path})
+	}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, op := range b.Operands {
+		xo := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrBoolean}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrObjectID}, Value: strconv.FormatUint(uint64(op.ObjectID), 10)},
+		}}
+		if op.HasTransform() {
+			xo.Attr = append(xo.Attr, xml.Attr{Name: xml.Name{Local: attrTransform}, Value: op.Transform.String()})
+		}
+		if op.Path != "" {
+			xo.Attr = append(xo.Attr, xml.Attr{Name: xml.Name{Space: production.Namespace, Local: attrPath}, Value: op.Path})
+		}
+		x.EncodeToken(xo)
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
@@ -0,0 +1,53 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package booleanops
+
+import (
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/production"
+	"github.com/go-test/deep"
+)
+
+func TestMarshalModel(t *testing.T) {
+	base := &go3mf.Object{ID: 1, Mesh: &go3mf.Mesh{
+		Vertices:  []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+		Triangles: []go3mf.Triangle{{V1: 0, V2: 1, V3: 2}},
+	}}
+	shape := &go3mf.Object{ID: 3, Name: "Shape", Any: go3mf.Any{&BooleanShape{
+		ObjectID:  1,
+		Operation: OperationIntersection,
+		Transform: go3mf.Identity().Translate(10, 0, 0),
+		Operands: []Boolean{
+			{ObjectID: 1},
+			{ObjectID: 2, Transform: go3mf.Identity().Translate(0, 5, 0), Path: "/3D/other.model"},
+		},
+	}}}
+	union := &go3mf.Object{ID: 4, Any: go3mf.Any{&BooleanShape{ObjectID: 3, Path: "/3D/3dmodel.model"}}}
+	m := &go3mf.Model{
+		Path:       "/3D/3dmodel.model",
+		Extensions: []go3mf.Extension{DefaultExtension, production.DefaultExtension},
+		Resources: go3mf.Resources{
+			Objects: []*go3mf.Object{base, shape, union},
+		},
+	}
+
+	t.Run("base", func(t *testing.T) {
+		b, err := go3mf.MarshalModel(m)
+		if err != nil {
+			t.Errorf("booleanops.MarshalModel() error = %v", err)
+			return
+		}
+		newModel := new(go3mf.Model)
+		newModel.Path = m.Path
+		if err := go3mf.UnmarshalModel(b, newModel); err != nil {
+			t.Errorf("booleanops.MarshalModel() error decoding = %v, s = %s", err, string(b))
+			return
+		}
+		if diff := deep.Equal(m, newModel); diff != nil {
+			t.Errorf("booleanops.MarshalModel() = %v, s = %s", diff, string(b))
+		}
+	})
+}
@@ -0,0 +1,106 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package booleanops
+
+import (
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+)
+
+func (Spec) Validate(m interface{}, path string, obj interface{}) error {
+	if obj, ok := obj.(*go3mf.Object); ok {
+		return validateObject(m.(*go3mf.Model), path, obj)
+	}
+	return nil
+}
+
+func validateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
+	bs := GetBooleanShape(obj)
+	if bs == nil {
+		return nil
+	}
+	var errs error
+	if obj.Type != go3mf.ObjectTypeModel {
+		errs = errors.Append(errs, ErrBooleanObjType)
+	}
+	if obj.Mesh != nil || obj.Components != nil {
+		errs = errors.Append(errs, ErrBooleanContent)
+	}
+	if bs.ObjectID == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrObjectID))
+	} else if isRecursive(m, path, obj) {
+		errs = errors.Append(errs, errors.ErrRecursion)
+	} else if ref, ok := m.FindObject(bs.ObjectPath(path), bs.ObjectID); !ok {
+		errs = errors.Append(errs, errors.ErrMissingResource)
+	} else if ref.Type != go3mf.ObjectTypeModel || (ref.Mesh == nil && GetBooleanShape(ref) == nil) {
+		errs = errors.Append(errs, ErrBooleanBaseObject)
+	}
+	if isSingular(bs.Transform) {
+		errs = errors.Append(errs, ErrSingularTransform)
+	}
+	for i, op := range bs.Operands {
+		var opErrs error
+		if op.ObjectID == 0 {
+			opErrs = errors.Append(opErrs, errors.NewMissingFieldError(attrObjectID))
+		} else if op.ObjectID == obj.ID && op.ObjectPath(path) == path {
+			opErrs = errors.Append(opErrs, errors.ErrRecursion)
+		} else if ref, ok := m.FindObject(op.ObjectPath(path), op.ObjectID); !ok {
+			opErrs = errors.Append(opErrs, errors.ErrMissingResource)
+		} else if ref.Type != go3mf.ObjectTypeModel || ref.Mesh == nil {
+			opErrs = errors.Append(opErrs, ErrBooleanOperand)
+		}
+		if isSingular(op.Transform) {
+			opErrs = errors.Append(opErrs, ErrSingularTransform)
+		}
+		if opErrs != nil {
+			errs = errors.Append(errs, errors.WrapIndex(opErrs, op, i))
+		}
+	}
+	if errs != nil {
+		errs = errors.Wrap(errs, bs)
+	}
+	return errs
+}
+
+// isRecursive returns true if following the base objects of the boolean shapes
+// from obj, which is in path, reaches an object already visited.
+func isRecursive(m *go3mf.Model, path string, obj *go3mf.Object) bool {
+	type key struct {
+		path string
+		id   uint32
+	}
+	normalize := func(p string) string {
+		if p == m.PathOrDefault() {
+			return ""
+		}
+		return p
+	}
+	path = normalize(path)
+	visited := map[key]bool{{path, obj.ID}: true}
+	for bs := GetBooleanShape(obj); bs != nil; {
+		next := key{normalize(bs.ObjectPath(path)), bs.ObjectID}
+		if visited[next] {
+			return true
+		}
+		visited[next] = true
+		ref, ok := m.FindObject(next.path, next.id)
+		if !ok {
+			return false
+		}
+		bs, path = GetBooleanShape(ref), next.path
+	}
+	return false
+}
+
+// isSingular returns true if the transform is defined
+// and its linear part is not invertible.
+func isSingular(t go3mf.Matrix) bool {
+	if t == (go3mf.Matrix{}) {
+		return false
+	}
+	det := t[0]*(t[5]*t[10]-t[6]*t[9]) -
+		t[1]*(t[4]*t[10]-t[6]*t[8]) +
+		t[2]*(t[4]*t[9]-t[5]*t[8])
+	return det == 0
+}
@@ -0,0 +1,114 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package booleanops
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/go-test/deep"
+)
+
+func validMesh() *go3mf.Mesh {
+	return &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}, {}}, Triangles: []go3mf.Triangle{
+		{V1: 0, V2: 1, V3: 2}, {V1: 0, V2: 3, V3: 1}, {V1: 0, V2: 2, V3: 3}, {V1: 1, V2: 3, V3: 2},
+	}}
+}
+
+func TestValidate(t *testing.T) {
+	singular := go3mf.Matrix{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}
+	tests := []struct {
+		name  string
+		model *go3mf.Model
+		want  []string
+	}{
+		{"valid", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Mesh: validMesh()},
+			{ID: 2, Mesh: validMesh()},
+			{ID: 3, Any: go3mf.Any{&BooleanShape{ObjectID: 1, Operation: OperationDifference, Operands: []Boolean{
+				{ObjectID: 2, Transform: go3mf.Identity().Translate(1, 0, 0)},
+			}}}},
+			{ID: 4, Any: go3mf.Any{&BooleanShape{ObjectID: 3, Operands: []Boolean{{ObjectID: 1}}}}},
+		}}}, nil},
+		{"object", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Type: go3mf.ObjectTypeSupport, Mesh: validMesh(), Any: go3mf.Any{&BooleanShape{ObjectID: 2, Transform: singular}}},
+			{ID: 2, Mesh: validMesh()},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#0@BooleanShape: %v", ErrBooleanObjType),
+			fmt.Sprintf("Resources@Object#0@BooleanShape: %v", ErrBooleanContent),
+			fmt.Sprintf("Resources@Object#0@BooleanShape: %v", ErrSingularTransform),
+		}},
+		{"base", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Type: go3mf.ObjectTypeOther, Mesh: validMesh()},
+			{ID: 2, Components: &go3mf.Components{Component: []*go3mf.Component{{ObjectID: 1}}}},
+			{ID: 3, Any: go3mf.Any{&BooleanShape{}}},
+			{ID: 4, Any: go3mf.Any{&BooleanShape{ObjectID: 4}}},
+			{ID: 5, Any: go3mf.Any{&BooleanShape{ObjectID: 100}}},
+			{ID: 6, Any: go3mf.Any{&BooleanShape{ObjectID: 1}}},
+			{ID: 7, Any: go3mf.Any{&BooleanShape{ObjectID: 2}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#2@BooleanShape: %v", &errors.MissingFieldError{Name: attrObjectID}),
+			fmt.Sprintf("Resources@Object#3@BooleanShape: %v", errors.ErrRecursion),
+			fmt.Sprintf("Resources@Object#4@BooleanShape: %v", errors.ErrMissingResource),
+			fmt.Sprintf("Resources@Object#5@BooleanShape: %v", ErrBooleanBaseObject),
+			fmt.Sprintf("Resources@Object#6@BooleanShape: %v", ErrBooleanBaseObject),
+		}},
+		{"cycle", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Any: go3mf.Any{&BooleanShape{ObjectID: 2}}},
+			{ID: 2, Any: go3mf.Any{&BooleanShape{ObjectID: 1}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#0@BooleanShape: %v", errors.ErrRecursion),
+			fmt.Sprintf("Resources@Object#1@BooleanShape: %v", errors.ErrRecursion),
+		}},
+		{"operands", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Mesh: validMesh()},
+			{ID: 2, Components: &go3mf.Components{Component: []*go3mf.Component{{ObjectID: 1}}}},
+			{ID: 3, Any: go3mf.Any{&BooleanShape{ObjectID: 1, Operands: []Boolean{
+				{}, {ObjectID: 3}, {ObjectID: 100}, {ObjectID: 2}, {ObjectID: 1, Transform: singular},
+			}}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#2@BooleanShape@Boolean#0: %v", &errors.MissingFieldError{Name: attrObjectID}),
+			fmt.Sprintf("Resources@Object#2@BooleanShape@Boolean#1: %v", errors.ErrRecursion),
+			fmt.Sprintf("Resources@Object#2@BooleanShape@Boolean#2: %v", errors.ErrMissingResource),
+			fmt.Sprintf("Resources@Object#2@BooleanShape@Boolean#3: %v", ErrBooleanOperand),
+			fmt.Sprintf("Resources@Object#2@BooleanShape@Boolean#4: %v", ErrSingularTransform),
+		}},
+		{"child", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 3, Any: go3mf.Any{&BooleanShape{ObjectID: 1, Path: "/other.model", Operands: []Boolean{
+				{ObjectID: 2, Path: "/other.model"},
+			}}}},
+		}}, Childs: map[string]*go3mf.ChildModel{
+			"/other.model": {Resources: go3mf.Resources{Objects: []*go3mf.Object{
+				{ID: 1, Mesh: validMesh()},
+				{ID: 2, Type: go3mf.ObjectTypeSupport, Mesh: validMesh()},
+			}}},
+		}}, []string{
+			fmt.Sprintf("Resources@Object#0@BooleanShape@Boolean#0: %v", ErrBooleanOperand),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.model.Extensions = []go3mf.Extension{DefaultExtension}
+			err := tt.model.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate() err = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("error expected")
+			}
+			var errs []string
+			for _, err := range err.(*errors.List).Errors {
+				errs = append(errs, err.Error())
+			}
+			if diff := deep.Equal(errs, tt.want); diff != nil {
+				t.Errorf("Validate() = %v", diff)
+			}
+		})
+	}
+}
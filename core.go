@@ -4,10 +4,12 @@
 package go3mf
 
 import (
+	"bytes"
 	"encoding/xml"
 	"image/color"
 	"io"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/MosaicManufacturing/go3mf/spec"
@@ -16,6 +18,10 @@ import (
 const (
 	// Namespace is the canonical name of this extension.
 	Namespace = "http://schemas.microsoft.com/3dmanufacturing/core/2015/02"
+	// TriangleSetsNamespace is the canonical name of the triangle sets
+	// namespace introduced in the 3MF Core 1.3 specification.
+	TriangleSetsNamespace = "http://schemas.microsoft.com/3dmanufacturing/trianglesets/2021/07"
+	triangleSetsPrefix    = "t"
 
 	// RelType3DModel is the canonical 3D model relationship type.
 	RelType3DModel = "http://schemas.microsoft.com/3dmanufacturing/2013/01/3dmodel"
@@ -25,6 +31,9 @@ const (
 	RelTypePrintTicket = "http://schemas.microsoft.com/3dmanufacturing/2013/01/printticket"
 	// RelTypeMustPreserve is the canonical must preserve relationship type.
 	RelTypeMustPreserve = "http://schemas.openxmlformats.org/package/2006/relationships/mustpreserve"
+	// RelTypeKeyStore is the canonical relationship type from the package root
+	// to the KeyStore part of the secure content extension.
+	RelTypeKeyStore = "http://schemas.microsoft.com/3dmanufacturing/2019/07/keystore"
 
 	// DefaultModelPath is the recommended root model part name.
 	DefaultModelPath = "/3D/3dmodel.model"
@@ -194,10 +203,24 @@ type Extension struct {
 // for multiple official specs.
 // The relationships are usually managed by the extensions themself,
 // but they are usefull to reference custom attachments.
+//
+// When decoded lazily, the child model is a placeholder whose
+// content is decoded on first access, see Load.
 type ChildModel struct {
 	Resources     Resources
 	Relationships []Relationship
 	Any           Any
+	lazy          *lazyChild
+}
+
+// Load decodes the content of a lazily decoded child model.
+// It is a no-op if the child model is already decoded.
+// It is safe for concurrent use.
+func (c *ChildModel) Load() error {
+	if c.lazy == nil {
+		return nil
+	}
+	return c.lazy.load()
 }
 
 // A Model is an in memory representation of the 3MF file.
@@ -260,11 +283,17 @@ func (m *Model) BoundingBox() Box {
 }
 
 // FindResources returns the resource associated with path.
+//
+// A lazily decoded child model that fails to load is reported as not found,
+// the load error is returned by ChildModel.Load and by Model.Validate.
 func (m *Model) FindResources(path string) (*Resources, bool) {
 	if path == "" || path == m.Path || (m.Path == "" && path == DefaultModelPath) {
 		return &m.Resources, true
 	}
 	if child, ok := m.Childs[path]; ok {
+		if child.Load() != nil {
+			return nil, false
+		}
 		return &child.Resources, true
 	}
 	return nil, false
@@ -278,6 +307,38 @@ func (m *Model) FindAsset(path string, id uint32) (Asset, bool) {
 	return nil, false
 }
 
+// FindAttachment returns the attachment located at path.
+// Paths are compared case-insensitively.
+func (m *Model) FindAttachment(path string) (*Attachment, bool) {
+	for i := range m.Attachments {
+		if strings.EqualFold(m.Attachments[i].Path, path) {
+			return &m.Attachments[i], true
+		}
+	}
+	return nil, false
+}
+
+// Bytes returns the content of the attachment without consuming its stream,
+// which is replaced by an in-memory copy if it can not be rewound.
+// It returns nil if the attachment has no stream.
+func (a *Attachment) Bytes() ([]byte, error) {
+	switch r := a.Stream.(type) {
+	case nil:
+		return nil, nil
+	case *bytes.Buffer:
+		return r.Bytes(), nil
+	case io.ReadSeeker:
+		data, err := io.ReadAll(r)
+		if err == nil {
+			_, err = r.Seek(0, io.SeekStart)
+		}
+		return data, err
+	}
+	data, err := io.ReadAll(a.Stream)
+	a.Stream = bytes.NewReader(data)
+	return data, err
+}
+
 // FindObject returns the object with the target path and ID.
 func (m *Model) FindObject(path string, id uint32) (*Object, bool) {
 	if rs, ok := m.FindResources(path); ok {
@@ -295,6 +356,9 @@ func (m *Model) WalkAssets(fn func(string, Asset) error) error {
 	sortedChilds := m.sortedChilds()
 	for _, path := range sortedChilds {
 		c := m.Childs[path]
+		if err := c.Load(); err != nil {
+			return err
+		}
 		for _, r := range c.Resources.Assets {
 			if err := fn(path, r); err != nil {
 				return err
@@ -318,6 +382,9 @@ func (m *Model) WalkObjects(fn func(string, *Object) error) error {
 	sortedChilds := m.sortedChilds()
 	for _, path := range sortedChilds {
 		c := m.Childs[path]
+		if err := c.Load(); err != nil {
+			return err
+		}
 		for _, r := range c.Resources.Objects {
 			if err := fn(path, r); err != nil {
 				return err
@@ -400,6 +467,7 @@ type Object struct {
 	Mesh       *Mesh
 	Components *Components
 	AnyAttr    AnyAttr
+	Any        Any
 }
 
 func (o *Object) boundingBox(m *Model, path string) Box {
@@ -467,11 +535,17 @@ type Triangle struct {
 // Each node and face have an ID, which allows to identify them. Each face have an
 // orientation (i.e. the face can look up or look down) and have three nodes.
 // The orientation is defined by the order of its nodes.
+//
+// TriangleAnyAttr contains the non-core attributes of the triangles,
+// indexed by triangle. It is kept apart from Triangle so meshes
+// without such attributes do not pay for them.
 type Mesh struct {
-	Vertices  []Point3D
-	Triangles []Triangle
-	AnyAttr   AnyAttr
-	Any       Any
+	Vertices        []Point3D
+	Triangles       []Triangle
+	TriangleSets    []TriangleSet
+	TriangleAnyAttr map[uint32]AnyAttr
+	AnyAttr         AnyAttr
+	Any             Any
 }
 
 // BoundingBox returns the bounding box of the mesh.
@@ -486,6 +560,70 @@ func (m *Mesh) BoundingBox() Box {
 	return box
 }
 
+// FindTriangleSet returns the triangle set with the target identifier.
+func (m *Mesh) FindTriangleSet(identifier string) (*TriangleSet, bool) {
+	for i := range m.TriangleSets {
+		if m.TriangleSets[i].Identifier == identifier {
+			return &m.TriangleSets[i], true
+		}
+	}
+	return nil, false
+}
+
+// TriangleRefRange defines an inclusive range of triangle indices.
+type TriangleRefRange struct {
+	Start uint32
+	End   uint32
+}
+
+// A TriangleSet groups a selection of the mesh triangles,
+// referenced either individually or by ranges.
+type TriangleSet struct {
+	Name       string
+	Identifier string
+	Refs       []uint32
+	RefRanges  []TriangleRefRange
+}
+
+// Contains returns true if the set references the triangle index.
+func (t *TriangleSet) Contains(index uint32) bool {
+	for _, ref := range t.Refs {
+		if ref == index {
+			return true
+		}
+	}
+	for _, r := range t.RefRanges {
+		if index >= r.Start && index <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// Triangles returns the sorted triangle indices referenced by the set,
+// without duplicates.
+func (t *TriangleSet) Triangles() []uint32 {
+	indices := make([]uint32, 0, len(t.Refs))
+	indices = append(indices, t.Refs...)
+	for _, r := range t.RefRanges {
+		for i := r.Start; i <= r.End && i >= r.Start; i++ {
+			indices = append(indices, i)
+		}
+	}
+	if len(indices) == 0 {
+		return indices
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	n := 1
+	for i := 1; i < len(indices); i++ {
+		if indices[i] != indices[n-1] {
+			indices[n] = indices[i]
+			n++
+		}
+	}
+	return indices[:n]
+}
+
 // MeshBuilder is a helper that creates mesh following a configurable criteria.
 // It must be instantiated using NewMeshBuilder.
 type MeshBuilder struct {
@@ -617,4 +755,12 @@ const (
 	attrMetadata      = "metadata"
 	attrMetadataGroup = "metadatagroup"
 	attrPath          = "path"
+	attrTriangleSets  = "trianglesets"
+	attrTriangleSet   = "triangleset"
+	attrIdentifier    = "identifier"
+	attrRef           = "ref"
+	attrRefRange      = "refrange"
+	attrIndex         = "index"
+	attrStartIndex    = "startindex"
+	attrEndIndex      = "endindex"
 )
@@ -4,7 +4,10 @@
 package go3mf
 
 import (
+	"bytes"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/MosaicManufacturing/go3mf/spec"
@@ -84,6 +87,34 @@ func TestModel_FindAsset(t *testing.T) {
 	}
 }
 
+func TestModel_FindAttachment(t *testing.T) {
+	model := &Model{Attachments: []Attachment{
+		{Path: "/3D/Textures/a.png"},
+		{Path: "/3D/Textures/b.png"},
+	}}
+	tests := []struct {
+		name   string
+		path   string
+		want   *Attachment
+		wantOk bool
+	}{
+		{"exist", "/3D/Textures/b.png", &model.Attachments[1], true},
+		{"case", "/3d/textures/A.PNG", &model.Attachments[0], true},
+		{"noexist", "/3D/Textures/c.png", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := model.FindAttachment(tt.path)
+			if got != tt.want {
+				t.Errorf("Model.FindAttachment() got = %v, want %v", got, tt.want)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("Model.FindAttachment() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
 func TestModel_FindObject(t *testing.T) {
 	model := &Model{Path: "/3D/model.model"}
 	id1 := &Object{ID: 0}
@@ -487,3 +518,99 @@ func TestModel_BoundingBox(t *testing.T) {
 		})
 	}
 }
+
+func TestTriangleSet_Triangles(t *testing.T) {
+	tests := []struct {
+		name string
+		t    *TriangleSet
+		want []uint32
+	}{
+		{"empty", new(TriangleSet), []uint32{}},
+		{"refs", &TriangleSet{Refs: []uint32{3, 1, 3}}, []uint32{1, 3}},
+		{"mixed", &TriangleSet{Refs: []uint32{7, 2}, RefRanges: []TriangleRefRange{{Start: 1, End: 3}, {Start: 5, End: 5}}}, []uint32{1, 2, 3, 5, 7}},
+		{"inverted", &TriangleSet{RefRanges: []TriangleRefRange{{Start: 3, End: 1}}}, []uint32{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.Triangles(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TriangleSet.Triangles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTriangleSet_Contains(t *testing.T) {
+	set := &TriangleSet{Refs: []uint32{7}, RefRanges: []TriangleRefRange{{Start: 1, End: 3}}}
+	tests := []struct {
+		name  string
+		index uint32
+		want  bool
+	}{
+		{"ref", 7, true},
+		{"rangeStart", 1, true},
+		{"rangeEnd", 3, true},
+		{"outside", 4, false},
+		{"zero", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := set.Contains(tt.index); got != tt.want {
+				t.Errorf("TriangleSet.Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMesh_FindTriangleSet(t *testing.T) {
+	m := &Mesh{TriangleSets: []TriangleSet{{Name: "a", Identifier: "ida"}, {Name: "b", Identifier: "idb"}}}
+	tests := []struct {
+		name       string
+		identifier string
+		want       *TriangleSet
+		wantOk     bool
+	}{
+		{"found", "idb", &m.TriangleSets[1], true},
+		{"notFound", "idc", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := m.FindTriangleSet(tt.identifier)
+			if got != tt.want {
+				t.Errorf("Mesh.FindTriangleSet() got = %v, want %v", got, tt.want)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("Mesh.FindTriangleSet() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+// plainReader hides the Seek method of the wrapped reader.
+type plainReader struct{ io.Reader }
+
+func TestAttachment_Bytes(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream io.Reader
+		want   []byte
+	}{
+		{"nil", nil, nil},
+		{"buffer", bytes.NewBufferString("abc"), []byte("abc")},
+		{"seeker", bytes.NewReader([]byte("abc")), []byte("abc")},
+		{"reader", plainReader{strings.NewReader("abc")}, []byte("abc")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Attachment{Stream: tt.stream}
+			for i := 0; i < 2; i++ {
+				got, err := a.Bytes()
+				if err != nil {
+					t.Fatalf("Attachment.Bytes() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Attachment.Bytes() = %s, want %s", got, tt.want)
+				}
+			}
+		})
+	}
+}
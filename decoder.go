@@ -25,7 +25,11 @@ func (d *modelDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 	if name.Space == Namespace {
 		switch name.Local {
 		case attrResources:
-			resources, _ := d.model.FindResources(d.path)
+			resources := &d.model.Resources
+			if !d.isRoot {
+				// Do not use FindResources, which would load the child model being decoded.
+				resources = &d.model.Childs[d.path].Resources
+			}
 			child = &resourceDecoder{resources: resources, model: d.model}
 		case attrBuild:
 			if d.isRoot {
@@ -36,6 +40,15 @@ func (d *modelDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 				child = &metadataDecoder{metadatas: &d.model.Metadata, model: d.model}
 			}
 		}
+	} else if !d.isRoot {
+		// Extensions receive the child model, so model-level elements
+		// that only apply to the root model, such as builds, are ignored.
+		c := d.model.Childs[d.path]
+		if ext, ok := loadExtension(name.Space); ok {
+			child = ext.CreateElementDecoder(c, name.Local)
+		} else {
+			child = &anyUnknownDecoder{UnknownTokensDecoder: spec.UnknownTokensDecoder{Name: name}, Any: &c.Any}
+		}
 	} else if ext, ok := loadExtension(name.Space); ok {
 		child = ext.CreateElementDecoder(d.model, name.Local)
 	} else {
@@ -409,6 +422,10 @@ func (d *meshDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 		} else if name.Local == attrTriangles {
 			child = &trianglesDecoder{resource: d.resource}
 		}
+	} else if name.Space == TriangleSetsNamespace {
+		if name.Local == attrTriangleSets {
+			child = &triangleSetsDecoder{mesh: d.resource.Mesh}
+		}
 	} else if ext, ok := loadExtension(name.Space); ok {
 		child = ext.CreateElementDecoder(d.resource.Mesh, name.Local)
 	} else {
@@ -505,6 +522,10 @@ func (d *triangleDecoder) Start(attrs []spec.Attr) error {
 	)
 
 	for _, a := range attrs {
+		if a.Name.Space != "" {
+			d.addUnknownAttr(a)
+			continue
+		}
 		required := true
 		val, err := strconv.ParseUint(string(a.Value), 10, 32)
 		switch a.Name.Local {
@@ -530,6 +551,9 @@ func (d *triangleDecoder) Start(attrs []spec.Attr) error {
 			p3 = uint32(val)
 			hasP3 = true
 			required = false
+		default:
+			d.addUnknownAttr(a)
+			continue
 		}
 		if err != nil {
 			errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, required))
@@ -549,6 +573,123 @@ func (d *triangleDecoder) Start(attrs []spec.Attr) error {
 	return nil
 }
 
+// addUnknownAttr stores a into the attributes of the triangle being decoded.
+func (d *triangleDecoder) addUnknownAttr(a spec.Attr) {
+	if d.mesh.TriangleAnyAttr == nil {
+		d.mesh.TriangleAnyAttr = make(map[uint32]AnyAttr)
+	}
+	idx := uint32(len(d.mesh.Triangles))
+	any := d.mesh.TriangleAnyAttr[idx]
+	any.AddUnknownAttr(a)
+	d.mesh.TriangleAnyAttr[idx] = any
+}
+
+type triangleSetsDecoder struct {
+	baseDecoder
+	mesh *Mesh
+}
+
+func (d *triangleSetsDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == TriangleSetsNamespace && name.Local == attrTriangleSet {
+		child = &triangleSetDecoder{mesh: d.mesh}
+	}
+	return
+}
+
+type triangleSetDecoder struct {
+	baseDecoder
+	mesh *Mesh
+	idx  int
+}
+
+func (d *triangleSetDecoder) Start(attrs []spec.Attr) error {
+	var set TriangleSet
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			set.Name = string(a.Value)
+		case attrIdentifier:
+			set.Identifier = string(a.Value)
+		}
+	}
+	d.mesh.TriangleSets = append(d.mesh.TriangleSets, set)
+	d.idx = len(d.mesh.TriangleSets) - 1
+	return nil
+}
+
+func (d *triangleSetDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.mesh.TriangleSets[d.idx], d.idx)
+}
+
+func (d *triangleSetDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == TriangleSetsNamespace {
+		if name.Local == attrRef {
+			child = &triangleRefDecoder{set: &d.mesh.TriangleSets[d.idx]}
+		} else if name.Local == attrRefRange {
+			child = &triangleRefRangeDecoder{set: &d.mesh.TriangleSets[d.idx]}
+		}
+	}
+	return
+}
+
+type triangleRefDecoder struct {
+	baseDecoder
+	set *TriangleSet
+}
+
+func (d *triangleRefDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrIndex {
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.set.Refs = append(d.set.Refs, uint32(val))
+		}
+	}
+	return errs
+}
+
+type triangleRefRangeDecoder struct {
+	baseDecoder
+	set *TriangleSet
+}
+
+func (d *triangleRefRangeDecoder) Start(attrs []spec.Attr) error {
+	var (
+		r    TriangleRefRange
+		errs error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		var target *uint32
+		switch a.Name.Local {
+		case attrStartIndex:
+			target = &r.Start
+		case attrEndIndex:
+			target = &r.End
+		default:
+			continue
+		}
+		val, err := strconv.ParseUint(string(a.Value), 10, 32)
+		if err != nil {
+			errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+		}
+		*target = uint32(val)
+	}
+	d.set.RefRanges = append(d.set.RefRanges, r)
+	if errs != nil {
+		return specerr.WrapIndex(errs, r, len(d.set.RefRanges)-1)
+	}
+	return nil
+}
+
 func applyDefault(val, defVal uint32, noDef bool) uint32 {
 	if noDef {
 		return val
@@ -597,6 +738,10 @@ func (d *objectDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 		} else if name.Local == attrMetadataGroup {
 			child = &metadataGroupDecoder{metadatas: &d.resource.Metadata, model: d.model}
 		}
+	} else if ext, ok := loadExtension(name.Space); ok {
+		child = ext.CreateElementDecoder(&d.resource, name.Local)
+	} else {
+		child = &anyUnknownDecoder{UnknownTokensDecoder: spec.UnknownTokensDecoder{Name: name}, Any: &d.resource.Any}
 	}
 	return
 }
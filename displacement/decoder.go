@@ -0,0 +1,467 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package displacement
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/MosaicManufacturing/go3mf/spec"
+)
+
+func (Spec) DecodeAttribute(interface{}, spec.Attr) error {
+	return nil
+}
+
+func (Spec) CreateElementDecoder(parent interface{}, name string) (child spec.ElementDecoder) {
+	switch parent := parent.(type) {
+	case *go3mf.Resources:
+		switch name {
+		case attrDisplacement2D:
+			child = &displacement2DDecoder{resources: parent}
+		case attrNormVectorGroup:
+			child = &normVectorGroupDecoder{resources: parent}
+		case attrDisp2DGroup:
+			child = &disp2DGroupDecoder{resources: parent}
+		}
+	case *go3mf.Object:
+		if name == attrDisplacementMesh {
+			child = &displacementMeshDecoder{obj: parent}
+		}
+	}
+	return
+}
+
+type displacement2DDecoder struct {
+	baseDecoder
+	resources *go3mf.Resources
+	resource  Displacement2D
+}
+
+func (d *displacement2DDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *displacement2DDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		var ok bool
+		switch a.Name.Local {
+		case attrID:
+			id, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.ID = uint32(id)
+		case attrPath:
+			d.resource.Path = string(a.Value)
+		case attrChannel:
+			if d.resource.Channel, ok = newChannel(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrTileStyleU:
+			if d.resource.TileStyleU, ok = newTileStyle(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrTileStyleV:
+			if d.resource.TileStyleV, ok = newTileStyle(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrFilter:
+			if d.resource.Filter, ok = newTextureFilter(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type normVectorGroupDecoder struct {
+	baseDecoder
+	resources         *go3mf.Resources
+	resource          NormVectorGroup
+	normVectorDecoder normVectorDecoder
+}
+
+func (d *normVectorGroupDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *normVectorGroupDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *normVectorGroupDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrNormVector {
+		child = &d.normVectorDecoder
+	}
+	return
+}
+
+func (d *normVectorGroupDecoder) Start(attrs []spec.Attr) error {
+	d.normVectorDecoder.resource = &d.resource
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrID {
+			id, err := strconv.ParseUint(string(a.Value), 10, 32)
+			d.resource.ID = uint32(id)
+			if err != nil {
+				return specerr.WrapIndex(specerr.NewParseAttrError(a.Name.Local, true), &d.resource, len(d.resources.Assets))
+			}
+		}
+	}
+	return nil
+}
+
+type normVectorDecoder struct {
+	baseDecoder
+	resource *NormVectorGroup
+}
+
+func (d *normVectorDecoder) Start(attrs []spec.Attr) error {
+	var (
+		n    go3mf.Point3D
+		errs error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		val, err := strconv.ParseFloat(string(a.Value), 32)
+		if err != nil {
+			errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+		}
+		switch a.Name.Local {
+		case attrX:
+			n[0] = float32(val)
+		case attrY:
+			n[1] = float32(val)
+		case attrZ:
+			n[2] = float32(val)
+		}
+	}
+	d.resource.Vectors = append(d.resource.Vectors, n)
+	if errs != nil {
+		return specerr.WrapIndex(errs, n, len(d.resource.Vectors)-1)
+	}
+	return nil
+}
+
+type disp2DGroupDecoder struct {
+	baseDecoder
+	resources          *go3mf.Resources
+	resource           Disp2DGroup
+	disp2DCoordDecoder disp2DCoordDecoder
+}
+
+func (d *disp2DGroupDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *disp2DGroupDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *disp2DGroupDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrDisp2DCoord {
+		child = &d.disp2DCoordDecoder
+	}
+	return
+}
+
+func (d *disp2DGroupDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.disp2DCoordDecoder.resource = &d.resource
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID, attrDispID, attrNID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			switch a.Name.Local {
+			case attrID:
+				d.resource.ID = uint32(val)
+			case attrDispID:
+				d.resource.DispID = uint32(val)
+			case attrNID:
+				d.resource.NID = uint32(val)
+			}
+		case attrHeight:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.Height = float32(val)
+		case attrOffset:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.Offset = float32(val)
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type disp2DCoordDecoder struct {
+	baseDecoder
+	resource *Disp2DGroup
+}
+
+func (d *disp2DCoordDecoder) Start(attrs []spec.Attr) error {
+	var (
+		c    = Disp2DCoord{F: 1}
+		errs error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrN:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			c.N = uint32(val)
+		case attrU, attrV, attrF:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, a.Name.Local != attrF))
+			}
+			switch a.Name.Local {
+			case attrU:
+				c.U = float32(val)
+			case attrV:
+				c.V = float32(val)
+			case attrF:
+				c.F = float32(val)
+			}
+		}
+	}
+	d.resource.Coords = append(d.resource.Coords, c)
+	if errs != nil {
+		return specerr.WrapIndex(errs, c, len(d.resource.Coords)-1)
+	}
+	return nil
+}
+
+type displacementMeshDecoder struct {
+	baseDecoder
+	obj  *go3mf.Object
+	mesh *DisplacementMesh
+}
+
+func (d *displacementMeshDecoder) Start([]spec.Attr) error {
+	d.mesh = new(DisplacementMesh)
+	d.obj.Any = append(d.obj.Any, d.mesh)
+	return nil
+}
+
+func (d *displacementMeshDecoder) Wrap(err error) error {
+	return specerr.Wrap(err, d.mesh)
+}
+
+func (d *displacementMeshDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace {
+		if name.Local == attrVertices {
+			child = &verticesDecoder{mesh: d.mesh}
+		} else if name.Local == attrTriangles {
+			child = &trianglesDecoder{mesh: d.mesh, obj: d.obj}
+		}
+	}
+	return
+}
+
+type verticesDecoder struct {
+	baseDecoder
+	mesh          *DisplacementMesh
+	vertexDecoder vertexDecoder
+}
+
+func (d *verticesDecoder) Start(_ []spec.Attr) error {
+	d.vertexDecoder.mesh = d.mesh
+	return nil
+}
+
+func (d *verticesDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrVertex {
+		child = &d.vertexDecoder
+	}
+	return
+}
+
+type vertexDecoder struct {
+	baseDecoder
+	mesh *DisplacementMesh
+}
+
+func (d *vertexDecoder) Start(attrs []spec.Attr) error {
+	var (
+		v    go3mf.Point3D
+		errs error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		val, err := strconv.ParseFloat(string(a.Value), 32)
+		if err != nil {
+			errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+		}
+		switch a.Name.Local {
+		case attrX:
+			v[0] = float32(val)
+		case attrY:
+			v[1] = float32(val)
+		case attrZ:
+			v[2] = float32(val)
+		}
+	}
+	d.mesh.Vertices = append(d.mesh.Vertices, v)
+	if errs != nil {
+		return specerr.WrapIndex(errs, v, len(d.mesh.Vertices)-1)
+	}
+	return nil
+}
+
+type trianglesDecoder struct {
+	baseDecoder
+	mesh            *DisplacementMesh
+	obj             *go3mf.Object
+	triangleDecoder triangleDecoder
+}
+
+func (d *trianglesDecoder) Start(attrs []spec.Attr) error {
+	d.triangleDecoder.mesh = d.mesh
+	d.triangleDecoder.obj = d.obj
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrDID {
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			d.mesh.DID = uint32(val)
+			if err != nil {
+				return specerr.NewParseAttrError(a.Name.Local, false)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *trianglesDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrTriangle {
+		child = &d.triangleDecoder
+	}
+	return
+}
+
+type triangleDecoder struct {
+	baseDecoder
+	mesh *DisplacementMesh
+	obj  *go3mf.Object
+}
+
+func (d *triangleDecoder) Start(attrs []spec.Attr) error {
+	var (
+		t                                Triangle
+		did, d1, d2, d3, pid, p1, p2, p3 uint32
+		hasDID, hasD2, hasD3             bool
+		hasPID, hasP1, hasP2, hasP3      bool
+		errs                             error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		required := false
+		val, err := strconv.ParseUint(string(a.Value), 10, 32)
+		switch a.Name.Local {
+		case attrV1:
+			t.V1, required = uint32(val), true
+		case attrV2:
+			t.V2, required = uint32(val), true
+		case attrV3:
+			t.V3, required = uint32(val), true
+		case attrDID:
+			did, hasDID = uint32(val), true
+		case attrD1:
+			d1 = uint32(val)
+		case attrD2:
+			d2, hasD2 = uint32(val), true
+		case attrD3:
+			d3, hasD3 = uint32(val), true
+		case attrPID:
+			pid, hasPID = uint32(val), true
+		case attrP1:
+			p1, hasP1 = uint32(val), true
+		case attrP2:
+			p2, hasP2 = uint32(val), true
+		case attrP3:
+			p3, hasP3 = uint32(val), true
+		default:
+			continue
+		}
+		if err != nil {
+			errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, required))
+		}
+	}
+	t.DID = applyDefault(did, d.mesh.DID, hasDID)
+	t.D1 = d1
+	t.D2 = applyDefault(d2, d1, hasD2)
+	t.D3 = applyDefault(d3, d1, hasD3)
+	t.PID = applyDefault(pid, d.obj.PID, hasPID)
+	t.P1 = applyDefault(p1, d.obj.PIndex, hasP1)
+	t.P2 = applyDefault(p2, t.P1, hasP2)
+	t.P3 = applyDefault(p3, t.P1, hasP3)
+	d.mesh.Triangles = append(d.mesh.Triangles, t)
+	if errs != nil {
+		return specerr.WrapIndex(errs, t, len(d.mesh.Triangles)-1)
+	}
+	return nil
+}
+
+func applyDefault(val, defVal uint32, noDef bool) uint32 {
+	if noDef {
+		return val
+	}
+	return defVal
+}
+
+func newTileStyle(s string) (t materials.TileStyle, ok bool) {
+	t, ok = map[string]materials.TileStyle{
+		"wrap":   materials.TileWrap,
+		"mirror": materials.TileMirror,
+		"clamp":  materials.TileClamp,
+	}[s]
+	return
+}
+
+func newTextureFilter(s string) (t materials.TextureFilter, ok bool) {
+	t, ok = map[string]materials.TextureFilter{
+		"auto":    materials.TextureFilterAuto,
+		"linear":  materials.TextureFilterLinear,
+		"nearest": materials.TextureFilterNearest,
+	}[s]
+	return
+}
+
+type baseDecoder struct {
+}
+
+func (d *baseDecoder) Start([]spec.Attr) error { return nil }
+func (d *baseDecoder) End()                    {}
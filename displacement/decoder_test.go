@@ -0,0 +1,146 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package displacement
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/go-test/deep"
+)
+
+func TestDecode(t *testing.T) {
+	tex := &Displacement2D{ID: 1, Path: "/3D/Textures/disp.png", Channel: ChannelR, TileStyleU: materials.TileMirror, TileStyleV: materials.TileClamp, Filter: materials.TextureFilterNearest}
+	norms := &NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 1}, {0, 1, 0}}}
+	group := &Disp2DGroup{ID: 3, DispID: 1, NID: 2, Height: 2, Offset: -0.5, Coords: []Disp2DCoord{
+		{U: 0, V: 0, N: 0, F: 1}, {U: 1, V: 0.5, N: 1, F: 0.5},
+	}}
+	obj := &go3mf.Object{ID: 4, PID: 5, PIndex: 1, Any: go3mf.Any{&DisplacementMesh{
+		DID:      3,
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+		Triangles: []Triangle{
+			{V1: 0, V2: 1, V3: 2, DID: 3, D1: 1, D2: 1, D3: 1, PID: 5, P1: 1, P2: 1, P3: 1},
+			{V1: 0, V2: 2, V3: 1, DID: 0, PID: 6, P1: 0, P2: 2, P3: 0},
+			{V1: 0, V2: 1, V3: 2, DID: 3, D1: 0, D2: 1, D3: 0, PID: 5, P1: 1, P2: 1, P3: 1},
+		},
+	}}}
+	want := &go3mf.Model{
+		Path:       "/3D/3dmodel.model",
+		Extensions: []go3mf.Extension{DefaultExtension},
+		Resources: go3mf.Resources{
+			Assets:  []go3mf.Asset{tex, norms, group},
+			Objects: []*go3mf.Object{obj},
+		},
+	}
+	got := &go3mf.Model{
+		Path: "/3D/3dmodel.model",
+	}
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:d="http://schemas.microsoft.com/3dmanufacturing/displacement/2022/07">
+		<resources>
+			<d:displacement2d id="1" path="/3D/Textures/disp.png" channel="R" tilestyleu="mirror" tilestylev="clamp" filter="nearest"/>
+			<d:normvectorgroup id="2">
+				<d:normvector x="0" y="0" z="1"/>
+				<d:normvector x="0" y="1" z="0"/>
+			</d:normvectorgroup>
+			<d:disp2dgroup id="3" dispid="1" nid="2" height="2" offset="-0.5">
+				<d:disp2dcoord u="0" v="0" n="0"/>
+				<d:disp2dcoord u="1" v="0.5" n="1" f="0.5"/>
+			</d:disp2dgroup>
+			<object id="4" type="model" pid="5" pindex="1">
+				<d:displacementmesh>
+					<d:vertices>
+						<d:vertex x="0" y="0" z="0"/>
+						<d:vertex x="1" y="0" z="0"/>
+						<d:vertex x="0" y="1" z="0"/>
+					</d:vertices>
+					<d:triangles did="3">
+						<d:triangle v1="0" v2="1" v3="2" d1="1"/>
+						<d:triangle v1="0" v2="2" v3="1" did="0" pid="6" p1="0" p2="2"/>
+						<d:triangle v1="0" v2="1" v3="2" d2="1"/>
+					</d:triangles>
+				</d:displacementmesh>
+			</object>
+		</resources>
+		<build>
+		</build>
+		</model>
+		`
+
+	t.Run("base", func(t *testing.T) {
+		if err := go3mf.UnmarshalModel([]byte(rootFile), got); err != nil {
+			t.Errorf("DecodeRawModel() unexpected error = %v", err)
+			return
+		}
+		if diff := deep.Equal(got, want); diff != nil {
+			t.Errorf("DecodeRawModel() = %v", diff)
+			return
+		}
+	})
+}
+
+func TestDecode_warns(t *testing.T) {
+	want := []string{
+		fmt.Sprintf("Resources@Displacement2D#0: %v", errors.NewParseAttrError("id", true)),
+		fmt.Sprintf("Resources@Displacement2D#0: %v", errors.NewParseAttrError("channel", false)),
+		fmt.Sprintf("Resources@Displacement2D#0: %v", errors.NewParseAttrError("tilestyleu", false)),
+		fmt.Sprintf("Resources@Displacement2D#0: %v", errors.NewParseAttrError("tilestylev", false)),
+		fmt.Sprintf("Resources@Displacement2D#0: %v", errors.NewParseAttrError("filter", false)),
+		fmt.Sprintf("Resources@NormVectorGroup#1: %v", errors.NewParseAttrError("id", true)),
+		fmt.Sprintf("Resources@NormVectorGroup#1@Point3D#0: %v", errors.NewParseAttrError("x", true)),
+		fmt.Sprintf("Resources@Disp2DGroup#2: %v", errors.NewParseAttrError("dispid", true)),
+		fmt.Sprintf("Resources@Disp2DGroup#2: %v", errors.NewParseAttrError("height", true)),
+		fmt.Sprintf("Resources@Disp2DGroup#2: %v", errors.NewParseAttrError("offset", false)),
+		fmt.Sprintf("Resources@Disp2DGroup#2@Disp2DCoord#0: %v", errors.NewParseAttrError("n", true)),
+		fmt.Sprintf("Resources@Disp2DGroup#2@Disp2DCoord#0: %v", errors.NewParseAttrError("f", false)),
+		fmt.Sprintf("Resources@Object#0@DisplacementMesh@Point3D#0: %v", errors.NewParseAttrError("y", true)),
+		fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#0: %v", errors.NewParseAttrError("v1", true)),
+		fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#0: %v", errors.NewParseAttrError("d2", false)),
+	}
+	got := new(go3mf.Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:d="http://schemas.microsoft.com/3dmanufacturing/displacement/2022/07">
+		<resources>
+			<d:displacement2d id="a" path="/3D/Textures/disp.png" channel="X" tilestyleu="b" tilestylev="c" filter="d"/>
+			<d:normvectorgroup id="b">
+				<d:normvector x="a" y="0" z="1"/>
+			</d:normvectorgroup>
+			<d:disp2dgroup id="3" dispid="a" nid="2" height="b" offset="c">
+				<d:disp2dcoord u="0" v="0" n="a" f="b"/>
+			</d:disp2dgroup>
+			<object id="4" type="model">
+				<d:displacementmesh>
+					<d:vertices>
+						<d:vertex x="0" y="a" z="0"/>
+					</d:vertices>
+					<d:triangles>
+						<d:triangle v1="a" v2="1" v3="2" d2="b"/>
+					</d:triangles>
+				</d:displacementmesh>
+			</object>
+		</resources>
+		<build>
+		</build>
+		</model>
+		`
+
+	t.Run("base", func(t *testing.T) {
+		err := go3mf.UnmarshalModel([]byte(rootFile), got)
+		if err == nil {
+			t.Fatal("error expected")
+		}
+		var errs []string
+		for _, err := range err.(*errors.List).Errors {
+			errs = append(errs, err.Error())
+		}
+		if diff := deep.Equal(errs, want); diff != nil {
+			t.Errorf("UnmarshalModel_warn() = %v", diff)
+			return
+		}
+	})
+}
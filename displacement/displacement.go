@@ -0,0 +1,243 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package displacement
+
+import (
+	"errors"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/internal/reference"
+	"github.com/MosaicManufacturing/go3mf/materials"
+)
+
+// Namespace is the canonical name of this extension.
+const Namespace = "http://schemas.microsoft.com/3dmanufacturing/displacement/2022/07"
+
+var DefaultExtension = go3mf.Extension{
+	Namespace:  Namespace,
+	LocalName:  "d",
+	IsRequired: false,
+}
+
+var (
+	ErrDisplacementContent = errors.New("MUST NOT contain a mesh or components")
+	ErrDisplacementRef     = errors.New("dispid MUST reference a displacement2d resource")
+	ErrNormVectorRef       = errors.New("nid MUST reference a normvectorgroup resource")
+	ErrDisp2DGroupRef      = errors.New("did MUST reference a disp2dgroup resource")
+	ErrZeroNormal          = errors.New("normal vectors MUST NOT be zero")
+	ErrNoDisplacementMesh  = errors.New("object does not contain a displacement mesh")
+)
+
+func init() {
+	go3mf.Register(Namespace, Spec{})
+}
+
+type Spec struct{}
+
+// Channel defines the texture channel used as displacement value.
+type Channel uint8
+
+// Supported channels. ChannelG is the default one.
+const (
+	ChannelG Channel = iota
+	ChannelR
+	ChannelB
+	ChannelA
+)
+
+func newChannel(s string) (c Channel, ok bool) {
+	c, ok = map[string]Channel{
+		"R": ChannelR,
+		"G": ChannelG,
+		"B": ChannelB,
+		"A": ChannelA,
+	}[s]
+	return
+}
+
+func (c Channel) String() string {
+	return map[Channel]string{
+		ChannelR: "R",
+		ChannelG: "G",
+		ChannelB: "B",
+		ChannelA: "A",
+	}[c]
+}
+
+// Displacement2D defines a PNG texture whose channel
+// values are used to displace the surface of a mesh.
+type Displacement2D struct {
+	ID         uint32
+	Path       string
+	Channel    Channel
+	TileStyleU materials.TileStyle
+	TileStyleV materials.TileStyle
+	Filter     materials.TextureFilter
+}
+
+// Identify returns the unique ID of the resource.
+func (r *Displacement2D) Identify() uint32 {
+	return r.ID
+}
+
+// Remap replaces the ID of r with the one returned by fn.
+func (r *Displacement2D) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+}
+
+// NormVectorGroup defines a list of displacement directions.
+type NormVectorGroup struct {
+	ID      uint32
+	Vectors []go3mf.Point3D
+}
+
+// Identify returns the unique ID of the resource.
+func (r *NormVectorGroup) Identify() uint32 {
+	return r.ID
+}
+
+// Remap replaces the ID of r with the one returned by fn.
+func (r *NormVectorGroup) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+}
+
+// Len returns the number of vectors.
+func (r *NormVectorGroup) Len() int {
+	return len(r.Vectors)
+}
+
+// Disp2DCoord maps a vertex to a position of the displacement texture.
+// N is the index of the normal vector and F a factor applied to the
+// displacement, which defaults to 1 when decoding.
+type Disp2DCoord struct {
+	U, V float32
+	N    uint32
+	F    float32
+}
+
+// Disp2DGroup defines the displacement coordinates referenced by
+// the triangles of a displacement mesh.
+// The displacement of a coordinate is computed as (Height * value + Offset) * F.
+type Disp2DGroup struct {
+	ID     uint32
+	DispID uint32
+	NID    uint32
+	Height float32
+	Offset float32
+	Coords []Disp2DCoord
+}
+
+// Identify returns the unique ID of the resource.
+func (r *Disp2DGroup) Identify() uint32 {
+	return r.ID
+}
+
+// References returns the IDs of the resources referenced by r.
+func (r *Disp2DGroup) References() []uint32 {
+	return reference.NonZero(r.DispID, r.NID)
+}
+
+// Remap replaces the ID of r, and the IDs it references, with the ones returned by fn.
+func (r *Disp2DGroup) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+	r.DispID = reference.Remap(fn, r.DispID)
+	r.NID = reference.Remap(fn, r.NID)
+}
+
+// Len returns the number of coordinates.
+func (r *Disp2DGroup) Len() int {
+	return len(r.Coords)
+}
+
+// Triangle is a displacement mesh triangle.
+// DID is the Disp2DGroup used by the triangle, 0 if the triangle is not displaced,
+// and D1, D2 and D3 are indices of its coordinates.
+type Triangle struct {
+	V1, V2, V3 uint32
+	DID        uint32
+	D1, D2, D3 uint32
+	PID        uint32
+	P1, P2, P3 uint32
+}
+
+// DisplacementMesh is a mesh whose surface is displaced
+// using displacement textures. DID is the default Disp2DGroup of the triangles.
+type DisplacementMesh struct {
+	DID       uint32
+	Vertices  []go3mf.Point3D
+	Triangles []Triangle
+}
+
+func GetDisplacementMesh(obj *go3mf.Object) *DisplacementMesh {
+	for _, a := range obj.Any {
+		if a, ok := a.(*DisplacementMesh); ok {
+			return a
+		}
+	}
+	return nil
+}
+
+// References returns the IDs of the resources referenced by the mesh and its triangles.
+func (m *DisplacementMesh) References() []uint32 {
+	refs := reference.NonZero(m.DID)
+	for _, t := range m.Triangles {
+		refs = append(refs, reference.NonZero(t.DID, t.PID)...)
+	}
+	return refs
+}
+
+// IsShape returns true, as DisplacementMesh defines the geometry of its object.
+func (m *DisplacementMesh) IsShape() bool {
+	return true
+}
+
+// Remap replaces the IDs referenced by the mesh and its triangles with the ones returned by fn.
+func (m *DisplacementMesh) Remap(fn func(path string, id uint32) (string, uint32)) {
+	m.DID = reference.Remap(fn, m.DID)
+	for i := range m.Triangles {
+		m.Triangles[i].DID = reference.Remap(fn, m.Triangles[i].DID)
+		m.Triangles[i].PID = reference.Remap(fn, m.Triangles[i].PID)
+	}
+}
+
+const (
+	attrDisplacement2D   = "displacement2d"
+	attrNormVectorGroup  = "normvectorgroup"
+	attrNormVector       = "normvector"
+	attrDisp2DGroup      = "disp2dgroup"
+	attrDisp2DCoord      = "disp2dcoord"
+	attrDisplacementMesh = "displacementmesh"
+	attrVertices         = "vertices"
+	attrVertex           = "vertex"
+	attrTriangles        = "triangles"
+	attrTriangle         = "triangle"
+	attrID               = "id"
+	attrPath             = "path"
+	attrChannel          = "channel"
+	attrTileStyleU       = "tilestyleu"
+	attrTileStyleV       = "tilestylev"
+	attrFilter           = "filter"
+	attrX                = "x"
+	attrY                = "y"
+	attrZ                = "z"
+	attrDispID           = "dispid"
+	attrNID              = "nid"
+	attrHeight           = "height"
+	attrOffset           = "offset"
+	attrU                = "u"
+	attrV                = "v"
+	attrN                = "n"
+	attrF                = "f"
+	attrDID              = "did"
+	attrV1               = "v1"
+	attrV2               = "v2"
+	attrV3               = "v3"
+	attrD1               = "d1"
+	attrD2               = "d2"
+	attrD3               = "d3"
+	attrPID              = "pid"
+	attrP1               = "p1"
+	attrP2               = "p2"
+	attrP3               = "p3"
+)
@@ -0,0 +1,110 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package displacement
+
+import (
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/spec"
+	"github.com/go-test/deep"
+)
+
+var _ spec.Marshaler = new(Displacement2D)
+var _ spec.Marshaler = new(NormVectorGroup)
+var _ spec.Marshaler = new(Disp2DGroup)
+var _ spec.Marshaler = new(DisplacementMesh)
+var _ spec.PropertyGroup = new(NormVectorGroup)
+var _ spec.PropertyGroup = new(Disp2DGroup)
+
+func TestChannel_String(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Channel
+	}{
+		{"R", ChannelR},
+		{"G", ChannelG},
+		{"B", ChannelB},
+		{"A", ChannelA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.name {
+				t.Errorf("Channel.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func Test_newChannel(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantC  Channel
+		wantOk bool
+	}{
+		{"R", ChannelR, true},
+		{"G", ChannelG, true},
+		{"B", ChannelB, true},
+		{"A", ChannelA, true},
+		{"r", ChannelG, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotC, gotOk := newChannel(tt.name)
+			if gotC != tt.wantC {
+				t.Errorf("newChannel() gotC = %v, want %v", gotC, tt.wantC)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("newChannel() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetDisplacementMesh(t *testing.T) {
+	dm := new(DisplacementMesh)
+	tests := []struct {
+		name string
+		obj  *go3mf.Object
+		want *DisplacementMesh
+	}{
+		{"empty", new(go3mf.Object), nil},
+		{"found", &go3mf.Object{Any: go3mf.Any{nil, dm}}, dm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetDisplacementMesh(tt.obj); got != tt.want {
+				t.Errorf("GetDisplacementMesh() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLen(t *testing.T) {
+	if got := (&NormVectorGroup{Vectors: make([]go3mf.Point3D, 2)}).Len(); got != 2 {
+		t.Errorf("NormVectorGroup.Len() = %v, want 2", got)
+	}
+	if got := (&Disp2DGroup{Coords: make([]Disp2DCoord, 3)}).Len(); got != 3 {
+		t.Errorf("Disp2DGroup.Len() = %v, want 3", got)
+	}
+}
+
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		r    interface{ References() []uint32 }
+		want []uint32
+	}{
+		{"groupEmpty", new(Disp2DGroup), nil},
+		{"group", &Disp2DGroup{DispID: 1, NID: 2}, []uint32{1, 2}},
+		{"mesh", &DisplacementMesh{DID: 3, Triangles: []Triangle{{DID: 4}, {PID: 5}, {}}}, []uint32{3, 4, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(tt.r.References(), tt.want); diff != nil {
+				t.Errorf("References() = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package displacement
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/MosaicManufacturing/go3mf/spec"
+)
+
+// Marshal3MF encodes the resource.
+func (r *Displacement2D) Marshal3MF(x spec.Encoder) error {
+	x.AddRelationship(spec.Relationship{Path: r.Path, Type: materials.RelTypeTexture3D})
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisplacement2D}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrPath}, Value: r.Path},
+	}}
+	if r.Channel != ChannelG {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrChannel}, Value: r.Channel.String()})
+	}
+	if r.TileStyleU != materials.TileWrap {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTileStyleU}, Value: r.TileStyleU.String()})
+	}
+	if r.TileStyleV != materials.TileWrap {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTileStyleV}, Value: r.TileStyleV.String()})
+	}
+	if r.Filter != materials.TextureFilterAuto {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrFilter}, Value: r.Filter.String()})
+	}
+	x.SetAutoClose(true)
+	x.EncodeToken(xs)
+	x.SetAutoClose(false)
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *NormVectorGroup) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrNormVectorGroup}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	x.SetSkipAttrEscape(true)
+	prec := x.FloatPresicion()
+	start := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrNormVector}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrX}},
+		{Name: xml.Name{Local: attrY}},
+		{Name: xml.Name{Local: attrZ}},
+	}}
+	for _, n := range r.Vectors {
+		start.Attr[0].Value = strconv.FormatFloat(float64(n.X()), 'f', prec, 32)
+		start.Attr[1].Value = strconv.FormatFloat(float64(n.Y()), 'f', prec, 32)
+		start.Attr[2].Value = strconv.FormatFloat(float64(n.Z()), 'f', prec, 32)
+		x.EncodeToken(start)
+	}
+	x.SetSkipAttrEscape(false)
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *Disp2DGroup) Marshal3MF(x spec.Encoder) error {
+	prec := x.FloatPresicion()
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisp2DGroup}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrDispID}, Value: strconv.FormatUint(uint64(r.DispID), 10)},
+		{Name: xml.Name{Local: attrNID}, Value: strconv.FormatUint(uint64(r.NID), 10)},
+		{Name: xml.Name{Local: attrHeight}, Value: strconv.FormatFloat(float64(r.Height), 'f', prec, 32)},
+	}}
+	if r.Offset != 0 {
+		xs.Attr = append(xs.Attr, xml.Attr{
+			Name: xml.Name{Local: attrOffset}, Value: strconv.FormatFloat(float64(r.Offset), 'f', prec, 32),
+		})
+	}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	x.SetSkipAttrEscape(true)
+	start := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisp2DCoord}}
+	attrs := []xml.Attr{
+		{Name: xml.Name{Local: attrU}},
+		{Name: xml.Name{Local: attrV}},
+		{Name: xml.Name{Local: attrN}},
+		{Name: xml.Name{Local: attrF}},
+	}
+	for _, c := range r.Coords {
+		attrs[0].Value = strconv.FormatFloat(float64(c.U), 'f', prec, 32)
+		attrs[1].Value = strconv.FormatFloat(float64(c.V), 'f', prec, 32)
+		attrs[2].Value = strconv.FormatUint(uint64(c.N), 10)
+		start.Attr = attrs[:3]
+		if c.F != 1 {
+			attrs[3].Value = strconv.FormatFloat(float64(c.F), 'f', prec, 32)
+			start.Attr = attrs
+		}
+		x.EncodeToken(start)
+	}
+	x.SetSkipAttrEscape(false)
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (m *DisplacementMesh) Marshal3MF(x spec.Encoder) error {
+	xm := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisplacementMesh}}
+	x.EncodeToken(xm)
+	m.writeVertices(x)
+	m.writeTriangles(x)
+	x.EncodeToken(xm.End())
+	return nil
+}
+
+func (m *DisplacementMesh) writeVertices(x spec.Encoder) {
+	xvs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrVertices}}
+	x.EncodeToken(xvs)
+	prec := x.FloatPresicion()
+	start := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrVertex}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrX}},
+		{Name: xml.Name{Local: attrY}},
+		{Name: xml.Name{Local: attrZ}},
+	}}
+	x.SetAutoClose(true)
+	x.SetSkipAttrEscape(true)
+	for _, v := range m.Vertices {
+		start.Attr[0].Value = strconv.FormatFloat(float64(v.X()), 'f', prec, 32)
+		start.Attr[1].Value = strconv.FormatFloat(float64(v.Y()), 'f', prec, 32)
+		start.Attr[2].Value = strconv.FormatFloat(float64(v.Z()), 'f', prec, 32)
+		x.EncodeToken(start)
+	}
+	x.SetSkipAttrEscape(false)
+	x.SetAutoClose(false)
+	x.EncodeToken(xvs.End())
+}
+
+func (m *DisplacementMesh) writeTriangles(x spec.Encoder) {
+	xts := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTriangles}}
+	if m.DID != 0 {
+		xts.Attr = append(xts.Attr, xml.Attr{Name: xml.Name{Local: attrDID}, Value: strconv.FormatUint(uint64(m.DID), 10)})
+	}
+	x.EncodeToken(xts)
+	start := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTriangle}}
+	attrs := make([]xml.Attr, 0, 11)
+	x.SetAutoClose(true)
+	x.SetSkipAttrEscape(true)
+	for _, t := range m.Triangles {
+		attrs = append(attrs[:0],
+			xml.Attr{Name: xml.Name{Local: attrV1}, Value: strconv.FormatUint(uint64(t.V1), 10)},
+			xml.Attr{Name: xml.Name{Local: attrV2}, Value: strconv.FormatUint(uint64(t.V2), 10)},
+			xml.Attr{Name: xml.Name{Local: attrV3}, Value: strconv.FormatUint(uint64(t.V3), 10)},
+		)
+		if t.DID != m.DID {
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attrDID}, Value: strconv.FormatUint(uint64(t.DID), 10)})
+		}
+		if t.DID != 0 {
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attrD1}, Value: strconv.FormatUint(uint64(t.D1), 10)})
+			if t.D1 != t.D2 || t.D1 != t.D3 {
+				attrs = append(attrs,
+					xml.Attr{Name: xml.Name{Local: attrD2}, Value: strconv.FormatUint(uint64(t.D2), 10)},
+					xml.Attr{Name: xml.Name{Local: attrD3}, Value: strconv.FormatUint(uint64(t.D3), 10)},
+				)
+			}
+		}
+		if t.PID != 0 {
+			attrs = append(attrs,
+				xml.Attr{Name: xml.Name{Local: attrPID}, Value: strconv.FormatUint(uint64(t.PID), 10)},
+				xml.Attr{Name: xml.Name{Local: attrP1}, Value: strconv.FormatUint(uint64(t.P1), 10)},
+			)
+			if t.P1 != t.P2 || t.P1 != t.P3 {
+				attrs = append(attrs,
+					xml.Attr{Name: xml.Name{Local: attrP2}, Value: strconv.FormatUint(uint64(t.P2), 10)},
+					xml.Attr{Name: xml.Name{Local: attrP3}, Value: strconv.FormatUint(uint64(t.P3), 10)},
+				)
+			}
+		}
+		start.Attr = attrs
+		x.EncodeToken(start)
+	}
+	x.SetSkipAttrEscape(false)
+	x.SetAutoClose(false)
+	x.EncodeToken(xts.End())
+}
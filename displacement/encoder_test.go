@@ -0,0 +1,56 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package displacement
+
+import (
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/go-test/deep"
+)
+
+func TestMarshalModel(t *testing.T) {
+	tex := &Displacement2D{ID: 1, Path: "/3D/Textures/disp.png", Channel: ChannelA, TileStyleU: materials.TileClamp, TileStyleV: materials.TileMirror, Filter: materials.TextureFilterLinear}
+	norms := &NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 1}, {0.5, 0.5, 0}}}
+	group := &Disp2DGroup{ID: 3, DispID: 1, NID: 2, Height: 1.5, Offset: 0.25, Coords: []Disp2DCoord{
+		{U: 0.25, V: 0.75, N: 1, F: 1}, {U: 1, V: 0, N: 0, F: 2},
+	}}
+	other := &Disp2DGroup{ID: 4, DispID: 1, NID: 2, Height: 1, Coords: []Disp2DCoord{{F: 1}}}
+	obj := &go3mf.Object{ID: 5, PID: 6, Any: go3mf.Any{&DisplacementMesh{
+		DID:      3,
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+		Triangles: []Triangle{
+			{V1: 0, V2: 1, V3: 2, DID: 3, D1: 1, D2: 1, D3: 1, PID: 6},
+			{V1: 0, V2: 3, V3: 1, DID: 3, D1: 0, D2: 1, D3: 0, PID: 6, P1: 1, P2: 2, P3: 0},
+			{V1: 0, V2: 2, V3: 3, DID: 4, PID: 6},
+			{V1: 1, V2: 3, V3: 2, PID: 6},
+		},
+	}}}
+	m := &go3mf.Model{
+		Path:       "/3D/3dmodel.model",
+		Extensions: []go3mf.Extension{DefaultExtension},
+		Resources: go3mf.Resources{
+			Assets:  []go3mf.Asset{tex, norms, group, other},
+			Objects: []*go3mf.Object{obj},
+		},
+	}
+
+	t.Run("base", func(t *testing.T) {
+		b, err := go3mf.MarshalModel(m)
+		if err != nil {
+			t.Errorf("displacement.MarshalModel() error = %v", err)
+			return
+		}
+		newModel := new(go3mf.Model)
+		newModel.Path = m.Path
+		if err := go3mf.UnmarshalModel(b, newModel); err != nil {
+			t.Errorf("displacement.MarshalModel() error decoding = %v, s = %s", err, string(b))
+			return
+		}
+		if diff := deep.Equal(m, newModel); diff != nil {
+			t.Errorf("displacement.MarshalModel() = %v, s = %s", diff, string(b))
+		}
+	})
+}
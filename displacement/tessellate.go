@@ -0,0 +1,259 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package displacement
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	_ "image/png" // displacement textures are PNG images
+	"math"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/internal/subdivision"
+	"github.com/MosaicManufacturing/go3mf/materials"
+)
+
+// Tessellate converts the displacement mesh of obj into a regular mesh.
+// Each triangle is split into subdivisions² triangles and the new vertices
+// are moved along the interpolated normal vector by the displacement
+// sampled from the texture at the interpolated coordinate.
+//
+// Vertices shared by adjacent triangles are merged, keeping the position
+// computed for the first triangle so the resulting mesh stays closed.
+// The properties of each new triangle corner are taken from
+// the closest corner of the original triangle.
+func Tessellate(m *go3mf.Model, path string, obj *go3mf.Object, subdivisions int) (*go3mf.Mesh, error) {
+	dm := GetDisplacementMesh(obj)
+	if dm == nil {
+		return nil, ErrNoDisplacementMesh
+	}
+	if subdivisions < 1 {
+		subdivisions = 1
+	}
+	t := tessellator{
+		m:        m,
+		path:     path,
+		dm:       dm,
+		s:        subdivisions,
+		mesh:     new(go3mf.Mesh),
+		vertices: make(map[subdivision.VertexKey]uint32),
+		samplers: make(map[uint32]*sampler),
+	}
+	for i := range dm.Triangles {
+		if err := t.tessellate(&dm.Triangles[i]); err != nil {
+			return nil, errors.WrapIndex(err, dm.Triangles[i], i)
+		}
+	}
+	return t.mesh, nil
+}
+
+type corner struct {
+	pos    [3]float64
+	normal [3]float64
+	u, v   float64
+	f      float64
+}
+
+type tessellator struct {
+	m        *go3mf.Model
+	path     string
+	dm       *DisplacementMesh
+	s        int
+	mesh     *go3mf.Mesh
+	vertices map[subdivision.VertexKey]uint32
+	samplers map[uint32]*sampler
+}
+
+func (t *tessellator) tessellate(tri *Triangle) error {
+	indices := [3]uint32{tri.V1, tri.V2, tri.V3}
+	var corners [3]corner
+	for k, idx := range indices {
+		if int(idx) >= len(t.dm.Vertices) {
+			return errors.ErrIndexOutOfBounds
+		}
+		v := t.dm.Vertices[idx]
+		corners[k].pos = [3]float64{float64(v[0]), float64(v[1]), float64(v[2])}
+	}
+	var (
+		group *Disp2DGroup
+		smp   *sampler
+	)
+	if tri.DID != 0 {
+		var err error
+		if group, smp, err = t.displacement(tri.DID); err != nil {
+			return err
+		}
+		norms, ok := t.m.FindAsset(t.path, group.NID)
+		if !ok {
+			return ErrNormVectorRef
+		}
+		normals, ok := norms.(*NormVectorGroup)
+		if !ok {
+			return ErrNormVectorRef
+		}
+		for k, d := range [3]uint32{tri.D1, tri.D2, tri.D3} {
+			if int(d) >= len(group.Coords) {
+				return errors.ErrIndexOutOfBounds
+			}
+			c := group.Coords[d]
+			if int(c.N) >= len(normals.Vectors) {
+				return errors.ErrIndexOutOfBounds
+			}
+			n := normals.Vectors[c.N]
+			corners[k].normal = normalize([3]float64{float64(n[0]), float64(n[1]), float64(n[2])})
+			corners[k].u, corners[k].v, corners[k].f = float64(c.U), float64(c.V), float64(c.F)
+		}
+	}
+
+	// grid[i][j] is the vertex at weights (s-i-j, i, j).
+	grid := make([][]uint32, t.s+1)
+	for i := 0; i <= t.s; i++ {
+		grid[i] = make([]uint32, t.s+1-i)
+		for j := 0; j <= t.s-i; j++ {
+			w := [3]int{t.s - i - j, i, j}
+			grid[i][j] = t.vertex(indices, w, &corners, group, smp)
+		}
+	}
+	props := [3]uint32{tri.P1, tri.P2, tri.P3}
+	addTriangle := func(a, b, c [2]int) {
+		t.mesh.Triangles = append(t.mesh.Triangles, go3mf.Triangle{
+			V1:  grid[a[0]][a[1]],
+			V2:  grid[b[0]][b[1]],
+			V3:  grid[c[0]][c[1]],
+			PID: tri.PID,
+			P1:  props[t.dominant(a)],
+			P2:  props[t.dominant(b)],
+			P3:  props[t.dominant(c)],
+		})
+	}
+	for i := 0; i < t.s; i++ {
+		for j := 0; j < t.s-i; j++ {
+			addTriangle([2]int{i, j}, [2]int{i + 1, j}, [2]int{i, j + 1})
+			if i+j < t.s-1 {
+				addTriangle([2]int{i + 1, j}, [2]int{i + 1, j + 1}, [2]int{i, j + 1})
+			}
+		}
+	}
+	return nil
+}
+
+// dominant returns the original corner with the highest weight at grid position p.
+func (t *tessellator) dominant(p [2]int) int {
+	w := [3]int{t.s - p[0] - p[1], p[0], p[1]}
+	k := 0
+	for i := 1; i < 3; i++ {
+		if w[i] > w[k] {
+			k = i
+		}
+	}
+	return k
+}
+
+func (t *tessellator) vertex(indices [3]uint32, w [3]int, corners *[3]corner, group *Disp2DGroup, smp *sampler) uint32 {
+	key := subdivision.NewVertexKey(indices, w)
+	if idx, ok := t.vertices[key]; ok {
+		return idx
+	}
+	var (
+		pos, normal [3]float64
+		u, v, f     float64
+	)
+	s := float64(t.s)
+	for k := range corners {
+		wk := float64(w[k]) / s
+		for a := 0; a < 3; a++ {
+			pos[a] += corners[k].pos[a] * wk
+			normal[a] += corners[k].normal[a] * wk
+		}
+		u += corners[k].u * wk
+		v += corners[k].v * wk
+		f += corners[k].f * wk
+	}
+	if group != nil {
+		h := (float64(group.Height)*smp.value(u, v) + float64(group.Offset)) * f
+		normal = normalize(normal)
+		for a := 0; a < 3; a++ {
+			pos[a] += normal[a] * h
+		}
+	}
+	idx := uint32(len(t.mesh.Vertices))
+	t.mesh.Vertices = append(t.mesh.Vertices, go3mf.Point3D{float32(pos[0]), float32(pos[1]), float32(pos[2])})
+	t.vertices[key] = idx
+	return idx
+}
+
+func (t *tessellator) displacement(did uint32) (*Disp2DGroup, *sampler, error) {
+	group, ok := findDisp2DGroup(t.m, t.path, did)
+	if !ok {
+		return nil, nil, ErrDisp2DGroupRef
+	}
+	if smp, ok := t.samplers[group.DispID]; ok {
+		return group, smp, nil
+	}
+	a, ok := t.m.FindAsset(t.path, group.DispID)
+	if !ok {
+		return nil, nil, ErrDisplacementRef
+	}
+	tex, ok := a.(*Displacement2D)
+	if !ok {
+		return nil, nil, ErrDisplacementRef
+	}
+	att, ok := t.m.FindAttachment(tex.Path)
+	if !ok {
+		return nil, nil, materials.ErrMissingTexturePart
+	}
+	data, err := att.Bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	smp := &sampler{tex: tex, img: img}
+	t.samplers[group.DispID] = smp
+	return group, smp, nil
+}
+
+type sampler struct {
+	tex *Displacement2D
+	img image.Image
+}
+
+// value returns the normalized channel value at the texture coordinate (u, v).
+func (s *sampler) value(u, v float64) (val float64) {
+	smp := materials.Sampler{Bounds: s.img.Bounds(), TileStyleU: s.tex.TileStyleU, TileStyleV: s.tex.TileStyleV, Filter: s.tex.Filter}
+	for _, t := range smp.Texels(u, v) {
+		if t.Weight != 0 {
+			val += s.texel(t.X, t.Y) * t.Weight
+		}
+	}
+	return val
+}
+
+func (s *sampler) texel(x, y int) float64 {
+	c := color.NRGBA64Model.Convert(s.img.At(x, y)).(color.NRGBA64)
+	var val uint16
+	switch s.tex.Channel {
+	case ChannelR:
+		val = c.R
+	case ChannelG:
+		val = c.G
+	case ChannelB:
+		val = c.B
+	case ChannelA:
+		val = c.A
+	}
+	return float64(val) / 0xffff
+}
+
+func normalize(v [3]float64) [3]float64 {
+	l := math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+	if l == 0 {
+		return v
+	}
+	return [3]float64{v[0] / l, v[1] / l, v[2] / l}
+}
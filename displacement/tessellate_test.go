@@ -0,0 +1,190 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package displacement
+
+import (
+	"bytes"
+	goerrors "errors"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/go-test/deep"
+)
+
+func encodePNG(t *testing.T, img image.Image) *bytes.Buffer {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func uniformTexture(t *testing.T, c color.NRGBA) *bytes.Buffer {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 2; y++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return encodePNG(t, img)
+}
+
+func tessellateModel(t *testing.T, mesh *DisplacementMesh) (*go3mf.Model, *go3mf.Object) {
+	obj := &go3mf.Object{ID: 4, Any: go3mf.Any{mesh}}
+	return &go3mf.Model{
+		Attachments: []go3mf.Attachment{
+			{Path: "/3D/Textures/disp.png", ContentType: "image/png", Stream: uniformTexture(t, color.NRGBA{G: 255, A: 255})},
+		},
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{
+				&Displacement2D{ID: 1, Path: "/3D/Textures/disp.png"},
+				&NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 2}}},
+				&Disp2DGroup{ID: 3, DispID: 1, NID: 2, Height: 2, Offset: -0.5, Coords: []Disp2DCoord{{F: 1}, {F: 0}}},
+			},
+			Objects: []*go3mf.Object{obj},
+		},
+	}, obj
+}
+
+func TestTessellate(t *testing.T) {
+	square := []go3mf.Point3D{{0, 0, 0}, {2, 0, 0}, {2, 2, 0}, {0, 2, 0}}
+	tests := []struct {
+		name         string
+		mesh         *DisplacementMesh
+		subdivisions int
+		want         *go3mf.Mesh
+	}{
+		{"flat", &DisplacementMesh{Vertices: square[:3], Triangles: []Triangle{
+			{V1: 0, V2: 1, V3: 2, PID: 5, P1: 1, P2: 2, P3: 3},
+		}}, 0, &go3mf.Mesh{
+			Vertices:  []go3mf.Point3D{{0, 0, 0}, {2, 2, 0}, {2, 0, 0}},
+			Triangles: []go3mf.Triangle{{V1: 0, V2: 2, V3: 1, PID: 5, P1: 1, P2: 2, P3: 3}},
+		}},
+		{"subdivided", &DisplacementMesh{Vertices: square[:3], Triangles: []Triangle{
+			{V1: 0, V2: 1, V3: 2, PID: 5, P1: 1, P2: 2, P3: 3},
+		}}, 2, &go3mf.Mesh{
+			Vertices: []go3mf.Point3D{{0, 0, 0}, {1, 1, 0}, {2, 2, 0}, {1, 0, 0}, {2, 1, 0}, {2, 0, 0}},
+			Triangles: []go3mf.Triangle{
+				{V1: 0, V2: 3, V3: 1, PID: 5, P1: 1, P2: 1, P3: 1},
+				{V1: 3, V2: 4, V3: 1, PID: 5, P1: 1, P2: 2, P3: 1},
+				{V1: 1, V2: 4, V3: 2, PID: 5, P1: 1, P2: 2, P3: 3},
+				{V1: 3, V2: 5, V3: 4, PID: 5, P1: 1, P2: 2, P3: 2},
+			},
+		}},
+		{"displaced", &DisplacementMesh{Vertices: square, Triangles: []Triangle{
+			{V1: 0, V2: 1, V3: 2, DID: 3},
+			{V1: 0, V2: 2, V3: 3, DID: 3, D1: 0, D2: 0, D3: 1},
+		}}, 1, &go3mf.Mesh{
+			Vertices:  []go3mf.Point3D{{0, 0, 1.5}, {2, 2, 1.5}, {2, 0, 1.5}, {0, 2, 0}},
+			Triangles: []go3mf.Triangle{{V1: 0, V2: 2, V3: 1}, {V1: 0, V2: 1, V3: 3}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, obj := tessellateModel(t, tt.mesh)
+			got, err := Tessellate(m, "", obj, tt.subdivisions)
+			if err != nil {
+				t.Fatalf("Tessellate() error = %v", err)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("Tessellate() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestTessellate_shared(t *testing.T) {
+	m, obj := tessellateModel(t, &DisplacementMesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {2, 0, 0}, {2, 2, 0}, {0, 2, 0}},
+		Triangles: []Triangle{
+			{V1: 0, V2: 1, V3: 2, DID: 3},
+			{V1: 0, V2: 2, V3: 3, DID: 3},
+		},
+	})
+	got, err := Tessellate(m, "", obj, 3)
+	if err != nil {
+		t.Fatalf("Tessellate() error = %v", err)
+	}
+	// 4 corners, 5 edges with 2 inner vertices each and 1 inner vertex per triangle.
+	if len(got.Vertices) != 16 {
+		t.Errorf("Tessellate() vertices = %d, want 16", len(got.Vertices))
+	}
+	if len(got.Triangles) != 18 {
+		t.Errorf("Tessellate() triangles = %d, want 18", len(got.Triangles))
+	}
+	for _, v := range got.Vertices {
+		if v.Z() != 1.5 {
+			t.Errorf("Tessellate() vertex %v not displaced", v)
+		}
+	}
+}
+
+func TestTessellate_error(t *testing.T) {
+	tests := []struct {
+		name string
+		mesh *DisplacementMesh
+		edit func(*go3mf.Model)
+		want error
+	}{
+		{"nomesh", nil, nil, ErrNoDisplacementMesh},
+		{"vertex", &DisplacementMesh{Triangles: []Triangle{{V1: 0, V2: 1, V3: 2}}}, nil, errors.ErrIndexOutOfBounds},
+		{"group", &DisplacementMesh{Vertices: make([]go3mf.Point3D, 3), Triangles: []Triangle{{V1: 0, V2: 1, V3: 2, DID: 2}}}, nil, ErrDisp2DGroupRef},
+		{"coord", &DisplacementMesh{Vertices: make([]go3mf.Point3D, 3), Triangles: []Triangle{{V1: 0, V2: 1, V3: 2, DID: 3, D3: 2}}}, nil, errors.ErrIndexOutOfBounds},
+		{"texture", &DisplacementMesh{Vertices: make([]go3mf.Point3D, 3), Triangles: []Triangle{{V1: 0, V2: 1, V3: 2, DID: 3}}}, func(m *go3mf.Model) {
+			m.Attachments = nil
+		}, materials.ErrMissingTexturePart},
+		{"normals", &DisplacementMesh{Vertices: make([]go3mf.Point3D, 3), Triangles: []Triangle{{V1: 0, V2: 1, V3: 2, DID: 3}}}, func(m *go3mf.Model) {
+			m.Resources.Assets[2].(*Disp2DGroup).NID = 1
+		}, ErrNormVectorRef},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, obj := tessellateModel(t, tt.mesh)
+			if tt.mesh == nil {
+				obj.Any = nil
+			}
+			if tt.edit != nil {
+				tt.edit(m)
+			}
+			if _, err := Tessellate(m, "", obj, 1); !goerrors.Is(err, tt.want) {
+				t.Errorf("Tessellate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sampler_value(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 0, A: 255})
+	img.SetNRGBA(1, 0, color.NRGBA{R: 255, A: 255})
+	tests := []struct {
+		name string
+		tex  Displacement2D
+		u, v float64
+		want float64
+	}{
+		{"nearest-left", Displacement2D{Channel: ChannelR, Filter: materials.TextureFilterNearest}, 0.25, 0.5, 0},
+		{"nearest-right", Displacement2D{Channel: ChannelR, Filter: materials.TextureFilterNearest}, 0.75, 0.5, 1},
+		{"nearest-wrap", Displacement2D{Channel: ChannelR, Filter: materials.TextureFilterNearest}, 1.75, 0.5, 1},
+		{"linear-center", Displacement2D{Channel: ChannelR, TileStyleU: materials.TileClamp}, 0.5, 0.5, 0.5},
+		{"linear-clamp", Displacement2D{Channel: ChannelR, TileStyleU: materials.TileClamp}, 1.5, 0.5, 1},
+		{"linear-none", Displacement2D{Channel: ChannelR, TileStyleU: materials.TileNone}, 1.5, 0.5, 1},
+		{"linear-wrap", Displacement2D{Channel: ChannelR}, 0, 0.5, 0.5},
+		{"alpha", Displacement2D{Channel: ChannelA}, 0.3, 0.5, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &sampler{tex: &tt.tex, img: img}
+			if got := s.value(tt.u, tt.v); math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("sampler.value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package displacement
+
+import (
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/MosaicManufacturing/go3mf/spec"
+)
+
+func (Spec) Validate(m interface{}, path string, obj interface{}) error {
+	switch obj := obj.(type) {
+	case *Displacement2D:
+		return validateDisplacement2D(m.(*go3mf.Model), obj)
+	case *NormVectorGroup:
+		return validateNormVectorGroup(obj)
+	case *Disp2DGroup:
+		return validateDisp2DGroup(m.(*go3mf.Model), path, obj)
+	case *go3mf.Object:
+		return validateObject(m.(*go3mf.Model), path, obj)
+	}
+	return nil
+}
+
+func validateDisplacement2D(m *go3mf.Model, r *Displacement2D) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Path == "" {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrPath))
+	} else if _, ok := m.FindAttachment(r.Path); !ok {
+		errs = errors.Append(errs, materials.ErrMissingTexturePart)
+	}
+	return
+}
+
+func validateNormVectorGroup(r *NormVectorGroup) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Vectors) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for i, n := range r.Vectors {
+		if n == (go3mf.Point3D{}) {
+			errs = errors.Append(errs, errors.WrapIndex(ErrZeroNormal, n, i))
+		}
+	}
+	return
+}
+
+func validateDisp2DGroup(m *go3mf.Model, path string, r *Disp2DGroup) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.DispID == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrDispID))
+	} else if a, ok := m.FindAsset(path, r.DispID); !ok {
+		errs = errors.Append(errs, ErrDisplacementRef)
+	} else if _, ok := a.(*Displacement2D); !ok {
+		errs = errors.Append(errs, ErrDisplacementRef)
+	}
+	var norms *NormVectorGroup
+	if r.NID == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrNID))
+	} else if a, ok := m.FindAsset(path, r.NID); !ok {
+		errs = errors.Append(errs, ErrNormVectorRef)
+	} else if norms, ok = a.(*NormVectorGroup); !ok {
+		errs = errors.Append(errs, ErrNormVectorRef)
+	}
+	if len(r.Coords) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	if norms != nil {
+		for i, c := range r.Coords {
+			if int(c.N) >= len(norms.Vectors) {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, c, i))
+			}
+		}
+	}
+	return
+}
+
+func validateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
+	mesh := GetDisplacementMesh(obj)
+	if mesh == nil {
+		return nil
+	}
+	var errs error
+	if obj.Mesh != nil || obj.Components != nil {
+		errs = errors.Append(errs, ErrDisplacementContent)
+	}
+	switch obj.Type {
+	case go3mf.ObjectTypeModel, go3mf.ObjectTypeSolidSupport:
+		if len(mesh.Vertices) < 3 {
+			errs = errors.Append(errs, errors.ErrInsufficientVertices)
+		}
+		if len(mesh.Triangles) <= 3 {
+			errs = errors.Append(errs, errors.ErrInsufficientTriangles)
+		}
+	}
+	if mesh.DID != 0 {
+		if _, ok := findDisp2DGroup(m, path, mesh.DID); !ok {
+			errs = errors.Append(errs, ErrDisp2DGroupRef)
+		}
+	}
+	res, _ := m.FindResources(path)
+	nodeCount := uint32(len(mesh.Vertices))
+	for i, t := range mesh.Triangles {
+		if t.V1 == t.V2 || t.V1 == t.V3 || t.V2 == t.V3 {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrDuplicatedIndices, t, i))
+		}
+		if t.V1 >= nodeCount || t.V2 >= nodeCount || t.V3 >= nodeCount {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, t, i))
+		}
+		if t.DID != 0 {
+			// A missing default group is already reported once for the whole mesh.
+			if g, ok := findDisp2DGroup(m, path, t.DID); !ok {
+				if t.DID != mesh.DID {
+					errs = errors.Append(errs, errors.WrapIndex(ErrDisp2DGroupRef, t, i))
+				}
+			} else if l := uint32(len(g.Coords)); t.D1 >= l || t.D2 >= l || t.D3 >= l {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, t, i))
+			}
+		}
+		if t.PID != 0 && (t.PID != obj.PID || t.P1 != obj.PIndex || t.P2 != obj.PIndex || t.P3 != obj.PIndex) {
+			if a, ok := res.FindAsset(t.PID); ok {
+				if a, ok := a.(spec.PropertyGroup); ok {
+					l := a.Len()
+					if int(t.P1) >= l || int(t.P2) >= l || int(t.P3) >= l {
+						errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, t, i))
+					}
+				}
+			} else {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrMissingResource, t, i))
+			}
+		}
+	}
+	if errs != nil {
+		errs = errors.Wrap(errs, mesh)
+	}
+	return errs
+}
+
+func findDisp2DGroup(m *go3mf.Model, path string, id uint32) (*Disp2DGroup, bool) {
+	if a, ok := m.FindAsset(path, id); ok {
+		g, ok := a.(*Disp2DGroup)
+		return g, ok
+	}
+	return nil, false
+}
@@ -0,0 +1,134 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package displacement
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/go-test/deep"
+)
+
+func validMesh(did uint32) *DisplacementMesh {
+	return &DisplacementMesh{DID: did, Vertices: []go3mf.Point3D{{}, {}, {}, {}}, Triangles: []Triangle{
+		{V1: 0, V2: 1, V3: 2, DID: did}, {V1: 0, V2: 3, V3: 1, DID: did}, {V1: 0, V2: 2, V3: 3, DID: did}, {V1: 1, V2: 3, V3: 2, DID: did},
+	}}
+}
+
+func validResources() []go3mf.Asset {
+	return []go3mf.Asset{
+		&Displacement2D{ID: 1, Path: "/3D/Textures/disp.png"},
+		&NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 1}}},
+		&Disp2DGroup{ID: 3, DispID: 1, NID: 2, Height: 1, Coords: []Disp2DCoord{{N: 0, F: 1}}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	attachments := []go3mf.Attachment{{Path: "/3D/Textures/disp.png", ContentType: "image/png", Stream: new(bytes.Buffer)}}
+	tests := []struct {
+		name  string
+		model *go3mf.Model
+		want  []string
+	}{
+		{"valid", &go3mf.Model{Attachments: attachments, Resources: go3mf.Resources{
+			Assets: validResources(),
+			Objects: []*go3mf.Object{
+				{ID: 4, Any: go3mf.Any{validMesh(3)}},
+				{ID: 5, Any: go3mf.Any{validMesh(0)}},
+			},
+		}}, nil},
+		{"displacement2d", &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&Displacement2D{},
+			&Displacement2D{ID: 2, Path: "/3D/Textures/other.png"},
+		}}}, []string{
+			fmt.Sprintf("Resources@Displacement2D#0: %v", errors.ErrMissingID),
+			fmt.Sprintf("Resources@Displacement2D#0: %v", &errors.MissingFieldError{Name: attrPath}),
+			fmt.Sprintf("Resources@Displacement2D#1: %v", materials.ErrMissingTexturePart),
+		}},
+		{"normvectorgroup", &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&NormVectorGroup{},
+			&NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 1}, {}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@NormVectorGroup#0: %v", errors.ErrMissingID),
+			fmt.Sprintf("Resources@NormVectorGroup#0: %v", errors.ErrEmptyResourceProps),
+			fmt.Sprintf("Resources@NormVectorGroup#1@Point3D#1: %v", ErrZeroNormal),
+		}},
+		{"disp2dgroup", &go3mf.Model{Attachments: attachments, Resources: go3mf.Resources{Assets: append(validResources(),
+			&Disp2DGroup{},
+			&Disp2DGroup{ID: 5, DispID: 2, NID: 1, Coords: []Disp2DCoord{{}}},
+			&Disp2DGroup{ID: 6, DispID: 100, NID: 100, Coords: []Disp2DCoord{{}}},
+			&Disp2DGroup{ID: 7, DispID: 1, NID: 2, Coords: []Disp2DCoord{{N: 0}, {N: 1}}},
+		)}}, []string{
+			fmt.Sprintf("Resources@Disp2DGroup#3: %v", errors.ErrMissingID),
+			fmt.Sprintf("Resources@Disp2DGroup#3: %v", &errors.MissingFieldError{Name: attrDispID}),
+			fmt.Sprintf("Resources@Disp2DGroup#3: %v", &errors.MissingFieldError{Name: attrNID}),
+			fmt.Sprintf("Resources@Disp2DGroup#3: %v", errors.ErrEmptyResourceProps),
+			fmt.Sprintf("Resources@Disp2DGroup#4: %v", ErrDisplacementRef),
+			fmt.Sprintf("Resources@Disp2DGroup#4: %v", ErrNormVectorRef),
+			fmt.Sprintf("Resources@Disp2DGroup#5: %v", ErrDisplacementRef),
+			fmt.Sprintf("Resources@Disp2DGroup#5: %v", ErrNormVectorRef),
+			fmt.Sprintf("Resources@Disp2DGroup#6@Disp2DCoord#1: %v", errors.ErrIndexOutOfBounds),
+		}},
+		{"object", &go3mf.Model{Attachments: attachments, Resources: go3mf.Resources{
+			Assets: validResources(),
+			Objects: []*go3mf.Object{
+				{ID: 4, Components: &go3mf.Components{}, Any: go3mf.Any{validMesh(3)}},
+				{ID: 5, Any: go3mf.Any{&DisplacementMesh{DID: 100}}},
+				{ID: 6, Type: go3mf.ObjectTypeOther, Any: go3mf.Any{&DisplacementMesh{}}},
+			},
+		}}, []string{
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh: %v", ErrDisplacementContent),
+			fmt.Sprintf("Resources@Object#1@DisplacementMesh: %v", errors.ErrInsufficientVertices),
+			fmt.Sprintf("Resources@Object#1@DisplacementMesh: %v", errors.ErrInsufficientTriangles),
+			fmt.Sprintf("Resources@Object#1@DisplacementMesh: %v", ErrDisp2DGroupRef),
+		}},
+		{"triangles", &go3mf.Model{Attachments: attachments, Resources: go3mf.Resources{
+			Assets: append(validResources(), &go3mf.BaseMaterials{ID: 10, Materials: []go3mf.Base{{Name: "a", Color: color.RGBA{A: 255}}}}),
+			Objects: []*go3mf.Object{
+				{ID: 4, Any: go3mf.Any{&DisplacementMesh{DID: 3, Vertices: []go3mf.Point3D{{}, {}, {}}, Triangles: []Triangle{
+					{V1: 0, V2: 0, V3: 1, DID: 3},
+					{V1: 0, V2: 1, V3: 3, DID: 3},
+					{V1: 0, V2: 1, V3: 2, DID: 2},
+					{V1: 0, V2: 1, V3: 2, DID: 3, D2: 1},
+					{V1: 0, V2: 1, V3: 2, PID: 100},
+					{V1: 0, V2: 1, V3: 2, PID: 10, P3: 1},
+				}}}},
+			},
+		}}, []string{
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#0: %v", errors.ErrDuplicatedIndices),
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#1: %v", errors.ErrIndexOutOfBounds),
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#2: %v", ErrDisp2DGroupRef),
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#3: %v", errors.ErrIndexOutOfBounds),
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#4: %v", errors.ErrMissingResource),
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#5: %v", errors.ErrIndexOutOfBounds),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.model.Extensions = []go3mf.Extension{DefaultExtension}
+			err := tt.model.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate() err = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("error expected")
+			}
+			var errs []string
+			for _, err := range err.(*errors.List).Errors {
+				errs = append(errs, err.Error())
+			}
+			if diff := deep.Equal(errs, tt.want); diff != nil {
+				t.Errorf("Validate() = %v", diff)
+			}
+		})
+	}
+}
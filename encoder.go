@@ -85,6 +85,49 @@ type packageWriter interface {
 	Close() error
 }
 
+// A PackageWriter adds parts and root relationships to a 3MF package.
+type PackageWriter interface {
+	Create(name, contentType string) (io.Writer, error)
+	AddRelationship(Relationship)
+}
+
+// A PartWriter processes the content of the package parts
+// before they are stored, e.g. to encrypt them.
+type PartWriter interface {
+	// WritePart returns a writer that receives the content of the part located at path
+	// and stores the processed content into w.
+	// It is closed once the part has been completely written.
+	WritePart(path string, w io.Writer) (io.WriteCloser, error)
+	// Flush is called after all the parts have been written
+	// and can be used to add new parts to the package.
+	Flush(p PackageWriter) error
+}
+
+type packageWriterAdapter struct {
+	w packageWriter
+}
+
+func (p packageWriterAdapter) Create(name, contentType string) (io.Writer, error) {
+	return p.w.Create(name, contentType)
+}
+
+func (p packageWriterAdapter) AddRelationship(r Relationship) {
+	p.w.AddRelationship(r)
+}
+
+type processedPart struct {
+	packagePart
+	w io.WriteCloser
+}
+
+func (p *processedPart) Write(b []byte) (int, error) {
+	return p.w.Write(b)
+}
+
+func (p *processedPart) Close() error {
+	return p.w.Close()
+}
+
 // MarshalModel returns the XML encoding of m.
 func MarshalModel(m *Model) ([]byte, error) {
 	var b bytes.Buffer
@@ -123,6 +166,7 @@ func CreateWriter(name string) (*WriteCloser, error) {
 type Encoder struct {
 	FloatPrecision int
 	w              packageWriter
+	partWriter     PartWriter
 }
 
 // NewEncoder returns a new encoder that writes to w.
@@ -133,6 +177,12 @@ func NewEncoder(w io.Writer) *Encoder {
 	}
 }
 
+// SetPartWriter sets the PartWriter used to process the model
+// and attachment parts before storing them.
+func (e *Encoder) SetPartWriter(w PartWriter) {
+	e.partWriter = w
+}
+
 // Encode writes the XML encoding of m to the stream.
 func (e *Encoder) Encode(m *Model) error {
 	if err := e.writeAttachements(m.Attachments); err != nil {
@@ -144,7 +194,7 @@ func (e *Encoder) Encode(m *Model) error {
 	}
 	e.w.AddRelationship(Relationship{Type: RelType3DModel, Path: rootName})
 
-	w, err := e.w.Create(rootName, ContentType3DModel)
+	w, err := e.createPart(rootName, ContentType3DModel)
 	if err != nil {
 		return err
 	}
@@ -160,24 +210,53 @@ func (e *Encoder) Encode(m *Model) error {
 	if err = e.writeModel(enc, m); err != nil {
 		return err
 	}
+	if err = closePart(w); err != nil {
+		return err
+	}
 	for _, r := range enc.relationships {
 		w.AddRelationship(r)
 	}
 	if err = e.writeChildModels(m); err != nil {
 		return err
 	}
-
+	if e.partWriter != nil {
+		if err = e.partWriter.Flush(packageWriterAdapter{e.w}); err != nil {
+			return err
+		}
+	}
 	return e.w.Close()
 }
 
+func (e *Encoder) createPart(name, contentType string) (packagePart, error) {
+	p, err := e.w.Create(name, contentType)
+	if err != nil || e.partWriter == nil {
+		return p, err
+	}
+	w, err := e.partWriter.WritePart(name, p)
+	if err != nil {
+		return nil, err
+	}
+	return &processedPart{packagePart: p, w: w}, nil
+}
+
+func closePart(p packagePart) error {
+	if c, ok := p.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 func (e *Encoder) writeChildModels(m *Model) error {
 	for path, child := range m.Childs {
 		var (
 			w   packagePart
 			err error
 		)
+		if err = child.Load(); err != nil {
+			return err
+		}
 		path = resolveRelationship(m.PathOrDefault(), path)
-		if w, err = e.w.Create(path, ContentType3DModel); err != nil {
+		if w, err = e.createPart(path, ContentType3DModel); err != nil {
 			return err
 		}
 		if _, err = w.Write([]byte(xml.Header)); err != nil {
@@ -188,6 +267,9 @@ func (e *Encoder) writeChildModels(m *Model) error {
 		if err = e.writeChildModel(enc, m, child); err != nil {
 			return err
 		}
+		if err = closePart(w); err != nil {
+			return err
+		}
 		for _, r := range enc.relationships {
 			w.AddRelationship(r)
 		}
@@ -197,10 +279,13 @@ func (e *Encoder) writeChildModels(m *Model) error {
 
 func (e *Encoder) writeAttachements(att []Attachment) error {
 	for _, a := range att {
-		w, err := e.w.Create(a.Path, a.ContentType)
+		w, err := e.createPart(a.Path, a.ContentType)
 		if err == nil {
 			_, err = io.Copy(w, a.Stream)
 		}
+		if err == nil {
+			err = closePart(w)
+		}
 		if err != nil {
 			return err
 		}
@@ -208,7 +293,7 @@ func (e *Encoder) writeAttachements(att []Attachment) error {
 	return nil
 }
 
-func (e *Encoder) modelToken(x spec.Encoder, m *Model, isRoot bool) (xml.StartElement, error) {
+func (e *Encoder) modelToken(x spec.Encoder, m *Model, rs *Resources, isRoot bool) (xml.StartElement, error) {
 	attrs := []xml.Attr{
 		{Name: xml.Name{Local: attrXmlns}, Value: Namespace},
 		{Name: xml.Name{Local: attrUnit}, Value: m.Units.String()},
@@ -223,6 +308,9 @@ func (e *Encoder) modelToken(x spec.Encoder, m *Model, isRoot bool) (xml.StartEl
 	for _, ext := range m.Extensions {
 		attrs = append(attrs, xml.Attr{Name: xml.Name{Space: attrXmlns, Local: ext.LocalName}, Value: ext.Namespace})
 	}
+	if !hasExtension(m.Extensions, TriangleSetsNamespace) && hasTriangleSets(rs) {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Space: attrXmlns, Local: triangleSetsPrefix}, Value: TriangleSetsNamespace})
+	}
 	var exts []string
 	for _, ext := range m.Extensions {
 		if ext.IsRequired {
@@ -239,7 +327,7 @@ func (e *Encoder) modelToken(x spec.Encoder, m *Model, isRoot bool) (xml.StartEl
 }
 
 func (e *Encoder) writeChildModel(x spec.Encoder, m *Model, child *ChildModel) error {
-	tm, _ := e.modelToken(x, m, false) // error already checked before
+	tm, _ := e.modelToken(x, m, &child.Resources, false) // error already checked before
 	x.EncodeToken(tm)
 
 	if err := e.writeResources(x, &child.Resources); err != nil {
@@ -255,7 +343,7 @@ func (e *Encoder) writeChildModel(x spec.Encoder, m *Model, child *ChildModel) e
 }
 
 func (e *Encoder) writeModel(x spec.Encoder, m *Model) error {
-	tm, err := e.modelToken(x, m, true)
+	tm, err := e.modelToken(x, m, &m.Resources, true)
 	if err != nil {
 		return err
 	}
@@ -379,7 +467,7 @@ func (e *Encoder) writeObject(x spec.Encoder, r *Object) {
 	if r.Name != "" {
 		xo.Attr = append(xo.Attr, xml.Attr{Name: xml.Name{Local: attrName}, Value: r.Name})
 	}
-	if r.Mesh != nil {
+	if r.Components == nil {
 		if r.PID != 0 {
 			xo.Attr = append(xo.Attr, xml.Attr{
 				Name: xml.Name{Local: attrPID}, Value: strconv.FormatUint(uint64(r.PID), 10),
@@ -403,6 +491,7 @@ func (e *Encoder) writeObject(x spec.Encoder, r *Object) {
 	} else if r.Components != nil {
 		e.writeComponents(x, r.Components)
 	}
+	r.Any.encode(x)
 	x.EncodeToken(xo.End())
 }
 
@@ -469,7 +558,7 @@ func (e *Encoder) writeTriangles(x spec.Encoder, r *Object, m *Mesh) {
 	}
 	x.SetAutoClose(true)
 	x.SetSkipAttrEscape(true)
-	for _, t := range m.Triangles {
+	for i, t := range m.Triangles {
 		attrs[0].Value = strconv.FormatUint(uint64(t.V1), 10)
 		attrs[1].Value = strconv.FormatUint(uint64(t.V2), 10)
 		attrs[2].Value = strconv.FormatUint(uint64(t.V3), 10)
@@ -487,6 +576,15 @@ func (e *Encoder) writeTriangles(x spec.Encoder, r *Object, m *Mesh) {
 				start.Attr = attrs[:5]
 			}
 		}
+		if any, ok := m.TriangleAnyAttr[uint32(i)]; ok {
+			// Limit the capacity so appending does not overwrite the reused buffer.
+			start.Attr = start.Attr[:len(start.Attr):len(start.Attr)]
+			any.encode(x, &start)
+			x.SetSkipAttrEscape(false)
+			x.EncodeToken(start)
+			x.SetSkipAttrEscape(true)
+			continue
+		}
 		x.EncodeToken(start)
 	}
 	x.SetSkipAttrEscape(false)
@@ -501,11 +599,61 @@ func (e *Encoder) writeMesh(x spec.Encoder, r *Object, m *Mesh) {
 
 	e.writeVertices(x, m)
 	e.writeTriangles(x, r, m)
+	if len(m.TriangleSets) > 0 {
+		e.writeTriangleSets(x, m)
+	}
 
 	m.Any.encode(x)
 	x.EncodeToken(xm.End())
 }
 
+func (e *Encoder) writeTriangleSets(x spec.Encoder, m *Mesh) {
+	xs := xml.StartElement{Name: xml.Name{Space: TriangleSetsNamespace, Local: attrTriangleSets}}
+	x.EncodeToken(xs)
+	for _, set := range m.TriangleSets {
+		xt := xml.StartElement{Name: xml.Name{Space: TriangleSetsNamespace, Local: attrTriangleSet}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: set.Name},
+			{Name: xml.Name{Local: attrIdentifier}, Value: set.Identifier},
+		}}
+		x.EncodeToken(xt)
+		x.SetAutoClose(true)
+		x.SetSkipAttrEscape(true)
+		for _, ref := range set.Refs {
+			x.EncodeToken(xml.StartElement{Name: xml.Name{Space: TriangleSetsNamespace, Local: attrRef}, Attr: []xml.Attr{
+				{Name: xml.Name{Local: attrIndex}, Value: strconv.FormatUint(uint64(ref), 10)},
+			}})
+		}
+		for _, r := range set.RefRanges {
+			x.EncodeToken(xml.StartElement{Name: xml.Name{Space: TriangleSetsNamespace, Local: attrRefRange}, Attr: []xml.Attr{
+				{Name: xml.Name{Local: attrStartIndex}, Value: strconv.FormatUint(uint64(r.Start), 10)},
+				{Name: xml.Name{Local: attrEndIndex}, Value: strconv.FormatUint(uint64(r.End), 10)},
+			}})
+		}
+		x.SetSkipAttrEscape(false)
+		x.SetAutoClose(false)
+		x.EncodeToken(xt.End())
+	}
+	x.EncodeToken(xs.End())
+}
+
+func hasExtension(exts []Extension, ns string) bool {
+	for _, ext := range exts {
+		if ext.Namespace == ns {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTriangleSets(rs *Resources) bool {
+	for _, o := range rs.Objects {
+		if o.Mesh != nil && len(o.Mesh.TriangleSets) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *BaseMaterials) Marshal3MF(x spec.Encoder) error {
 	xt := xml.StartElement{Name: xml.Name{Local: attrBaseMaterials}, Attr: []xml.Attr{
 		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
@@ -539,7 +687,7 @@ func (e AnyAttr) encode(x spec.Encoder, start *xml.StartElement) {
 
 func (e Any) encode(x spec.Encoder) error {
 	for _, ext := range e {
-		if err := ext.Marshal3MF(x); err == nil {
+		if err := ext.Marshal3MF(x); err != nil {
 			return err
 		}
 	}
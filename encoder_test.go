@@ -8,6 +8,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"image/color"
+	"io"
 	"reflect"
 	"strconv"
 	"testing"
@@ -95,6 +96,10 @@ func TestMarshalModel(t *testing.T) {
 							{V1: 3, V2: 0, V3: 4, PID: 5, P1: 0, P2: 0, P3: 0},
 							{V1: 4, V2: 7, V3: 3, PID: 5, P1: 0, P2: 0, P3: 0},
 						},
+						TriangleAnyAttr: map[uint32]AnyAttr{
+							1: {&spec.UnknownAttrs{{Name: fooName, Value: "a<&b"}}},
+							4: {&spec.UnknownAttrs{{Name: xml.Name{Local: "paint_color"}, Value: "1C"}}},
+						},
 					}},
 				{
 					ID: 20, Type: ObjectTypeSupport,
@@ -135,6 +140,40 @@ func TestMarshalModel(t *testing.T) {
 	})
 }
 
+type failingElement struct{}
+
+func (failingElement) Marshal3MF(_ spec.Encoder) error {
+	return errors.New("failing element")
+}
+
+func TestAny_encode(t *testing.T) {
+	a := spec.UnknownTokens{xml.StartElement{Name: xml.Name{Local: "a"}}, xml.EndElement{Name: xml.Name{Local: "a"}}}
+	b := spec.UnknownTokens{xml.StartElement{Name: xml.Name{Local: "b"}}, xml.EndElement{Name: xml.Name{Local: "b"}}}
+	tests := []struct {
+		name    string
+		e       Any
+		want    string
+		wantErr bool
+	}{
+		{"empty", nil, "", false},
+		{"all", Any{a, b}, "<a></a><b></b>", false},
+		{"error", Any{a, failingElement{}, b}, "<a></a>", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			x := newXMLEncoder(&buf, 4)
+			if err := tt.e.encode(x); (err != nil) != tt.wantErr {
+				t.Errorf("Any.encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			x.Flush()
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Any.encode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestEncoder_writeAttachements(t *testing.T) {
 	type args struct {
 		m *Model
@@ -288,6 +327,16 @@ func TestEncoder_Encode_Roundtrip(t *testing.T) {
 				},
 			}}},
 		},
+		{"withTriangleSets", args{&Model{
+			Extensions: []Extension{{Namespace: TriangleSetsNamespace, LocalName: "t"}},
+			Resources: Resources{Objects: []*Object{
+				{ID: 1, Mesh: &Mesh{Vertices: []Point3D{{}, {}, {}}, Triangles: []Triangle{{V1: 0, V2: 1, V3: 2}, {V1: 0, V2: 2, V3: 1}},
+					TriangleSets: []TriangleSet{
+						{Name: "a", Identifier: "ida", Refs: []uint32{1}},
+						{Name: "b&c", Identifier: "idb", Refs: []uint32{0}, RefRanges: []TriangleRefRange{{Start: 0, End: 1}}},
+					}}},
+			}}}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -311,3 +360,104 @@ func TestEncoder_Encode_Roundtrip(t *testing.T) {
 		})
 	}
 }
+
+func TestEncoder_Encode_TriangleSetsNamespace(t *testing.T) {
+	m := &Model{Resources: Resources{Objects: []*Object{
+		{ID: 1, Mesh: &Mesh{Vertices: []Point3D{{}, {}, {}}, Triangles: []Triangle{{V1: 0, V2: 1, V3: 2}},
+			TriangleSets: []TriangleSet{{Name: "a", Identifier: "a", RefRanges: []TriangleRefRange{{Start: 0, End: 0}}}}}},
+	}}}
+	buff := new(bytes.Buffer)
+	if err := NewEncoder(buff).Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	newModel := new(Model)
+	if err := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len())).Decode(newModel); err != nil {
+		t.Fatalf("Encoder.Encode() malformed = %v", err)
+	}
+	want := []Extension{{Namespace: TriangleSetsNamespace, LocalName: "t"}}
+	if diff := deep.Equal(newModel.Extensions, want); diff != nil {
+		t.Errorf("Encoder.Encode() extensions = %v", diff)
+	}
+	if diff := deep.Equal(newModel.Resources.Objects[0].Mesh.TriangleSets, m.Resources.Objects[0].Mesh.TriangleSets); diff != nil {
+		t.Errorf("Encoder.Encode() triangle sets = %v", diff)
+	}
+}
+
+type xorPart struct {
+	io.Writer
+	flushed bool
+}
+
+func (x *xorPart) Write(p []byte) (int, error) {
+	b := make([]byte, len(p))
+	for i := range p {
+		b[i] = p[i] ^ 0xff
+	}
+	return x.Writer.Write(b)
+}
+
+func (x *xorPart) Close() error { return nil }
+
+func (x *xorPart) WritePart(_ string, w io.Writer) (io.WriteCloser, error) {
+	return &xorPart{Writer: w}, nil
+}
+
+func (x *xorPart) Flush(p PackageWriter) error {
+	x.flushed = true
+	w, err := p.Create("/other.txt", "text/plain")
+	if err == nil {
+		_, err = w.Write([]byte("other"))
+	}
+	return err
+}
+
+func (x *xorPart) ReadPart(_ *Model, path string, r io.Reader) (io.Reader, error) {
+	if path == "/other.txt" {
+		return r, nil
+	}
+	b, err := io.ReadAll(r)
+	for i := range b {
+		b[i] ^= 0xff
+	}
+	return bytes.NewReader(b), err
+}
+
+func TestEncoder_SetPartWriter(t *testing.T) {
+	m := &Model{
+		Path:     DefaultModelPath,
+		Metadata: []Metadata{{Name: xml.Name{Local: "Title"}, Value: "xor"}},
+		Childs:   map[string]*ChildModel{"/other.model": {}},
+		RootRelationships: []Relationship{
+			{Path: "/Metadata/thumbnail.png", Type: RelTypeThumbnail, ID: "1"},
+		},
+		Attachments: []Attachment{
+			{ContentType: "image/png", Path: "/Metadata/thumbnail.png", Stream: bytes.NewBufferString("fake")},
+		},
+	}
+	buff := new(bytes.Buffer)
+	enc := NewEncoder(buff)
+	pw := new(xorPart)
+	enc.SetPartWriter(pw)
+	if err := enc.Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	if !pw.flushed {
+		t.Error("Encoder.Encode() did not flush the PartWriter")
+	}
+	if got := new(Model); NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len())).Decode(got) == nil && len(got.Metadata) != 0 {
+		t.Error("Decoder.Decode() without PartReader should not decode the processed parts")
+	}
+	newModel := new(Model)
+	dec := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+	dec.SetPartReader(pw)
+	if err := dec.Decode(newModel); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	if b, _ := io.ReadAll(newModel.Attachments[0].Stream); string(b) != "fake" {
+		t.Errorf("Decoder.Decode() attachment = %q", b)
+	}
+	newModel.Attachments[0].Stream, m.Attachments[0].Stream = nil, nil
+	if diff := deep.Equal(newModel, m); diff != nil {
+		t.Errorf("Decoder.Decode() = %v", diff)
+	}
+}
@@ -12,30 +12,33 @@ import (
 // Error guards.
 var (
 	// core
-	ErrMissingID              = errors.New("resource ID MUST be greater than zero")
-	ErrDuplicatedID           = errors.New("IDs MUST be unique among all resources under same Model")
-	ErrMissingResource        = errors.New("resource MUST be defined prior to referencing")
-	ErrDuplicatedIndices      = errors.New("indices v1, v2 and v3 MUST be distinct")
-	ErrIndexOutOfBounds       = errors.New("index is bigger than referenced slice")
-	ErrInsufficientVertices   = errors.New("mesh MUST contain at least 3 vertices to form a solid body")
-	ErrInsufficientTriangles  = errors.New("mesh MUST contain at least 4 triangles to form a solid body")
-	ErrComponentsPID          = errors.New("MUST NOT assign pid to objects that contain components")
-	ErrOPCPartName            = errors.New("part name MUST conform to the syntax specified in the OPC specification")
-	ErrOPCRelTarget           = errors.New("relationship target part MUST be included in the 3MF document")
-	ErrOPCDuplicatedRel       = errors.New("there MUST NOT be more than one relationship of a given type from one part to a second part")
-	ErrOPCContentType         = errors.New("part MUST use an appropriate content type specified")
-	ErrOPCDuplicatedTicket    = errors.New("each model part MUST attach no more than one PrintTicket")
-	ErrOPCDuplicatedModelName = errors.New("model part names MUST be unique")
-	ErrMetadataName           = errors.New("names without a namespace MUST be restricted to predefined values")
-	ErrMetadataNamespace      = errors.New("namespace MUST be declared on the model")
-	ErrMetadataDuplicated     = errors.New("names MUST NOT be duplicated")
-	ErrOtherItem              = errors.New("MUST NOT reference objects of type other")
-	ErrNonObject              = errors.New("MUST NOT reference non-object resources")
-	ErrRequiredExt            = errors.New("unsupported required extension")
-	ErrEmptyResourceProps     = errors.New("resource properties MUST NOT be empty")
-	ErrRecursion              = errors.New("MUST NOT contain recursive references")
-	ErrInvalidObject          = errors.New("MUST contain a mesh or components")
-	ErrMeshConsistency        = errors.New("mesh has non-manifold edges without consistent triangle orientation")
+	ErrMissingID               = errors.New("resource ID MUST be greater than zero")
+	ErrDuplicatedID            = errors.New("IDs MUST be unique among all resources under same Model")
+	ErrMissingResource         = errors.New("resource MUST be defined prior to referencing")
+	ErrDuplicatedIndices       = errors.New("indices v1, v2 and v3 MUST be distinct")
+	ErrIndexOutOfBounds        = errors.New("index is bigger than referenced slice")
+	ErrInsufficientVertices    = errors.New("mesh MUST contain at least 3 vertices to form a solid body")
+	ErrInsufficientTriangles   = errors.New("mesh MUST contain at least 4 triangles to form a solid body")
+	ErrComponentsPID           = errors.New("MUST NOT assign pid to objects that contain components")
+	ErrOPCPartName             = errors.New("part name MUST conform to the syntax specified in the OPC specification")
+	ErrOPCRelTarget            = errors.New("relationship target part MUST be included in the 3MF document")
+	ErrOPCDuplicatedRel        = errors.New("there MUST NOT be more than one relationship of a given type from one part to a second part")
+	ErrOPCContentType          = errors.New("part MUST use an appropriate content type specified")
+	ErrOPCDuplicatedTicket     = errors.New("each model part MUST attach no more than one PrintTicket")
+	ErrOPCDuplicatedModelName  = errors.New("model part names MUST be unique")
+	ErrMetadataName            = errors.New("names without a namespace MUST be restricted to predefined values")
+	ErrMetadataNamespace       = errors.New("namespace MUST be declared on the model")
+	ErrMetadataDuplicated      = errors.New("names MUST NOT be duplicated")
+	ErrOtherItem               = errors.New("MUST NOT reference objects of type other")
+	ErrNonObject               = errors.New("MUST NOT reference non-object resources")
+	ErrRequiredExt             = errors.New("unsupported required extension")
+	ErrEmptyResourceProps      = errors.New("resource properties MUST NOT be empty")
+	ErrRecursion               = errors.New("MUST NOT contain recursive references")
+	ErrInvalidObject           = errors.New("MUST contain a mesh or components")
+	ErrMeshConsistency         = errors.New("mesh has non-manifold edges without consistent triangle orientation")
+	ErrDuplicatedTriangleSetID = errors.New("triangle set identifiers MUST be unique within a mesh")
+	ErrTriangleSetRange        = errors.New("refrange startindex MUST NOT be greater than endindex")
+	ErrEncryptedPackage        = errors.New("encrypted package MUST be decoded with a PartReader")
 )
 
 type Level struct {
@@ -0,0 +1,97 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+// Package reference implements the helpers shared by the extensions
+// to list and remap the resources referenced by their elements.
+package reference
+
+import "github.com/MosaicManufacturing/go3mf"
+
+// NonZero returns the ids that are not zero.
+func NonZero(ids ...uint32) []uint32 {
+	var refs []uint32
+	for _, id := range ids {
+		if id != 0 {
+			refs = append(refs, id)
+		}
+	}
+	return refs
+}
+
+// Remap returns the new ID of the resource id of the same model file.
+func Remap(fn func(string, uint32) (string, uint32), id uint32) uint32 {
+	if id == 0 {
+		return 0
+	}
+	_, id = fn("", id)
+	return id
+}
+
+// referencer is implemented by the assets, and by the extension elements
+// and attributes of objects, meshes and base materials, that reference
+// other resources of their model file by ID.
+type referencer interface {
+	References() []uint32
+}
+
+// Object returns the IDs of the resources referenced by obj, except its components.
+func Object(obj *go3mf.Object) []uint32 {
+	var refs []uint32
+	if obj.PID != 0 {
+		refs = append(refs, obj.PID)
+	}
+	if obj.Mesh != nil {
+		for _, t := range obj.Mesh.Triangles {
+			if t.PID != 0 {
+				refs = append(refs, t.PID)
+			}
+		}
+		refs = append(refs, Any(obj.Mesh.Any, obj.Mesh.AnyAttr)...)
+	}
+	return append(refs, Any(obj.Any, obj.AnyAttr)...)
+}
+
+// Asset returns the IDs of the resources referenced by a.
+func Asset(a go3mf.Asset) []uint32 {
+	var refs []uint32
+	if r, ok := a.(referencer); ok {
+		refs = r.References()
+	}
+	if b, ok := a.(*go3mf.BaseMaterials); ok {
+		refs = append(refs, Any(nil, b.AnyAttr)...)
+	}
+	return refs
+}
+
+// Any returns the IDs of the resources referenced by the extension elements and attributes.
+func Any(any go3mf.Any, attrs go3mf.AnyAttr) []uint32 {
+	var refs []uint32
+	for _, a := range any {
+		if r, ok := a.(referencer); ok {
+			refs = append(refs, r.References()...)
+		}
+	}
+	for _, a := range attrs {
+		if r, ok := a.(referencer); ok {
+			refs = append(refs, r.References()...)
+		}
+	}
+	return refs
+}
+
+// Assets returns the IDs of the assets of res reachable from ids.
+func Assets(res *go3mf.Resources, ids []uint32) map[uint32]bool {
+	seen := make(map[uint32]bool)
+	for len(ids) > 0 {
+		id := ids[len(ids)-1]
+		ids = ids[:len(ids)-1]
+		if seen[id] {
+			continue
+		}
+		if a, ok := res.FindAsset(id); ok {
+			seen[id] = true
+			ids = append(ids, Asset(a)...)
+		}
+	}
+	return seen
+}
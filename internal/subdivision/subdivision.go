@@ -0,0 +1,35 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+// Package subdivision identifies the vertices created when subdividing
+// the triangles of a mesh, so adjacent triangles share them.
+package subdivision
+
+import "sort"
+
+// weightedIndex is the weight of an original vertex
+// in the position of a new vertex, in 1/subdivisions units.
+type weightedIndex struct {
+	v uint32
+	w int
+}
+
+// A VertexKey identifies a subdivision vertex.
+type VertexKey [3]weightedIndex
+
+// NewVertexKey returns a key that identifies a subdivision vertex
+// regardless of the triangle it is computed from.
+func NewVertexKey(indices [3]uint32, w [3]int) VertexKey {
+	var key VertexKey
+	n := 0
+	for k := range indices {
+		if w[k] != 0 {
+			key[n] = weightedIndex{v: indices[k], w: w[k]}
+			n++
+		}
+	}
+	sort.Slice(key[:n], func(i, j int) bool {
+		return key[i].v < key[j].v
+	})
+	return key
+}
@@ -0,0 +1,415 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package materials
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/internal/reference"
+	"github.com/MosaicManufacturing/go3mf/internal/subdivision"
+)
+
+// BakeColors converts the triangles that reference a Texture2DGroup,
+// a CompositeMaterials or a MultiProperties, directly or through the object
+// default property, into per-corner colors of a new ColorGroup appended
+// to the resources of each model file, so the model can be read by
+// consumers that only support color groups.
+// The object default properties are converted the same way.
+//
+// If subdivisions is greater than 1, every triangle of a mesh with textured
+// triangles is split into subdivisions² triangles to capture the texture detail.
+// The original vertices keep their index and the new vertices shared by adjacent
+// triangles are merged, so the mesh stays closed. The split triangles that
+// reference a color group are baked as well, while the ones that reference
+// base materials keep the property of the closest original corner.
+// Triangle sets and per-triangle attributes of a split triangle
+// apply to all its sub-triangles.
+//
+// The resources that were only used by the converted triangles are removed,
+// together with the attachments and relationships of the removed textures
+// that are no longer referenced.
+func BakeColors(m *go3mf.Model, subdivisions int) error {
+	b := baker{m: m, r: NewResolver(m), s: subdivisions, textures: make(map[string]bool)}
+	paths := make([]string, 0, len(m.Childs))
+	for path := range m.Childs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		child := m.Childs[path]
+		if err := child.Load(); err != nil {
+			return err
+		}
+		if err := b.bakeResources(path, &child.Resources); err != nil {
+			return err
+		}
+	}
+	if err := b.bakeResources("", &m.Resources); err != nil {
+		return err
+	}
+	removeTextures(m, b.textures)
+	return nil
+}
+
+type baker struct {
+	m        *go3mf.Model
+	r        *Resolver
+	s        int
+	textures map[string]bool
+
+	// State of the model file being baked.
+	path   string
+	group  *ColorGroup
+	colors map[color.RGBA]uint32
+	baked  map[uint32]bool
+}
+
+func (b *baker) bakeResources(path string, res *go3mf.Resources) error {
+	b.path = path
+	b.group = &ColorGroup{ID: res.UnusedID()}
+	b.colors = make(map[color.RGBA]uint32)
+	b.baked = make(map[uint32]bool)
+	for i, obj := range res.Objects {
+		if err := b.bakeObject(obj); err != nil {
+			return specerr.WrapPath(specerr.WrapIndex(err, obj, i), *res, path)
+		}
+	}
+	if len(b.baked) == 0 {
+		return nil
+	}
+	if len(b.group.Colors) > 0 {
+		res.Assets = append(res.Assets, b.group)
+	}
+	removeUnused(res, b.baked, b.textures)
+	return nil
+}
+
+// kind reports whether the resource pid has to be baked
+// and whether it samples a texture.
+func (b *baker) kind(pid uint32) (bake, textured bool) {
+	a, _ := b.m.FindAsset(b.path, pid)
+	switch a := a.(type) {
+	case *Texture2DGroup:
+		return true, true
+	case *CompositeMaterials:
+		return true, false
+	case *MultiProperties:
+		for _, layer := range a.PIDs {
+			if l, _ := b.m.FindAsset(b.path, layer); l != nil {
+				if _, ok := l.(*Texture2DGroup); ok {
+					return true, true
+				}
+			}
+		}
+		return true, false
+	}
+	return false, false
+}
+
+// effectivePID returns the pid of the triangle, or the object pid if it has none.
+func effectivePID(obj *go3mf.Object, t *go3mf.Triangle) uint32 {
+	if t.PID != 0 {
+		return t.PID
+	}
+	return obj.PID
+}
+
+func (b *baker) index(c color.RGBA) uint32 {
+	if i, ok := b.colors[c]; ok {
+		return i
+	}
+	i := uint32(len(b.group.Colors))
+	b.group.Colors = append(b.group.Colors, c)
+	b.colors[c] = i
+	return i
+}
+
+func (b *baker) bakeObject(obj *go3mf.Object) error {
+	if obj.Mesh != nil {
+		var bake, split bool
+		for i := range obj.Mesh.Triangles {
+			bk, textured := b.kind(effectivePID(obj, &obj.Mesh.Triangles[i]))
+			bake = bake || bk
+			split = split || textured
+		}
+		var err error
+		if b.s > 1 && split {
+			err = b.split(obj)
+		} else if bake {
+			err = b.bakeTriangles(obj)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if bake, _ := b.kind(obj.PID); bake {
+		c, err := b.r.PropertyColor(b.path, obj.PID, obj.PIndex)
+		if err != nil {
+			return err
+		}
+		b.baked[obj.PID] = true
+		obj.PID, obj.PIndex = b.group.ID, b.index(c)
+	}
+	return nil
+}
+
+func (b *baker) bakeTriangles(obj *go3mf.Object) error {
+	for i := range obj.Mesh.Triangles {
+		t := &obj.Mesh.Triangles[i]
+		pid := effectivePID(obj, t)
+		if bake, _ := b.kind(pid); !bake {
+			continue
+		}
+		c, _, err := b.r.TriangleColors(b.path, obj, i)
+		if err != nil {
+			return specerr.WrapIndex(err, *t, i)
+		}
+		b.baked[pid] = true
+		t.PID, t.P1, t.P2, t.P3 = b.group.ID, b.index(c[0]), b.index(c[1]), b.index(c[2])
+	}
+	return nil
+}
+
+func (b *baker) split(obj *go3mf.Object) error {
+	out, subs, err := subdivide(obj.Mesh, b.s)
+	if err != nil {
+		return err
+	}
+	type gridPoint struct {
+		i int
+		w [3]int
+	}
+	colors := make(map[gridPoint]uint32)
+	for k, sub := range subs {
+		t := &obj.Mesh.Triangles[sub.i]
+		pid := effectivePID(obj, t)
+		bake, _ := b.kind(pid)
+		if a, _ := b.m.FindAsset(b.path, pid); a != nil {
+			if _, ok := a.(*ColorGroup); ok {
+				bake = true
+			}
+		}
+		orig := [3]uint32{t.P1, t.P2, t.P3}
+		var props [3]uint32
+		for c, w := range sub.w {
+			if !bake {
+				props[c] = orig[dominant(w)]
+				continue
+			}
+			p, ok := colors[gridPoint{sub.i, w}]
+			if !ok {
+				rgba, _, err := b.r.TriangleColorAt(b.path, obj, sub.i, weights(w, b.s))
+				if err != nil {
+					return specerr.WrapIndex(err, *t, sub.i)
+				}
+				p = b.index(rgba)
+				colors[gridPoint{sub.i, w}] = p
+			}
+			props[c] = p
+		}
+		nt := &out.Triangles[k]
+		nt.P1, nt.P2, nt.P3 = props[0], props[1], props[2]
+		if bake {
+			b.baked[pid] = true
+			nt.PID = b.group.ID
+		}
+	}
+	obj.Mesh = out
+	return nil
+}
+
+// subTriangle is a triangle of a subdivided mesh.
+type subTriangle struct {
+	// i is the index of the original triangle.
+	i int
+	// w are the weights of the corners of the original triangle
+	// in each corner, in 1/subdivisions units.
+	w [3][3]int
+}
+
+// subdivide splits each triangle of mesh into s² triangles,
+// which keep the properties of the original one, and returns the new mesh
+// together with the origin of each new triangle.
+//
+// The original vertices keep their index and the new vertices shared by
+// adjacent triangles are merged. Triangle sets and per-triangle attributes
+// of a triangle apply to all its sub-triangles.
+// If s is lower than 2 mesh is returned unchanged.
+func subdivide(mesh *go3mf.Mesh, s int) (*go3mf.Mesh, []subTriangle, error) {
+	if s < 2 {
+		subs := make([]subTriangle, len(mesh.Triangles))
+		for i := range subs {
+			subs[i] = subTriangle{i: i, w: [3][3]int{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}}
+		}
+		return mesh, subs, nil
+	}
+	out := &go3mf.Mesh{
+		Vertices: append([]go3mf.Point3D(nil), mesh.Vertices...),
+		AnyAttr:  mesh.AnyAttr,
+		Any:      mesh.Any,
+	}
+	if len(mesh.TriangleAnyAttr) > 0 {
+		out.TriangleAnyAttr = make(map[uint32]go3mf.AnyAttr)
+	}
+	subs := make([]subTriangle, 0, len(mesh.Triangles)*s*s)
+	vertices := make(map[subdivision.VertexKey]uint32)
+	vertex := func(indices [3]uint32, w [3]int) uint32 {
+		for k := range w {
+			if w[k] == s {
+				return indices[k]
+			}
+		}
+		key := subdivision.NewVertexKey(indices, w)
+		if idx, ok := vertices[key]; ok {
+			return idx
+		}
+		var pos [3]float64
+		for k, idx := range indices {
+			wk := float64(w[k]) / float64(s)
+			for a := 0; a < 3; a++ {
+				pos[a] += float64(mesh.Vertices[idx][a]) * wk
+			}
+		}
+		idx := uint32(len(out.Vertices))
+		out.Vertices = append(out.Vertices, go3mf.Point3D{float32(pos[0]), float32(pos[1]), float32(pos[2])})
+		vertices[key] = idx
+		return idx
+	}
+	starts := make([]uint32, len(mesh.Triangles))
+	for i, t := range mesh.Triangles {
+		indices := [3]uint32{t.V1, t.V2, t.V3}
+		for _, idx := range indices {
+			if int(idx) >= len(mesh.Vertices) {
+				return nil, nil, specerr.WrapIndex(specerr.ErrIndexOutOfBounds, t, i)
+			}
+		}
+		starts[i] = uint32(len(out.Triangles))
+		add := func(a, b, c [2]int) {
+			if attr, ok := mesh.TriangleAnyAttr[uint32(i)]; ok {
+				out.TriangleAnyAttr[uint32(len(out.Triangles))] = attr
+			}
+			// (a, b) is the grid point at weights (s-a-b, a, b).
+			sub := subTriangle{i: i, w: [3][3]int{
+				{s - a[0] - a[1], a[0], a[1]},
+				{s - b[0] - b[1], b[0], b[1]},
+				{s - c[0] - c[1], c[0], c[1]},
+			}}
+			nt := t
+			nt.V1, nt.V2, nt.V3 = vertex(indices, sub.w[0]), vertex(indices, sub.w[1]), vertex(indices, sub.w[2])
+			out.Triangles = append(out.Triangles, nt)
+			subs = append(subs, sub)
+		}
+		for gi := 0; gi < s; gi++ {
+			for gj := 0; gj < s-gi; gj++ {
+				add([2]int{gi, gj}, [2]int{gi + 1, gj}, [2]int{gi, gj + 1})
+				if gi+gj < s-1 {
+					add([2]int{gi + 1, gj}, [2]int{gi + 1, gj + 1}, [2]int{gi, gj + 1})
+				}
+			}
+		}
+	}
+	n := uint32(s * s)
+	for _, set := range mesh.TriangleSets {
+		newSet := go3mf.TriangleSet{Name: set.Name, Identifier: set.Identifier}
+		for _, i := range set.Triangles() {
+			if int(i) >= len(starts) {
+				continue
+			}
+			start := starts[i]
+			if l := len(newSet.RefRanges); l > 0 && newSet.RefRanges[l-1].End+1 == start {
+				newSet.RefRanges[l-1].End = start + n - 1
+			} else {
+				newSet.RefRanges = append(newSet.RefRanges, go3mf.TriangleRefRange{Start: start, End: start + n - 1})
+			}
+		}
+		out.TriangleSets = append(out.TriangleSets, newSet)
+	}
+	return out, subs, nil
+}
+
+// weights returns the barycentric coordinates of the grid point w.
+func weights(w [3]int, s int) [3]float64 {
+	return [3]float64{float64(w[0]) / float64(s), float64(w[1]) / float64(s), float64(w[2]) / float64(s)}
+}
+
+// dominant returns the original corner with the highest weight.
+func dominant(w [3]int) int {
+	k := 0
+	for i := 1; i < 3; i++ {
+		if w[i] > w[k] {
+			k = i
+		}
+	}
+	return k
+}
+
+// removeUnused removes the replaced resources, and the resources they reference,
+// that are no longer referenced by the objects or the remaining resources.
+// The paths of the removed textures are added to textures.
+func removeUnused(res *go3mf.Resources, replaced map[uint32]bool, textures map[string]bool) {
+	ids := make([]uint32, 0, len(replaced))
+	for id := range replaced {
+		ids = append(ids, id)
+	}
+	candidates := reference.Assets(res, ids)
+	var roots []uint32
+	for _, obj := range res.Objects {
+		roots = append(roots, reference.Object(obj)...)
+	}
+	for _, a := range res.Assets {
+		if !candidates[a.Identify()] {
+			roots = append(roots, reference.Asset(a)...)
+		}
+	}
+	used := reference.Assets(res, roots)
+	assets := res.Assets[:0]
+	for _, a := range res.Assets {
+		if id := a.Identify(); candidates[id] && !used[id] {
+			if t, ok := a.(*Texture2D); ok {
+				textures[t.Path] = true
+			}
+			continue
+		}
+		assets = append(assets, a)
+	}
+	res.Assets = assets
+}
+
+// removeTextures removes the attachments and relationships
+// of the textures paths that no remaining texture references.
+func removeTextures(m *go3mf.Model, textures map[string]bool) {
+	if len(textures) == 0 {
+		return
+	}
+	m.WalkAssets(func(_ string, a go3mf.Asset) error {
+		if t, ok := a.(*Texture2D); ok {
+			delete(textures, t.Path)
+		}
+		return nil
+	})
+	attachments := m.Attachments[:0]
+	for _, att := range m.Attachments {
+		if !textures[att.Path] {
+			attachments = append(attachments, att)
+		}
+	}
+	m.Attachments = attachments
+	m.Relationships = withoutTextures(m.Relationships, textures)
+	for _, c := range m.Childs {
+		c.Relationships = withoutTextures(c.Relationships, textures)
+	}
+}
+
+func withoutTextures(rels []go3mf.Relationship, textures map[string]bool) []go3mf.Relationship {
+	out := rels[:0]
+	for _, r := range rels {
+		if !textures[r.Path] {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,118 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package materials
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/go-test/deep"
+)
+
+func TestBakeColors(t *testing.T) {
+	red, green, blue := color.RGBA{R: 255, A: 255}, color.RGBA{G: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	m := newResolverModel(t)
+	obj := &go3mf.Object{ID: 20, PID: 1, PIndex: 1, Mesh: &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {2, 0, 0}, {0, 2, 0}, {2, 2, 0}},
+		Triangles: []go3mf.Triangle{
+			{V1: 0, V2: 1, V3: 2, PID: 8, P1: 0, P2: 1, P3: 0},
+			{V1: 1, V2: 3, V3: 2, PID: 4, P1: 0, P2: 1, P3: 0},
+			{V1: 0, V2: 2, V3: 3},
+		},
+	}}
+	m.Resources.Objects = append(m.Resources.Objects, obj)
+	if err := BakeColors(m, 1); err != nil {
+		t.Fatalf("BakeColors() error = %v", err)
+	}
+	want := []go3mf.Triangle{
+		{V1: 0, V2: 1, V3: 2, PID: 9, P1: 0, P2: 1, P3: 0},
+		{V1: 1, V2: 3, V3: 2, PID: 9, P1: 2, P2: 1, P3: 2},
+		{V1: 0, V2: 2, V3: 3},
+	}
+	if diff := deep.Equal(obj.Mesh.Triangles, want); diff != nil {
+		t.Errorf("BakeColors() triangles = %v", diff)
+	}
+	if obj.PID != 1 || obj.PIndex != 1 {
+		t.Errorf("BakeColors() object pid = %d, pindex = %d", obj.PID, obj.PIndex)
+	}
+	group, ok := m.Resources.Assets[len(m.Resources.Assets)-1].(*ColorGroup)
+	if !ok || group.ID != 9 {
+		t.Fatalf("BakeColors() resources = %v", m.Resources.Assets)
+	}
+	if diff := deep.Equal(group.Colors, []color.RGBA{red, blue, green}); diff != nil {
+		t.Errorf("BakeColors() colors = %v", diff)
+	}
+	var ids []uint32
+	for _, a := range m.Resources.Assets {
+		ids = append(ids, a.Identify())
+	}
+	// The multiproperties 4 is still referenced by 6.
+	if diff := deep.Equal(ids, []uint32{1, 2, 3, 4, 5, 6, 9}); diff != nil {
+		t.Errorf("BakeColors() resources = %v", diff)
+	}
+	if len(m.Attachments) != 0 || len(m.Relationships) != 0 {
+		t.Errorf("BakeColors() attachments = %v, relationships = %v", m.Attachments, m.Relationships)
+	}
+}
+
+func TestBakeColors_subdivisions(t *testing.T) {
+	m := &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+		&go3mf.BaseMaterials{ID: 1, Materials: []go3mf.Base{{Name: "red", Color: color.RGBA{R: 255, A: 255}}, {Name: "blue", Color: color.RGBA{B: 255, A: 255}}}},
+	}}}
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	img.Set(1, 0, color.NRGBA{B: 255, A: 255})
+	tex, err := AddTexture2D(m, img, TextureTypePNG)
+	if err != nil {
+		t.Fatalf("AddTexture2D() error = %v", err)
+	}
+	tex.Filter = TextureFilterNearest
+	m.Resources.Assets = append(m.Resources.Assets, &Texture2DGroup{ID: 3, TextureID: tex.ID, Coords: []TextureCoord{{0.25, 0.5}, {0.75, 0.5}}})
+	obj := &go3mf.Object{ID: 20, Mesh: &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {2, 0, 0}, {0, 2, 0}, {2, 2, 0}},
+		Triangles: []go3mf.Triangle{
+			{V1: 0, V2: 1, V3: 2, PID: 3, P1: 0, P2: 1, P3: 0},
+			{V1: 1, V2: 3, V3: 2, PID: 1, P1: 1, P2: 1, P3: 1},
+		},
+		TriangleSets: []go3mf.TriangleSet{{Name: "a", Identifier: "a", Refs: []uint32{1}}},
+	}}
+	m.Resources.Objects = append(m.Resources.Objects, obj)
+	if err := BakeColors(m, 2); err != nil {
+		t.Fatalf("BakeColors() error = %v", err)
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("BakeColors() produced an invalid model: %v", err)
+	}
+	if len(obj.Mesh.Vertices) != 9 {
+		t.Errorf("BakeColors() vertices = %v", obj.Mesh.Vertices)
+	}
+	if len(obj.Mesh.Triangles) != 8 {
+		t.Fatalf("BakeColors() triangles = %v", obj.Mesh.Triangles)
+	}
+	for i, tri := range obj.Mesh.Triangles {
+		if wantPID := []uint32{4, 1}[i/4]; tri.PID != wantPID {
+			t.Errorf("BakeColors() triangle %d pid = %d, want %d", i, tri.PID, wantPID)
+		}
+	}
+	if tri := obj.Mesh.Triangles[0]; tri.V1 != 0 || tri.P1 != 0 || tri.P2 != 1 {
+		t.Errorf("BakeColors() triangle 0 = %v", tri)
+	}
+	if diff := deep.Equal(obj.Mesh.TriangleSets, []go3mf.TriangleSet{
+		{Name: "a", Identifier: "a", RefRanges: []go3mf.TriangleRefRange{{Start: 4, End: 7}}},
+	}); diff != nil {
+		t.Errorf("BakeColors() triangle sets = %v", diff)
+	}
+	var ids []uint32
+	for _, a := range m.Resources.Assets {
+		ids = append(ids, a.Identify())
+	}
+	if diff := deep.Equal(ids, []uint32{1, 4}); diff != nil {
+		t.Errorf("BakeColors() resources = %v", diff)
+	}
+	if len(m.Attachments) != 0 || len(m.Relationships) != 0 {
+		t.Errorf("BakeColors() attachments = %v, relationships = %v", m.Attachments, m.Relationships)
+	}
+}
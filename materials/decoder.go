@@ -13,7 +13,18 @@ import (
 	"github.com/MosaicManufacturing/go3mf/spec"
 )
 
-func (Spec) DecodeAttribute(interface{}, spec.Attr) error {
+func (Spec) DecodeAttribute(parentNode interface{}, attr spec.Attr) error {
+	if t, ok := parentNode.(*go3mf.BaseMaterials); ok && attr.Name.Local == attrDisplayPropsID {
+		val, err := strconv.ParseUint(string(attr.Value), 10, 32)
+		if err != nil {
+			return specerr.NewParseAttrError(attr.Name.Local, false)
+		}
+		if ext := GetBaseMaterialsAttr(t); ext != nil {
+			ext.DisplayPropertiesID = uint32(val)
+		} else {
+			t.AnyAttr = append(t.AnyAttr, &BaseMaterialsAttr{DisplayPropertiesID: uint32(val)})
+		}
+	}
 	return nil
 }
 
@@ -29,6 +40,16 @@ func (Spec) CreateElementDecoder(parent interface{}, name string) (child spec.El
 		child = &compositeMaterialsDecoder{resources: parent.(*go3mf.Resources)}
 	case attrMultiProps:
 		child = &multiPropertiesDecoder{resources: parent.(*go3mf.Resources)}
+	case attrPBSpecularDisplay:
+		child = &pbSpecularDisplayDecoder{resources: parent.(*go3mf.Resources)}
+	case attrPBSpecularTexture:
+		child = &pbSpecularTextureDecoder{resources: parent.(*go3mf.Resources)}
+	case attrPBMetallicDisplay:
+		child = &pbMetallicDisplayDecoder{resources: parent.(*go3mf.Resources)}
+	case attrPBMetallicTexture:
+		child = &pbMetallicTextureDecoder{resources: parent.(*go3mf.Resources)}
+	case attrTranslucentDisplay:
+		child = &translucentDisplayDecoder{resources: parent.(*go3mf.Resources)}
 	}
 	return
 }
@@ -58,15 +79,27 @@ func (d *colorGroupDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 func (d *colorGroupDecoder) Start(attrs []spec.Attr) (errs error) {
 	d.colorDecoder.resource = &d.resource
 	for _, a := range attrs {
-		if a.Name.Space == "" && a.Name.Local == attrID {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
 			id, err := strconv.ParseUint(string(a.Value), 10, 32)
 			if err != nil {
 				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
 			}
 			d.resource.ID = uint32(id)
-			break
+		case attrDisplayPropsID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.DisplayPropertiesID = uint32(val)
 		}
 	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
 	return
 }
 
@@ -166,6 +199,12 @@ func (d *tex2DGroupDecoder) Start(attrs []spec.Attr) error {
 				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
 			}
 			d.resource.TextureID = uint32(val)
+		case attrDisplayPropsID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.DisplayPropertiesID = uint32(val)
 		}
 	}
 	if errs != nil {
@@ -386,6 +425,368 @@ func (d *multiDecoder) Start(attrs []spec.Attr) error {
 	return nil
 }
 
+type pbSpecularDisplayDecoder struct {
+	baseDecoder
+	resources         *go3mf.Resources
+	resource          PBSpecularDisplayProperties
+	pbSpecularDecoder pbSpecularDecoder
+}
+
+func (d *pbSpecularDisplayDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *pbSpecularDisplayDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *pbSpecularDisplayDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrPBSpecular {
+		child = &d.pbSpecularDecoder
+	}
+	return
+}
+
+func (d *pbSpecularDisplayDecoder) Start(attrs []spec.Attr) error {
+	d.pbSpecularDecoder.resource = &d.resource
+	return decodeDisplayPropertiesID(attrs, &d.resource.ID, &d.resource, len(d.resources.Assets))
+}
+
+type pbSpecularDecoder struct {
+	baseDecoder
+	resource *PBSpecularDisplayProperties
+}
+
+func (d *pbSpecularDecoder) Start(attrs []spec.Attr) error {
+	var (
+		specular = PBSpecular{SpecularColor: defaultSpecularColor}
+		errs     error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			specular.Name = string(a.Value)
+		case attrSpecularColor:
+			c, err := spec.ParseRGBA(string(a.Value))
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			specular.SpecularColor = c
+		case attrGlossiness:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			specular.Glossiness = float32(val)
+		}
+	}
+	d.resource.Specular = append(d.resource.Specular, specular)
+	if errs != nil {
+		return specerr.WrapIndex(errs, specular, len(d.resource.Specular)-1)
+	}
+	return nil
+}
+
+type pbSpecularTextureDecoder struct {
+	baseDecoder
+	resources *go3mf.Resources
+	resource  PBSpecularTextureDisplayProperties
+}
+
+func (d *pbSpecularTextureDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *pbSpecularTextureDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.resource.DiffuseFactor = defaultFactorColor
+	d.resource.SpecularFactor = defaultFactorColor
+	d.resource.GlossinessFactor = 1
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
+			id, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.ID = uint32(id)
+		case attrName:
+			d.resource.Name = string(a.Value)
+		case attrSpecularTextureID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.SpecularTextureID = uint32(val)
+		case attrGlossinessTexID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.GlossinessTextureID = uint32(val)
+		case attrDiffuseFactor:
+			c, err := spec.ParseRGBA(string(a.Value))
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.DiffuseFactor = c
+		case attrSpecularFactor:
+			c, err := spec.ParseRGBA(string(a.Value))
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.SpecularFactor = c
+		case attrGlossinessFactor:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.GlossinessFactor = float32(val)
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type pbMetallicDisplayDecoder struct {
+	baseDecoder
+	resources         *go3mf.Resources
+	resource          PBMetallicDisplayProperties
+	pbMetallicDecoder pbMetallicDecoder
+}
+
+func (d *pbMetallicDisplayDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *pbMetallicDisplayDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *pbMetallicDisplayDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrPBMetallic {
+		child = &d.pbMetallicDecoder
+	}
+	return
+}
+
+func (d *pbMetallicDisplayDecoder) Start(attrs []spec.Attr) error {
+	d.pbMetallicDecoder.resource = &d.resource
+	return decodeDisplayPropertiesID(attrs, &d.resource.ID, &d.resource, len(d.resources.Assets))
+}
+
+type pbMetallicDecoder struct {
+	baseDecoder
+	resource *PBMetallicDisplayProperties
+}
+
+func (d *pbMetallicDecoder) Start(attrs []spec.Attr) error {
+	var (
+		metallic = PBMetallic{Roughness: 1}
+		errs     error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			metallic.Name = string(a.Value)
+		case attrMetallicness:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			metallic.Metallicness = float32(val)
+		case attrRoughness:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			metallic.Roughness = float32(val)
+		}
+	}
+	d.resource.Metallic = append(d.resource.Metallic, metallic)
+	if errs != nil {
+		return specerr.WrapIndex(errs, metallic, len(d.resource.Metallic)-1)
+	}
+	return nil
+}
+
+type pbMetallicTextureDecoder struct {
+	baseDecoder
+	resources *go3mf.Resources
+	resource  PBMetallicTextureDisplayProperties
+}
+
+func (d *pbMetallicTextureDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *pbMetallicTextureDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.resource.BaseColorFactor = defaultFactorColor
+	d.resource.MetallicFactor = 1
+	d.resource.RoughnessFactor = 1
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
+			id, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.ID = uint32(id)
+		case attrName:
+			d.resource.Name = string(a.Value)
+		case attrMetallicTextureID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.MetallicTextureID = uint32(val)
+		case attrRoughnessTexID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.RoughnessTextureID = uint32(val)
+		case attrBaseColorFactor:
+			c, err := spec.ParseRGBA(string(a.Value))
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.BaseColorFactor = c
+		case attrMetallicFactor:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.MetallicFactor = float32(val)
+		case attrRoughnessFactor:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.RoughnessFactor = float32(val)
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type translucentDisplayDecoder struct {
+	baseDecoder
+	resources          *go3mf.Resources
+	resource           TranslucentDisplayProperties
+	translucentDecoder translucentDecoder
+}
+
+func (d *translucentDisplayDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *translucentDisplayDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *translucentDisplayDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrTranslucent {
+		child = &d.translucentDecoder
+	}
+	return
+}
+
+func (d *translucentDisplayDecoder) Start(attrs []spec.Attr) error {
+	d.translucentDecoder.resource = &d.resource
+	return decodeDisplayPropertiesID(attrs, &d.resource.ID, &d.resource, len(d.resources.Assets))
+}
+
+type translucentDecoder struct {
+	baseDecoder
+	resource *TranslucentDisplayProperties
+}
+
+func (d *translucentDecoder) Start(attrs []spec.Attr) error {
+	var (
+		translucent = Translucent{RefractiveIndex: defaultRefractiveIndex}
+		errs        error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			translucent.Name = string(a.Value)
+		case attrAttenuation:
+			var ok bool
+			if translucent.Attenuation, ok = parseFloat3(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+		case attrRefractiveIndex:
+			var ok bool
+			if translucent.RefractiveIndex, ok = parseFloat3(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrRoughness:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			translucent.Roughness = float32(val)
+		}
+	}
+	d.resource.Translucents = append(d.resource.Translucents, translucent)
+	if errs != nil {
+		return specerr.WrapIndex(errs, translucent, len(d.resource.Translucents)-1)
+	}
+	return nil
+}
+
+// decodeDisplayPropertiesID decodes the id attribute of
+// the display properties containers.
+func decodeDisplayPropertiesID(attrs []spec.Attr, id *uint32, resource interface{}, index int) error {
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrID {
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			*id = uint32(val)
+			if err != nil {
+				return specerr.WrapIndex(specerr.NewParseAttrError(a.Name.Local, true), resource, index)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func parseFloat3(s string) (v [3]float32, ok bool) {
+	fields := strings.Fields(s)
+	if len(fields) != 3 {
+		return v, false
+	}
+	for i, f := range fields {
+		val, err := strconv.ParseFloat(f, 32)
+		if err != nil {
+			return v, false
+		}
+		v[i] = float32(val)
+	}
+	return v, true
+}
+
 type baseDecoder struct {
 }
 
@@ -4,12 +4,14 @@
 package materials
 
 import (
+	"encoding/xml"
 	"fmt"
 	"image/color"
 	"testing"
 
 	"github.com/MosaicManufacturing/go3mf"
 	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/spec"
 	"github.com/go-test/deep"
 )
 
@@ -19,21 +21,48 @@ func TestDecode(t *testing.T) {
 	texGroup := &Texture2DGroup{ID: 2, TextureID: 6, Coords: []TextureCoord{{0.3, 0.5}, {0.3, 0.8}, {0.5, 0.8}, {0.5, 0.5}}}
 	compositeGroup := &CompositeMaterials{ID: 4, MaterialID: 5, Indices: []uint32{1, 2}, Composites: []Composite{{Values: []float32{0.5, 0.5}}, {Values: []float32{0.2, 0.8}}}}
 	multiGroup := &MultiProperties{ID: 9, BlendMethods: []BlendMethod{BlendMultiply}, PIDs: []uint32{5, 2}, Multis: []Multi{{PIndices: []uint32{0, 0}}, {PIndices: []uint32{1, 0}}, {PIndices: []uint32{2, 3}}}}
+	baseMaterials := &go3mf.BaseMaterials{ID: 5, Materials: []go3mf.Base{
+		{Name: "Red", Color: color.RGBA{R: 255, A: 255}},
+		{Name: "Blue", Color: color.RGBA{B: 255, A: 255}},
+	}, AnyAttr: go3mf.AnyAttr{&BaseMaterialsAttr{DisplayPropertiesID: 12}}}
+	specular := &PBSpecularDisplayProperties{ID: 10, Specular: []PBSpecular{
+		{Name: "Shiny", SpecularColor: color.RGBA{R: 10, G: 20, B: 30, A: 255}, Glossiness: 0.8},
+		{Name: "Matte", SpecularColor: defaultSpecularColor},
+	}}
+	specularTex := &PBSpecularTextureDisplayProperties{
+		ID: 11, Name: "Textured", SpecularTextureID: 6, GlossinessTextureID: 6,
+		DiffuseFactor: defaultFactorColor, SpecularFactor: color.RGBA{R: 128, G: 128, B: 128, A: 255}, GlossinessFactor: 0.5,
+	}
+	metallic := &PBMetallicDisplayProperties{ID: 12, Metallic: []PBMetallic{
+		{Name: "Steel", Metallicness: 1, Roughness: 0.3},
+		{Name: "Plastic", Roughness: 1},
+	}}
+	metallicTex := &PBMetallicTextureDisplayProperties{
+		ID: 13, Name: "Rust", MetallicTextureID: 6, RoughnessTextureID: 6,
+		BaseColorFactor: defaultFactorColor, MetallicFactor: 1, RoughnessFactor: 0.25,
+	}
+	translucent := &TranslucentDisplayProperties{ID: 14, Translucents: []Translucent{
+		{Name: "Glass", Attenuation: [3]float32{0.1, 0.2, 0.3}, RefractiveIndex: [3]float32{1.5, 1.5, 1.5}, Roughness: 0.1},
+		{Name: "Water", Attenuation: [3]float32{0, 0, 0}, RefractiveIndex: defaultRefractiveIndex},
+	}}
+	colorGroup.DisplayPropertiesID = 10
+	texGroup.DisplayPropertiesID = 11
 	want := &go3mf.Model{
 		Path:       "/3D/3dmodel.model",
 		Extensions: []go3mf.Extension{DefaultExtension},
 	}
-	want.Resources.Assets = append(want.Resources.Assets, baseTexture, colorGroup, texGroup, compositeGroup, multiGroup)
+	want.Resources.Assets = append(want.Resources.Assets, baseTexture, colorGroup, texGroup, compositeGroup, multiGroup,
+		baseMaterials, specular, specularTex, metallic, metallicTex, translucent)
 	got := new(go3mf.Model)
 	got.Path = "/3D/3dmodel.model"
 	rootFile := `
 	<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:m="http://schemas.microsoft.com/3dmanufacturing/material/2015/02">
 		<resources>
 			<m:texture2d id="6" path="/3D/Texture/msLogo.png" contenttype="image/png" tilestyleu="wrap" tilestylev="mirror" filter="auto" />
-			<m:colorgroup id="1">
+			<m:colorgroup id="1" displaypropertiesid="10">
 				<m:color color="#FFFFFF" /> <m:color color="#000000" /> <m:color color="#1AB567" /> <m:color color="#DF045A" />
 			</m:colorgroup>
-			<m:texture2dgroup id="2" texid="6">
+			<m:texture2dgroup id="2" texid="6" displaypropertiesid="11">
 				<m:tex2coord u="0.3" v="0.5" /> <m:tex2coord u="0.3" v="0.8" />	<m:tex2coord u="0.5" v="0.8" />	<m:tex2coord u="0.5" v="0.5" />
 			</m:texture2dgroup>
 			<m:compositematerials id="4" matid="5" matindices="1 2">
@@ -45,6 +74,24 @@ func TestDecode(t *testing.T) {
 				<m:multi pindices="1 0" />
 				<m:multi pindices="2 3" />
 			</m:multiproperties>
+			<basematerials id="5" m:displaypropertiesid="12">
+				<base name="Red" displaycolor="#FF0000" />
+				<base name="Blue" displaycolor="#0000FF" />
+			</basematerials>
+			<m:pbspeculardisplayproperties id="10">
+				<m:pbspecular name="Shiny" specularcolor="#0A141E" glossiness="0.8" />
+				<m:pbspecular name="Matte" />
+			</m:pbspeculardisplayproperties>
+			<m:pbspeculartexturedisplayproperties id="11" name="Textured" speculartextureid="6" glossinesstextureid="6" specularfactor="#808080" glossinessfactor="0.5" />
+			<m:pbmetallicdisplayproperties id="12">
+				<m:pbmetallic name="Steel" metallicness="1" roughness="0.3" />
+				<m:pbmetallic name="Plastic" />
+			</m:pbmetallicdisplayproperties>
+			<m:pbmetallictexturedisplayproperties id="13" name="Rust" metallictextureid="6" roughnesstextureid="6" roughnessfactor="0.25" />
+			<m:translucentdisplayproperties id="14">
+				<m:translucent name="Glass" attenuation="0.1 0.2 0.3" refractiveindex="1.5 1.5 1.5" roughness="0.1" />
+				<m:translucent name="Water" attenuation="0 0 0" />
+			</m:translucentdisplayproperties>
 		</resources>
 		<build>
 		</build>
@@ -71,6 +118,9 @@ func TestDecode_warns(t *testing.T) {
 		fmt.Sprintf("Resources@CompositeMaterials#4: %v", errors.NewParseAttrError("matid", true)),
 		fmt.Sprintf("Resources@CompositeMaterials#4@Composite#1: %v", errors.NewParseAttrError("values", true)),
 		fmt.Sprintf("Resources@MultiProperties#5: %v", errors.NewParseAttrError("pids", true)),
+		fmt.Sprintf("Resources@PBSpecularDisplayProperties#7@PBSpecular#0: %v", errors.NewParseAttrError("glossiness", false)),
+		fmt.Sprintf("Resources@PBMetallicTextureDisplayProperties#8: %v", errors.NewParseAttrError("metallictextureid", true)),
+		fmt.Sprintf("Resources@TranslucentDisplayProperties#9@Translucent#0: %v", errors.NewParseAttrError("attenuation", true)),
 	}
 	got := new(go3mf.Model)
 	got.Path = "/3D/3dmodel.model"
@@ -93,6 +143,13 @@ func TestDecode_warns(t *testing.T) {
 				<m:multi />
 			</m:multiproperties>
 			<m:multiproperties id="19" />
+			<m:pbspeculardisplayproperties id="20">
+				<m:pbspecular name="Shiny" glossiness="a" />
+			</m:pbspeculardisplayproperties>
+			<m:pbmetallictexturedisplayproperties id="21" name="Rust" metallictextureid="a" roughnesstextureid="6" />
+			<m:translucentdisplayproperties id="22">
+				<m:translucent name="Glass" attenuation="0.1 0.2" />
+			</m:translucentdisplayproperties>
 			<object id="8" name="Box 1" pid="5" pindex="0" type="model">
 				<mesh>
 					<vertices>
@@ -142,3 +199,27 @@ func TestDecode_warns(t *testing.T) {
 		}
 	})
 }
+
+func TestSpec_DecodeAttribute(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    go3mf.AnyAttr
+		wantErr bool
+	}{
+		{"valid", "12", go3mf.AnyAttr{&BaseMaterialsAttr{DisplayPropertiesID: 12}}, false},
+		{"invalid", "a", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := new(go3mf.BaseMaterials)
+			err := Spec{}.DecodeAttribute(r, spec.Attr{Name: xml.Name{Space: Namespace, Local: attrDisplayPropsID}, Value: []byte(tt.value)})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Spec.DecodeAttribute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := deep.Equal(r.AnyAttr, tt.want); diff != nil {
+				t.Errorf("Spec.DecodeAttribute() = %v", diff)
+			}
+		})
+	}
+}
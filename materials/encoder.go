@@ -11,11 +11,23 @@ import (
 	"github.com/MosaicManufacturing/go3mf/spec"
 )
 
+// Marshal3MFAttr encodes the resource attributes.
+func (r *BaseMaterialsAttr) Marshal3MFAttr(_ spec.Encoder) ([]xml.Attr, error) {
+	return []xml.Attr{
+		{Name: xml.Name{Space: Namespace, Local: attrDisplayPropsID}, Value: strconv.FormatUint(uint64(r.DisplayPropertiesID), 10)},
+	}, nil
+}
+
 // Marshal3MF encodes the resource.
 func (r *ColorGroup) Marshal3MF(x spec.Encoder) error {
 	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrColorGroup}, Attr: []xml.Attr{
 		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
 	}}
+	if r.DisplayPropertiesID != 0 {
+		xs.Attr = append(xs.Attr, xml.Attr{
+			Name: xml.Name{Local: attrDisplayPropsID}, Value: strconv.FormatUint(uint64(r.DisplayPropertiesID), 10),
+		})
+	}
 	x.EncodeToken(xs)
 	x.SetAutoClose(true)
 	x.SetSkipAttrEscape(true)
@@ -38,6 +50,11 @@ func (r *Texture2DGroup) Marshal3MF(x spec.Encoder) error {
 		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
 		{Name: xml.Name{Local: attrTexID}, Value: strconv.FormatUint(uint64(r.TextureID), 10)},
 	}}
+	if r.DisplayPropertiesID != 0 {
+		xs.Attr = append(xs.Attr, xml.Attr{
+			Name: xml.Name{Local: attrDisplayPropsID}, Value: strconv.FormatUint(uint64(r.DisplayPropertiesID), 10),
+		})
+	}
 	x.EncodeToken(xs)
 	x.SetAutoClose(true)
 	x.SetSkipAttrEscape(true)
@@ -141,3 +158,131 @@ func (r *Texture2D) Marshal3MF(x spec.Encoder) error {
 	x.SetAutoClose(false)
 	return nil
 }
+
+// Marshal3MF encodes the resource.
+func (r *PBSpecularDisplayProperties) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBSpecularDisplay}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	prec := x.FloatPresicion()
+	for _, s := range r.Specular {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBSpecular}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: s.Name},
+			{Name: xml.Name{Local: attrSpecularColor}, Value: spec.FormatRGBA(s.SpecularColor)},
+			{Name: xml.Name{Local: attrGlossiness}, Value: strconv.FormatFloat(float64(s.Glossiness), 'f', prec, 32)},
+		}})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *PBSpecularTextureDisplayProperties) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBSpecularTexture}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrName}, Value: r.Name},
+		{Name: xml.Name{Local: attrSpecularTextureID}, Value: strconv.FormatUint(uint64(r.SpecularTextureID), 10)},
+		{Name: xml.Name{Local: attrGlossinessTexID}, Value: strconv.FormatUint(uint64(r.GlossinessTextureID), 10)},
+	}}
+	if r.DiffuseFactor != defaultFactorColor {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrDiffuseFactor}, Value: spec.FormatRGBA(r.DiffuseFactor)})
+	}
+	if r.SpecularFactor != defaultFactorColor {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrSpecularFactor}, Value: spec.FormatRGBA(r.SpecularFactor)})
+	}
+	if r.GlossinessFactor != 1 {
+		xs.Attr = append(xs.Attr, xml.Attr{
+			Name: xml.Name{Local: attrGlossinessFactor}, Value: strconv.FormatFloat(float64(r.GlossinessFactor), 'f', x.FloatPresicion(), 32),
+		})
+	}
+	x.SetAutoClose(true)
+	x.EncodeToken(xs)
+	x.SetAutoClose(false)
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *PBMetallicDisplayProperties) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBMetallicDisplay}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	prec := x.FloatPresicion()
+	for _, m := range r.Metallic {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBMetallic}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: m.Name},
+			{Name: xml.Name{Local: attrMetallicness}, Value: strconv.FormatFloat(float64(m.Metallicness), 'f', prec, 32)},
+			{Name: xml.Name{Local: attrRoughness}, Value: strconv.FormatFloat(float64(m.Roughness), 'f', prec, 32)},
+		}})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *PBMetallicTextureDisplayProperties) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBMetallicTexture}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrName}, Value: r.Name},
+		{Name: xml.Name{Local: attrMetallicTextureID}, Value: strconv.FormatUint(uint64(r.MetallicTextureID), 10)},
+		{Name: xml.Name{Local: attrRoughnessTexID}, Value: strconv.FormatUint(uint64(r.RoughnessTextureID), 10)},
+	}}
+	if r.BaseColorFactor != defaultFactorColor {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrBaseColorFactor}, Value: spec.FormatRGBA(r.BaseColorFactor)})
+	}
+	if r.MetallicFactor != 1 {
+		xs.Attr = append(xs.Attr, xml.Attr{
+			Name: xml.Name{Local: attrMetallicFactor}, Value: strconv.FormatFloat(float64(r.MetallicFactor), 'f', x.FloatPresicion(), 32),
+		})
+	}
+	if r.RoughnessFactor != 1 {
+		xs.Attr = append(xs.Attr, xml.Attr{
+			Name: xml.Name{Local: attrRoughnessFactor}, Value: strconv.FormatFloat(float64(r.RoughnessFactor), 'f', x.FloatPresicion(), 32),
+		})
+	}
+	x.SetAutoClose(true)
+	x.EncodeToken(xs)
+	x.SetAutoClose(false)
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *TranslucentDisplayProperties) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTranslucentDisplay}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	prec := x.FloatPresicion()
+	for _, t := range r.Translucents {
+		xt := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTranslucent}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: t.Name},
+			{Name: xml.Name{Local: attrAttenuation}, Value: formatFloat3(t.Attenuation, prec)},
+		}}
+		if t.RefractiveIndex != defaultRefractiveIndex {
+			xt.Attr = append(xt.Attr, xml.Attr{Name: xml.Name{Local: attrRefractiveIndex}, Value: formatFloat3(t.RefractiveIndex, prec)})
+		}
+		if t.Roughness != 0 {
+			xt.Attr = append(xt.Attr, xml.Attr{
+				Name: xml.Name{Local: attrRoughness}, Value: strconv.FormatFloat(float64(t.Roughness), 'f', prec, 32),
+			})
+		}
+		x.EncodeToken(xt)
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+func formatFloat3(v [3]float32, prec int) string {
+	return strings.Join([]string{
+		strconv.FormatFloat(float64(v[0]), 'f', prec, 32),
+		strconv.FormatFloat(float64(v[1]), 'f', prec, 32),
+		strconv.FormatFloat(float64(v[2]), 'f', prec, 32),
+	}, " ")
+}
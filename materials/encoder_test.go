@@ -17,8 +17,32 @@ func TestMarshalModel(t *testing.T) {
 	texGroup := &Texture2DGroup{ID: 2, TextureID: 6, Coords: []TextureCoord{{0.3, 0.5}, {0.3, 0.8}, {0.5, 0.8}, {0.5, 0.5}}}
 	compositeGroup := &CompositeMaterials{ID: 4, MaterialID: 5, Indices: []uint32{1, 2}, Composites: []Composite{{Values: []float32{0.5, 0.5}}, {Values: []float32{0.2, 0.8}}}}
 	multiGroup := &MultiProperties{ID: 9, BlendMethods: []BlendMethod{BlendMultiply}, PIDs: []uint32{5, 2}, Multis: []Multi{{PIndices: []uint32{0, 0}}, {PIndices: []uint32{1, 0}}, {PIndices: []uint32{2, 3}}}}
+	baseMaterials := &go3mf.BaseMaterials{ID: 5, Materials: []go3mf.Base{
+		{Name: "Red", Color: color.RGBA{R: 255, A: 255}},
+	}, AnyAttr: go3mf.AnyAttr{&BaseMaterialsAttr{DisplayPropertiesID: 12}}}
+	specular := &PBSpecularDisplayProperties{ID: 10, Specular: []PBSpecular{
+		{Name: "Shiny", SpecularColor: color.RGBA{R: 10, G: 20, B: 30, A: 255}, Glossiness: 0.8},
+	}}
+	specularTex := &PBSpecularTextureDisplayProperties{
+		ID: 11, Name: "Textured", SpecularTextureID: 6, GlossinessTextureID: 6,
+		DiffuseFactor: defaultFactorColor, SpecularFactor: color.RGBA{R: 128, G: 128, B: 128, A: 255}, GlossinessFactor: 0.5,
+	}
+	metallic := &PBMetallicDisplayProperties{ID: 12, Metallic: []PBMetallic{
+		{Name: "Steel", Metallicness: 1, Roughness: 0.3},
+	}}
+	metallicTex := &PBMetallicTextureDisplayProperties{
+		ID: 13, Name: "Rust", MetallicTextureID: 6, RoughnessTextureID: 6,
+		BaseColorFactor: color.RGBA{R: 200, G: 100, B: 50, A: 255}, MetallicFactor: 0.5, RoughnessFactor: 1,
+	}
+	translucent := &TranslucentDisplayProperties{ID: 14, Translucents: []Translucent{
+		{Name: "Glass", Attenuation: [3]float32{0.1, 0.2, 0.3}, RefractiveIndex: [3]float32{1.5, 1.5, 1.5}, Roughness: 0.1},
+		{Name: "Water", RefractiveIndex: defaultRefractiveIndex},
+	}}
+	colorGroup.DisplayPropertiesID = 10
+	texGroup.DisplayPropertiesID = 11
 	m := &go3mf.Model{Path: "/3D/3dmodel.model"}
-	m.Resources.Assets = append(m.Resources.Assets, baseTexture, colorGroup, texGroup, compositeGroup, multiGroup)
+	m.Resources.Assets = append(m.Resources.Assets, baseTexture, colorGroup, texGroup, compositeGroup, multiGroup,
+		baseMaterials, specular, specularTex, metallic, metallicTex, translucent)
 	m.Extensions = []go3mf.Extension{DefaultExtension}
 	t.Run("base", func(t *testing.T) {
 		b, err := go3mf.MarshalModel(m)
@@ -8,6 +8,7 @@ import (
 	"image/color"
 
 	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/internal/reference"
 )
 
 const (
@@ -37,6 +38,11 @@ var (
 	ErrTextureReference   = errors.New("MUST reference to a texture resource")
 	ErrCompositeBase      = errors.New("MUST reference to a basematerials group")
 	ErrMissingTexturePart = errors.New("texture part MUST be added as an attachment")
+	ErrDisplayPropsRef    = errors.New("displaypropertiesid MUST reference a display properties resource of a compatible type")
+	ErrDisplayPropsCount  = errors.New("the number of display properties MUST match the number of elements of the referencing group")
+	ErrDisplayPropsRange  = errors.New("value MUST be in the range [0, 1]")
+	ErrPropertyRef        = errors.New("pid MUST reference a property resource")
+	ErrEmptyPalette       = errors.New("palette MUST contain at least one filament")
 )
 
 // Texture2DType defines the allowed texture 2D types.
@@ -124,6 +130,11 @@ func (t *Texture2D) Identify() uint32 {
 	return t.ID
 }
 
+// Remap replaces the ID of t with the one returned by fn.
+func (t *Texture2D) Remap(fn func(path string, id uint32) (string, uint32)) {
+	t.ID = reference.Remap(fn, t.ID)
+}
+
 // TextureCoord map a vertex of a triangle to a position in image space (U, V coordinates)
 type TextureCoord [2]float32
 
@@ -139,9 +150,10 @@ func (t TextureCoord) V() float32 {
 
 // Texture2DGroup acts as a container for texture coordinate properties.
 type Texture2DGroup struct {
-	ID        uint32
-	TextureID uint32
-	Coords    []TextureCoord
+	ID                  uint32
+	TextureID           uint32
+	DisplayPropertiesID uint32
+	Coords              []TextureCoord
 }
 
 // Len returns the materials count.
@@ -154,10 +166,23 @@ func (r *Texture2DGroup) Identify() uint32 {
 	return r.ID
 }
 
+// References returns the IDs of the resources referenced by r.
+func (r *Texture2DGroup) References() []uint32 {
+	return reference.NonZero(r.TextureID, r.DisplayPropertiesID)
+}
+
+// Remap replaces the ID of r, and the IDs it references, with the ones returned by fn.
+func (r *Texture2DGroup) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+	r.TextureID = reference.Remap(fn, r.TextureID)
+	r.DisplayPropertiesID = reference.Remap(fn, r.DisplayPropertiesID)
+}
+
 // ColorGroup acts as a container for color properties.
 type ColorGroup struct {
-	ID     uint32
-	Colors []color.RGBA
+	ID                  uint32
+	DisplayPropertiesID uint32
+	Colors              []color.RGBA
 }
 
 // Len returns the materials count.
@@ -170,6 +195,17 @@ func (c *ColorGroup) Identify() uint32 {
 	return c.ID
 }
 
+// References returns the IDs of the resources referenced by c.
+func (c *ColorGroup) References() []uint32 {
+	return reference.NonZero(c.DisplayPropertiesID)
+}
+
+// Remap replaces the ID of c, and the IDs it references, with the ones returned by fn.
+func (c *ColorGroup) Remap(fn func(path string, id uint32) (string, uint32)) {
+	c.ID = reference.Remap(fn, c.ID)
+	c.DisplayPropertiesID = reference.Remap(fn, c.DisplayPropertiesID)
+}
+
 // A Composite specifies the proportion of the overall mixture for each material.
 type Composite struct {
 	Values []float32
@@ -193,6 +229,17 @@ func (c *CompositeMaterials) Identify() uint32 {
 	return c.ID
 }
 
+// References returns the IDs of the resources referenced by c.
+func (c *CompositeMaterials) References() []uint32 {
+	return reference.NonZero(c.MaterialID)
+}
+
+// Remap replaces the ID of c, and the IDs it references, with the ones returned by fn.
+func (c *CompositeMaterials) Remap(fn func(path string, id uint32) (string, uint32)) {
+	c.ID = reference.Remap(fn, c.ID)
+	c.MaterialID = reference.Remap(fn, c.MaterialID)
+}
+
 // The Multi element combines the constituent materials and properties.
 type Multi struct {
 	PIndices []uint32
@@ -217,6 +264,193 @@ func (c *MultiProperties) Identify() uint32 {
 	return c.ID
 }
 
+// References returns the IDs of the resources referenced by c.
+func (c *MultiProperties) References() []uint32 {
+	return reference.NonZero(c.PIDs...)
+}
+
+// Remap replaces the ID of c, and the IDs it references, with the ones returned by fn.
+func (c *MultiProperties) Remap(fn func(path string, id uint32) (string, uint32)) {
+	c.ID = reference.Remap(fn, c.ID)
+	for i, pid := range c.PIDs {
+		c.PIDs[i] = reference.Remap(fn, pid)
+	}
+}
+
+// BaseMaterialsAttr provides the display properties
+// of a core basematerials resource.
+type BaseMaterialsAttr struct {
+	DisplayPropertiesID uint32
+}
+
+// GetBaseMaterialsAttr returns the materials attributes of r, nil if it has none.
+func GetBaseMaterialsAttr(r *go3mf.BaseMaterials) *BaseMaterialsAttr {
+	for _, a := range r.AnyAttr {
+		if a, ok := a.(*BaseMaterialsAttr); ok {
+			return a
+		}
+	}
+	return nil
+}
+
+// References returns the IDs of the resources referenced by r.
+func (r *BaseMaterialsAttr) References() []uint32 {
+	return reference.NonZero(r.DisplayPropertiesID)
+}
+
+// Remap replaces the IDs referenced by r with the ones returned by fn.
+func (r *BaseMaterialsAttr) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.DisplayPropertiesID = reference.Remap(fn, r.DisplayPropertiesID)
+}
+
+// PBSpecular defines the specular and glossiness
+// properties of a single material.
+type PBSpecular struct {
+	Name          string
+	SpecularColor color.RGBA
+	Glossiness    float32
+}
+
+// PBSpecularDisplayProperties describes a physically based material
+// using the specular workflow.
+type PBSpecularDisplayProperties struct {
+	ID       uint32
+	Specular []PBSpecular
+}
+
+// Len returns the display properties count.
+func (r *PBSpecularDisplayProperties) Len() int {
+	return len(r.Specular)
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBSpecularDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// Remap replaces the ID of r with the one returned by fn.
+func (r *PBSpecularDisplayProperties) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+}
+
+// PBSpecularTextureDisplayProperties describes a physically based material
+// using the specular workflow whose parameters are defined by textures.
+type PBSpecularTextureDisplayProperties struct {
+	ID                  uint32
+	Name                string
+	SpecularTextureID   uint32
+	GlossinessTextureID uint32
+	DiffuseFactor       color.RGBA
+	SpecularFactor      color.RGBA
+	GlossinessFactor    float32
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBSpecularTextureDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// References returns the IDs of the resources referenced by r.
+func (r *PBSpecularTextureDisplayProperties) References() []uint32 {
+	return reference.NonZero(r.SpecularTextureID, r.GlossinessTextureID)
+}
+
+// Remap replaces the ID of r, and the IDs it references, with the ones returned by fn.
+func (r *PBSpecularTextureDisplayProperties) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+	r.SpecularTextureID = reference.Remap(fn, r.SpecularTextureID)
+	r.GlossinessTextureID = reference.Remap(fn, r.GlossinessTextureID)
+}
+
+// PBMetallic defines the metallicness and roughness
+// properties of a single material.
+type PBMetallic struct {
+	Name         string
+	Metallicness float32
+	Roughness    float32
+}
+
+// PBMetallicDisplayProperties describes a physically based material
+// using the metallic workflow.
+type PBMetallicDisplayProperties struct {
+	ID       uint32
+	Metallic []PBMetallic
+}
+
+// Len returns the display properties count.
+func (r *PBMetallicDisplayProperties) Len() int {
+	return len(r.Metallic)
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBMetallicDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// Remap replaces the ID of r with the one returned by fn.
+func (r *PBMetallicDisplayProperties) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+}
+
+// PBMetallicTextureDisplayProperties describes a physically based material
+// using the metallic workflow whose parameters are defined by textures.
+type PBMetallicTextureDisplayProperties struct {
+	ID                 uint32
+	Name               string
+	MetallicTextureID  uint32
+	RoughnessTextureID uint32
+	BaseColorFactor    color.RGBA
+	MetallicFactor     float32
+	RoughnessFactor    float32
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBMetallicTextureDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// References returns the IDs of the resources referenced by r.
+func (r *PBMetallicTextureDisplayProperties) References() []uint32 {
+	return reference.NonZero(r.MetallicTextureID, r.RoughnessTextureID)
+}
+
+// Remap replaces the ID of r, and the IDs it references, with the ones returned by fn.
+func (r *PBMetallicTextureDisplayProperties) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+	r.MetallicTextureID = reference.Remap(fn, r.MetallicTextureID)
+	r.RoughnessTextureID = reference.Remap(fn, r.RoughnessTextureID)
+}
+
+// Translucent defines the attenuation, refraction and roughness
+// properties of a single translucent material.
+type Translucent struct {
+	Name            string
+	Attenuation     [3]float32
+	RefractiveIndex [3]float32
+	Roughness       float32
+}
+
+// TranslucentDisplayProperties describes translucent materials.
+type TranslucentDisplayProperties struct {
+	ID           uint32
+	Translucents []Translucent
+}
+
+// Len returns the display properties count.
+func (r *TranslucentDisplayProperties) Len() int {
+	return len(r.Translucents)
+}
+
+// Identify returns the unique ID of the resource.
+func (r *TranslucentDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// Remap replaces the ID of r with the one returned by fn.
+func (r *TranslucentDisplayProperties) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+}
+
 func newTexture2DType(s string) (t Texture2DType, ok bool) {
 	t, ok = map[string]Texture2DType{
 		"image/png":  TextureTypePNG,
@@ -277,4 +511,37 @@ const (
 	attrPIndices           = "pindices"
 	attrPIDs               = "pids"
 	attrBlendMethods       = "blendmethods"
+	attrDisplayPropsID     = "displaypropertiesid"
+	attrName               = "name"
+	attrPBSpecularDisplay  = "pbspeculardisplayproperties"
+	attrPBSpecular         = "pbspecular"
+	attrSpecularColor      = "specularcolor"
+	attrGlossiness         = "glossiness"
+	attrPBSpecularTexture  = "pbspeculartexturedisplayproperties"
+	attrSpecularTextureID  = "speculartextureid"
+	attrGlossinessTexID    = "glossinesstextureid"
+	attrDiffuseFactor      = "diffusefactor"
+	attrSpecularFactor     = "specularfactor"
+	attrGlossinessFactor   = "glossinessfactor"
+	attrPBMetallicDisplay  = "pbmetallicdisplayproperties"
+	attrPBMetallic         = "pbmetallic"
+	attrMetallicness       = "metallicness"
+	attrRoughness          = "roughness"
+	attrPBMetallicTexture  = "pbmetallictexturedisplayproperties"
+	attrMetallicTextureID  = "metallictextureid"
+	attrRoughnessTexID     = "roughnesstextureid"
+	attrBaseColorFactor    = "basecolorfactor"
+	attrMetallicFactor     = "metallicfactor"
+	attrRoughnessFactor    = "roughnessfactor"
+	attrTranslucentDisplay = "translucentdisplayproperties"
+	attrTranslucent        = "translucent"
+	attrAttenuation        = "attenuation"
+	attrRefractiveIndex    = "refractiveindex"
+)
+
+// Default values of the display properties optional attributes.
+var (
+	defaultSpecularColor   = color.RGBA{R: 0x38, G: 0x38, B: 0x38, A: 0xff}
+	defaultFactorColor     = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	defaultRefractiveIndex = [3]float32{1, 1, 1}
 )
@@ -26,6 +26,17 @@ var _ spec.PropertyGroup = new(ColorGroup)
 var _ spec.PropertyGroup = new(Texture2DGroup)
 var _ spec.PropertyGroup = new(CompositeMaterials)
 var _ spec.PropertyGroup = new(MultiProperties)
+var _ go3mf.Asset = new(PBSpecularDisplayProperties)
+var _ go3mf.Asset = new(PBSpecularTextureDisplayProperties)
+var _ go3mf.Asset = new(PBMetallicDisplayProperties)
+var _ go3mf.Asset = new(PBMetallicTextureDisplayProperties)
+var _ go3mf.Asset = new(TranslucentDisplayProperties)
+var _ spec.Marshaler = new(PBSpecularDisplayProperties)
+var _ spec.Marshaler = new(PBSpecularTextureDisplayProperties)
+var _ spec.Marshaler = new(PBMetallicDisplayProperties)
+var _ spec.Marshaler = new(PBMetallicTextureDisplayProperties)
+var _ spec.Marshaler = new(TranslucentDisplayProperties)
+var _ spec.MarshalerAttr = new(BaseMaterialsAttr)
 
 func TestTexture2D_Identify(t *testing.T) {
 	tests := []struct {
@@ -394,3 +405,87 @@ func TestTexture2DGroup_Len(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBaseMaterialsAttr(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *go3mf.BaseMaterials
+		want *BaseMaterialsAttr
+	}{
+		{"empty", new(go3mf.BaseMaterials), nil},
+		{"base", &go3mf.BaseMaterials{AnyAttr: go3mf.AnyAttr{&spec.UnknownAttrs{}, &BaseMaterialsAttr{DisplayPropertiesID: 2}}}, &BaseMaterialsAttr{DisplayPropertiesID: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetBaseMaterialsAttr(tt.r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBaseMaterialsAttr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplayProperties_Len(t *testing.T) {
+	tests := []struct {
+		name string
+		r    interface{ Len() int }
+		want int
+	}{
+		{"specular", &PBSpecularDisplayProperties{Specular: make([]PBSpecular, 2)}, 2},
+		{"metallic", &PBMetallicDisplayProperties{Metallic: make([]PBMetallic, 3)}, 3},
+		{"translucent", &TranslucentDisplayProperties{Translucents: make([]Translucent, 1)}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Len(); got != tt.want {
+				t.Errorf("Len() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		r    interface{ References() []uint32 }
+		want []uint32
+	}{
+		{"texture2DGroupEmpty", new(Texture2DGroup), nil},
+		{"texture2DGroup", &Texture2DGroup{TextureID: 2, DisplayPropertiesID: 3}, []uint32{2, 3}},
+		{"colorGroup", &ColorGroup{DisplayPropertiesID: 3}, []uint32{3}},
+		{"composite", &CompositeMaterials{MaterialID: 4}, []uint32{4}},
+		{"multi", &MultiProperties{PIDs: []uint32{1, 0, 5}}, []uint32{1, 5}},
+		{"baseMaterials", &BaseMaterialsAttr{DisplayPropertiesID: 6}, []uint32{6}},
+		{"specular", &PBSpecularTextureDisplayProperties{SpecularTextureID: 1, GlossinessTextureID: 2}, []uint32{1, 2}},
+		{"metallic", &PBMetallicTextureDisplayProperties{RoughnessTextureID: 2}, []uint32{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.References(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("References() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemap(t *testing.T) {
+	fn := func(path string, id uint32) (string, uint32) { return path, id + 10 }
+	tests := []struct {
+		name string
+		r    interface {
+			Remap(func(string, uint32) (string, uint32))
+		}
+		want interface{}
+	}{
+		{"texture2DGroupEmpty", &Texture2DGroup{ID: 1}, &Texture2DGroup{ID: 11}},
+		{"texture2DGroup", &Texture2DGroup{ID: 1, TextureID: 2, DisplayPropertiesID: 3}, &Texture2DGroup{ID: 11, TextureID: 12, DisplayPropertiesID: 13}},
+		{"multi", &MultiProperties{ID: 1, PIDs: []uint32{1, 5}}, &MultiProperties{ID: 11, PIDs: []uint32{11, 15}}},
+		{"baseMaterials", &BaseMaterialsAttr{DisplayPropertiesID: 6}, &BaseMaterialsAttr{DisplayPropertiesID: 16}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.r.Remap(fn); !reflect.DeepEqual(tt.r, tt.want) {
+				t.Errorf("Remap() = %v, want %v", tt.r, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package materials
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+)
+
+// A Filament is a printing material available to Quantize.
+type Filament struct {
+	Name  string
+	Color color.RGBA
+}
+
+// QuantizeReport summarizes the result of Quantize.
+type QuantizeReport struct {
+	// Materials maps each model file path, empty for the root model,
+	// to the IDs of the base materials created for each filament.
+	Materials map[string][]uint32
+	// Area is the surface assigned to each filament, in the same order
+	// as the palette, measured in object coordinates and counting each
+	// object once regardless of how many times it is built.
+	Area []float64
+	// Unassigned is the surface of the triangles without properties.
+	Unassigned float64
+}
+
+// Quantize assigns to every triangle of m with properties, of any kind,
+// the filament of palette closest to its resolved color, comparing colors
+// in the CIELAB space. The color of a triangle is the one at its centroid.
+//
+// A BaseMaterials with a single base is appended for each filament to the
+// resources of each model file with assigned triangles, and the triangles and
+// object default properties are rewritten to reference them.
+// The resources that are no longer used are removed as in BakeColors.
+//
+// If dither is greater than 1, each triangle of the meshes with properties is
+// split into dither² triangles, as in BakeColors, and the quantization error
+// of each sub-triangle is diffused into the next one, approximating the colors
+// that are not in the palette with a mix of filaments.
+func Quantize(m *go3mf.Model, palette []Filament, dither int) (*QuantizeReport, error) {
+	if len(palette) == 0 {
+		return nil, ErrEmptyPalette
+	}
+	q := quantizer{
+		m:        m,
+		r:        NewResolver(m),
+		s:        dither,
+		palette:  make([]labColor, len(palette)),
+		filament: palette,
+		textures: make(map[string]bool),
+		report: &QuantizeReport{
+			Materials: make(map[string][]uint32),
+			Area:      make([]float64, len(palette)),
+		},
+	}
+	for i, f := range palette {
+		q.palette[i] = toLab(toLinear(f.Color))
+	}
+	paths := make([]string, 0, len(m.Childs))
+	for path := range m.Childs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		child := m.Childs[path]
+		if err := child.Load(); err != nil {
+			return nil, err
+		}
+		if err := q.quantizeResources(path, &child.Resources); err != nil {
+			return nil, err
+		}
+	}
+	if err := q.quantizeResources("", &m.Resources); err != nil {
+		return nil, err
+	}
+	removeTextures(m, q.textures)
+	return q.report, nil
+}
+
+type quantizer struct {
+	m        *go3mf.Model
+	r        *Resolver
+	s        int
+	palette  []labColor
+	filament []Filament
+	textures map[string]bool
+	report   *QuantizeReport
+
+	// State of the model file being quantized.
+	path     string
+	res      *go3mf.Resources
+	ids      []uint32
+	replaced map[uint32]bool
+}
+
+func (q *quantizer) quantizeResources(path string, res *go3mf.Resources) error {
+	q.path, q.res, q.ids = path, res, nil
+	q.replaced = make(map[uint32]bool)
+	for i, obj := range res.Objects {
+		if err := q.quantizeObject(obj); err != nil {
+			return specerr.WrapPath(specerr.WrapIndex(err, obj, i), *res, path)
+		}
+	}
+	if len(q.replaced) > 0 {
+		removeUnused(res, q.replaced, q.textures)
+	}
+	return nil
+}
+
+// filamentID returns the ID of the base materials of the filament f,
+// creating the base materials of the palette on first use.
+func (q *quantizer) filamentID(f int) uint32 {
+	if q.ids == nil {
+		q.ids = make([]uint32, len(q.filament))
+		for i, fil := range q.filament {
+			q.ids[i] = q.res.UnusedID()
+			q.res.Assets = append(q.res.Assets, &go3mf.BaseMaterials{
+				ID:        q.ids[i],
+				Materials: []go3mf.Base{{Name: fil.Name, Color: fil.Color}},
+			})
+		}
+		q.report.Materials[q.path] = q.ids
+	}
+	return q.ids[f]
+}
+
+func (q *quantizer) quantizeObject(obj *go3mf.Object) error {
+	if obj.Mesh != nil {
+		var hasProps bool
+		for _, t := range obj.Mesh.Triangles {
+			if effectivePID(obj, &t) != 0 {
+				hasProps = true
+				break
+			}
+		}
+		s := 1
+		if hasProps {
+			s = q.s
+		}
+		out, subs, err := subdivide(obj.Mesh, s)
+		if err != nil {
+			return err
+		}
+		var (
+			diffused labColor
+			prev     = -1
+		)
+		for k, sub := range subs {
+			nt := &out.Triangles[k]
+			area := triangleArea(out, nt)
+			var b [3]float64
+			for _, w := range sub.w {
+				for c := range b {
+					b[c] += float64(w[c]) / float64(3*s)
+				}
+			}
+			rgba, ok, err := q.r.TriangleColorAt(q.path, obj, sub.i, b)
+			if err != nil {
+				return specerr.WrapIndex(err, obj.Mesh.Triangles[sub.i], sub.i)
+			}
+			if !ok {
+				q.report.Unassigned += area
+				continue
+			}
+			q.replaced[effectivePID(obj, &obj.Mesh.Triangles[sub.i])] = true
+			target := toLab(toLinear(rgba))
+			if sub.i != prev {
+				diffused, prev = labColor{}, sub.i
+			}
+			if s > 1 {
+				for c := range target {
+					target[c] += diffused[c]
+				}
+			}
+			f := q.nearest(target)
+			for c := range diffused {
+				diffused[c] = target[c] - q.palette[f][c]
+			}
+			nt.PID, nt.P1, nt.P2, nt.P3 = q.filamentID(f), 0, 0, 0
+			q.report.Area[f] += area
+		}
+		obj.Mesh = out
+	}
+	if obj.PID != 0 {
+		rgba, err := q.r.PropertyColor(q.path, obj.PID, obj.PIndex)
+		if err != nil {
+			return err
+		}
+		q.replaced[obj.PID] = true
+		obj.PID, obj.PIndex = q.filamentID(q.nearest(toLab(toLinear(rgba)))), 0
+	}
+	return nil
+}
+
+// nearest returns the index of the filament closest to c.
+func (q *quantizer) nearest(c labColor) int {
+	best, dist := 0, math.Inf(1)
+	for i, p := range q.palette {
+		var d float64
+		for k := range c {
+			d += (c[k] - p[k]) * (c[k] - p[k])
+		}
+		if d < dist {
+			best, dist = i, d
+		}
+	}
+	return best
+}
+
+func triangleArea(mesh *go3mf.Mesh, t *go3mf.Triangle) float64 {
+	v1, v2, v3 := mesh.Vertices[t.V1], mesh.Vertices[t.V2], mesh.Vertices[t.V3]
+	var a, b [3]float64
+	for k := 0; k < 3; k++ {
+		a[k] = float64(v2[k] - v1[k])
+		b[k] = float64(v3[k] - v1[k])
+	}
+	cx := a[1]*b[2] - a[2]*b[1]
+	cy := a[2]*b[0] - a[0]*b[2]
+	cz := a[0]*b[1] - a[1]*b[0]
+	return math.Sqrt(cx*cx+cy*cy+cz*cz) / 2
+}
+
+// labColor is a color in the CIELAB space, relative to the D65 white point.
+type labColor [3]float64
+
+func toLab(c linearColor) labColor {
+	x := (0.4124*c[0] + 0.3576*c[1] + 0.1805*c[2]) / 0.95047
+	y := 0.2126*c[0] + 0.7152*c[1] + 0.0722*c[2]
+	z := (0.0193*c[0] + 0.1192*c[1] + 0.9505*c[2]) / 1.08883
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return labColor{116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)}
+}
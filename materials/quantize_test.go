@@ -0,0 +1,96 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package materials
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/go-test/deep"
+)
+
+func TestQuantize(t *testing.T) {
+	palette := []Filament{
+		{Name: "black", Color: color.RGBA{A: 255}},
+		{Name: "red", Color: color.RGBA{R: 250, G: 10, A: 255}},
+		{Name: "green", Color: color.RGBA{G: 200, A: 255}},
+		{Name: "blue", Color: color.RGBA{B: 200, A: 255}},
+	}
+	m := newResolverModel(t)
+	obj := &go3mf.Object{ID: 20, Mesh: &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {2, 0, 0}, {0, 2, 0}, {2, 2, 0}},
+		Triangles: []go3mf.Triangle{
+			{V1: 0, V2: 1, V3: 2, PID: 8, P1: 0, P2: 1, P3: 0},
+			{V1: 1, V2: 3, V3: 2, PID: 4, P1: 0, P2: 0, P3: 0},
+			{V1: 0, V2: 2, V3: 3},
+		},
+	}}
+	m.Resources.Objects = append(m.Resources.Objects, obj)
+	got, err := Quantize(m, palette, 1)
+	if err != nil {
+		t.Fatalf("Quantize() error = %v", err)
+	}
+	want := &QuantizeReport{
+		Materials:  map[string][]uint32{"": {9, 10, 11, 12}},
+		Area:       []float64{0, 2, 2, 0},
+		Unassigned: 2,
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Quantize() = %v", diff)
+	}
+	if diff := deep.Equal(obj.Mesh.Triangles, []go3mf.Triangle{
+		{V1: 0, V2: 1, V3: 2, PID: 10},
+		{V1: 1, V2: 3, V3: 2, PID: 11},
+		{V1: 0, V2: 2, V3: 3},
+	}); diff != nil {
+		t.Errorf("Quantize() triangles = %v", diff)
+	}
+	var ids []uint32
+	for _, a := range m.Resources.Assets {
+		ids = append(ids, a.Identify())
+	}
+	if diff := deep.Equal(ids, []uint32{1, 2, 3, 4, 5, 6, 9, 10, 11, 12}); diff != nil {
+		t.Errorf("Quantize() resources = %v", diff)
+	}
+	if base := m.Resources.Assets[7].(*go3mf.BaseMaterials); base.Materials[0].Name != "red" {
+		t.Errorf("Quantize() base materials = %v", base)
+	}
+	if len(m.Attachments) != 0 {
+		t.Errorf("Quantize() attachments = %v", m.Attachments)
+	}
+}
+
+func TestQuantize_dither(t *testing.T) {
+	palette := []Filament{{Name: "black", Color: color.RGBA{A: 255}}, {Name: "white", Color: color.RGBA{R: 255, G: 255, B: 255, A: 255}}}
+	m := &go3mf.Model{Resources: go3mf.Resources{
+		Assets: []go3mf.Asset{&ColorGroup{ID: 1, Colors: []color.RGBA{{R: 128, G: 128, B: 128, A: 255}}}},
+		Objects: []*go3mf.Object{{ID: 2, PID: 1, Mesh: &go3mf.Mesh{
+			Vertices:  []go3mf.Point3D{{0, 0, 0}, {4, 0, 0}, {0, 4, 0}},
+			Triangles: []go3mf.Triangle{{V1: 0, V2: 1, V3: 2, PID: 1}},
+		}}},
+	}}
+	got, err := Quantize(m, palette, 4)
+	if err != nil {
+		t.Fatalf("Quantize() error = %v", err)
+	}
+	obj := m.Resources.Objects[0]
+	if len(obj.Mesh.Triangles) != 16 || len(obj.Mesh.Vertices) != 15 {
+		t.Errorf("Quantize() mesh = %v", obj.Mesh)
+	}
+	if got.Area[0] == 0 || got.Area[1] == 0 || math.Abs(got.Area[0]+got.Area[1]-8) > 1e-6 {
+		t.Errorf("Quantize() area = %v", got.Area)
+	}
+	if obj.PID != 3 && obj.PID != 4 {
+		t.Errorf("Quantize() object pid = %d", obj.PID)
+	}
+	if len(m.Resources.Assets) != 2 {
+		t.Errorf("Quantize() resources = %v", m.Resources.Assets)
+	}
+	if _, err := Quantize(m, nil, 1); !errors.Is(err, ErrEmptyPalette) {
+		t.Errorf("Quantize() error = %v, want %v", err, ErrEmptyPalette)
+	}
+}
@@ -0,0 +1,284 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package materials
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+)
+
+// Resolver computes the effective color of the triangles of a model,
+// resolving base materials, color groups, textures, composite materials
+// and multi-properties into a single sRGB color with alpha.
+//
+// Colors are interpolated, mixed and blended in linear RGB space.
+// Decoded texture images are cached, so a Resolver should be reused
+// across calls. It is not safe for concurrent use.
+type Resolver struct {
+	m      *go3mf.Model
+	images map[*Texture2D]image.Image
+}
+
+// NewResolver returns a Resolver of the properties of m.
+func NewResolver(m *go3mf.Model) *Resolver {
+	return &Resolver{m: m, images: make(map[*Texture2D]image.Image)}
+}
+
+// TriangleColors returns the effective color of each corner of the triangle i
+// of obj, which is located in the model file path.
+//
+// Triangles without pid use the object pid and pindex.
+// ok is false if neither the triangle nor the object define properties.
+func (r *Resolver) TriangleColors(path string, obj *go3mf.Object, i int) (c [3]color.RGBA, ok bool, err error) {
+	for j := range c {
+		var b [3]float64
+		b[j] = 1
+		if c[j], ok, err = r.TriangleColorAt(path, obj, i, b); !ok || err != nil {
+			return
+		}
+	}
+	return
+}
+
+// TriangleColorAt returns the effective color at the point of the triangle i
+// of obj with barycentric coordinates b, which must add up to 1.
+// Texture coordinates are interpolated before sampling the texture,
+// other properties are interpolated from the colors of the corners.
+//
+// Triangles without pid use the object pid and pindex.
+// ok is false if neither the triangle nor the object define properties.
+func (r *Resolver) TriangleColorAt(path string, obj *go3mf.Object, i int, b [3]float64) (color.RGBA, bool, error) {
+	if obj.Mesh == nil || i < 0 || i >= len(obj.Mesh.Triangles) {
+		return color.RGBA{}, false, specerr.ErrIndexOutOfBounds
+	}
+	t := obj.Mesh.Triangles[i]
+	pid, indices := t.PID, [3]uint32{t.P1, t.P2, t.P3}
+	if pid == 0 {
+		pid, indices = obj.PID, [3]uint32{obj.PIndex, obj.PIndex, obj.PIndex}
+	}
+	if pid == 0 {
+		return color.RGBA{}, false, nil
+	}
+	c, err := r.resolve(path, pid, indices, b, false)
+	if err != nil {
+		return color.RGBA{}, false, err
+	}
+	return c.sRGB(), true, nil
+}
+
+// PropertyColor returns the effective color of the property index
+// of the resource pid, which is located in the model file path.
+func (r *Resolver) PropertyColor(path string, pid, index uint32) (color.RGBA, error) {
+	c, err := r.resolve(path, pid, [3]uint32{index, index, index}, [3]float64{1, 0, 0}, false)
+	if err != nil {
+		return color.RGBA{}, err
+	}
+	return c.sRGB(), nil
+}
+
+// resolve returns the color of the resource pid interpolated from the
+// property indices of the three corners with the barycentric coordinates b.
+func (r *Resolver) resolve(path string, pid uint32, indices [3]uint32, b [3]float64, nested bool) (linearColor, error) {
+	a, ok := r.m.FindAsset(path, pid)
+	if !ok {
+		return linearColor{}, specerr.ErrMissingResource
+	}
+	switch a := a.(type) {
+	case *go3mf.BaseMaterials:
+		return interpolate(indices, b, len(a.Materials), func(i uint32) (linearColor, error) {
+			return toLinear(a.Materials[i].Color), nil
+		})
+	case *ColorGroup:
+		return interpolate(indices, b, len(a.Colors), func(i uint32) (linearColor, error) {
+			return toLinear(a.Colors[i]), nil
+		})
+	case *CompositeMaterials:
+		return interpolate(indices, b, len(a.Composites), func(i uint32) (linearColor, error) {
+			return r.composite(path, a, a.Composites[i])
+		})
+	case *Texture2DGroup:
+		var u, v float64
+		for j, idx := range indices {
+			if int(idx) >= len(a.Coords) {
+				return linearColor{}, specerr.ErrIndexOutOfBounds
+			}
+			u += b[j] * float64(a.Coords[idx].U())
+			v += b[j] * float64(a.Coords[idx].V())
+		}
+		return r.sample(path, a.TextureID, u, v)
+	case *MultiProperties:
+		if nested {
+			return linearColor{}, ErrMultiRefMulti
+		}
+		return r.multi(path, a, indices, b)
+	}
+	return linearColor{}, ErrPropertyRef
+}
+
+// interpolate mixes the colors of the three indices with the weights b.
+func interpolate(indices [3]uint32, b [3]float64, n int, fn func(uint32) (linearColor, error)) (c linearColor, err error) {
+	for j, idx := range indices {
+		if b[j] == 0 {
+			continue
+		}
+		if int(idx) >= n {
+			return linearColor{}, specerr.ErrIndexOutOfBounds
+		}
+		var ci linearColor
+		if ci, err = fn(idx); err != nil {
+			return
+		}
+		for k := range c {
+			c[k] += b[j] * ci[k]
+		}
+	}
+	return
+}
+
+// composite mixes the base materials of res with the proportions of c.
+func (r *Resolver) composite(path string, res *CompositeMaterials, c Composite) (linearColor, error) {
+	a, ok := r.m.FindAsset(path, res.MaterialID)
+	if !ok {
+		return linearColor{}, specerr.ErrMissingResource
+	}
+	base, ok := a.(*go3mf.BaseMaterials)
+	if !ok {
+		return linearColor{}, ErrCompositeBase
+	}
+	var (
+		mix   linearColor
+		total float64
+	)
+	for i, v := range c.Values {
+		if i >= len(res.Indices) || int(res.Indices[i]) >= len(base.Materials) {
+			return linearColor{}, specerr.ErrIndexOutOfBounds
+		}
+		ci := toLinear(base.Materials[res.Indices[i]].Color)
+		for k := range mix {
+			mix[k] += float64(v) * ci[k]
+		}
+		total += float64(v)
+	}
+	if total > 0 {
+		for k := range mix {
+			mix[k] /= total
+		}
+	}
+	return mix, nil
+}
+
+// multi blends the layers of res from the first to the last one.
+func (r *Resolver) multi(path string, res *MultiProperties, indices [3]uint32, b [3]float64) (linearColor, error) {
+	var c linearColor
+	for j, pid := range res.PIDs {
+		var layer [3]uint32
+		for k, idx := range indices {
+			if int(idx) >= len(res.Multis) {
+				return linearColor{}, specerr.ErrIndexOutOfBounds
+			}
+			if pindices := res.Multis[idx].PIndices; j < len(pindices) {
+				layer[k] = pindices[j]
+			}
+		}
+		lc, err := r.resolve(path, pid, layer, b, true)
+		if err != nil {
+			return linearColor{}, err
+		}
+		if j == 0 {
+			c = lc
+			continue
+		}
+		method := BlendMix
+		if j-1 < len(res.BlendMethods) {
+			method = res.BlendMethods[j-1]
+		}
+		c = blend(c, lc, method)
+	}
+	return c, nil
+}
+
+// blend composes the layer over c using method.
+func blend(c, layer linearColor, method BlendMethod) linearColor {
+	if method == BlendMultiply {
+		return linearColor{c[0] * layer[0], c[1] * layer[1], c[2] * layer[2], c[3] * layer[3]}
+	}
+	a := layer[3] + c[3]*(1-layer[3])
+	if a == 0 {
+		return linearColor{}
+	}
+	var out linearColor
+	for k := 0; k < 3; k++ {
+		out[k] = (layer[k]*layer[3] + c[k]*c[3]*(1-layer[3])) / a
+	}
+	out[3] = a
+	return out
+}
+
+// sample returns the color of the texture id at the texture coordinate (u, v),
+// applying its tile styles and filter.
+func (r *Resolver) sample(path string, id uint32, u, v float64) (linearColor, error) {
+	a, ok := r.m.FindAsset(path, id)
+	if !ok {
+		return linearColor{}, specerr.ErrMissingResource
+	}
+	tex, ok := a.(*Texture2D)
+	if !ok {
+		return linearColor{}, ErrTextureReference
+	}
+	img, ok := r.images[tex]
+	if !ok {
+		var err error
+		if img, err = tex.Image(r.m); err != nil {
+			return linearColor{}, err
+		}
+		r.images[tex] = img
+	}
+	smp := Sampler{Bounds: img.Bounds(), TileStyleU: tex.TileStyleU, TileStyleV: tex.TileStyleV, Filter: tex.Filter}
+	var c linearColor
+	for _, t := range smp.Texels(u, v) {
+		if t.Weight == 0 {
+			continue
+		}
+		tc := toLinear(color.RGBA(color.NRGBAModel.Convert(img.At(t.X, t.Y)).(color.NRGBA)))
+		for k := range c {
+			c[k] += tc[k] * t.Weight
+		}
+	}
+	return c, nil
+}
+
+// linearColor is a non premultiplied color with linear RGB components and alpha in [0, 1].
+type linearColor [4]float64
+
+// toLinear converts c, which as any color of the spec is not premultiplied.
+func toLinear(c color.RGBA) linearColor {
+	return linearColor{srgbToLinear(c.R), srgbToLinear(c.G), srgbToLinear(c.B), float64(c.A) / 255}
+}
+
+func (c linearColor) sRGB() color.RGBA {
+	return color.RGBA{R: linearToSRGB(c[0]), G: linearToSRGB(c[1]), B: linearToSRGB(c[2]), A: clamp8(c[3])}
+}
+
+func srgbToLinear(v uint8) float64 {
+	f := float64(v) / 255
+	if f <= 0.04045 {
+		return f / 12.92
+	}
+	return math.Pow((f+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(f float64) uint8 {
+	if f <= 0.0031308 {
+		return clamp8(f * 12.92)
+	}
+	return clamp8(1.055*math.Pow(f, 1/2.4) - 0.055)
+}
+
+func clamp8(f float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, f)) * 255))
+}
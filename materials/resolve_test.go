@@ -0,0 +1,140 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package materials
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+)
+
+func newResolverModel(t *testing.T) *go3mf.Model {
+	m := &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+		&go3mf.BaseMaterials{ID: 1, Materials: []go3mf.Base{
+			{Name: "red", Color: color.RGBA{R: 255, A: 255}},
+			{Name: "blue", Color: color.RGBA{B: 255, A: 255}},
+		}},
+		&ColorGroup{ID: 2, Colors: []color.RGBA{{G: 255, A: 255}, {R: 255, G: 255, B: 255, A: 0}}},
+		&CompositeMaterials{ID: 3, MaterialID: 1, Indices: []uint32{0, 1}, Composites: []Composite{
+			{Values: []float32{1, 1}}, {Values: []float32{0, 2}},
+		}},
+		&MultiProperties{ID: 4, PIDs: []uint32{1, 2}, Multis: []Multi{{PIndices: []uint32{0, 0}}, {PIndices: []uint32{1, 1}}, {PIndices: []uint32{1}}}},
+		&MultiProperties{ID: 5, PIDs: []uint32{1, 2}, BlendMethods: []BlendMethod{BlendMultiply}, Multis: []Multi{{PIndices: []uint32{0, 0}}}},
+		&MultiProperties{ID: 6, PIDs: []uint32{4}, Multis: []Multi{{PIndices: []uint32{0}}}},
+	}}}
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	img.Set(1, 0, color.NRGBA{B: 255, A: 255})
+	tex, err := AddTexture2D(m, img, TextureTypePNG)
+	if err != nil {
+		t.Fatalf("AddTexture2D() error = %v", err)
+	}
+	tex.Filter = TextureFilterNearest
+	m.Resources.Assets = append(m.Resources.Assets, &Texture2DGroup{ID: 8, TextureID: tex.ID, Coords: []TextureCoord{
+		{0.25, 0.5}, {0.75, 0.5}, {1.25, 0.5},
+	}})
+	return m
+}
+
+func TestResolver_TriangleColors(t *testing.T) {
+	red, green, blue := color.RGBA{R: 255, A: 255}, color.RGBA{G: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	tests := []struct {
+		name    string
+		obj     go3mf.Object
+		want    [3]color.RGBA
+		wantOK  bool
+		wantErr error
+	}{
+		{"none", go3mf.Object{}, [3]color.RGBA{}, false, nil},
+		{"objectPID", go3mf.Object{PID: 1, PIndex: 1}, [3]color.RGBA{blue, blue, blue}, true, nil},
+		{"base", go3mf.Object{PID: 2, Mesh: &go3mf.Mesh{Triangles: []go3mf.Triangle{{PID: 1, P1: 0, P2: 1, P3: 0}}}}, [3]color.RGBA{red, blue, red}, true, nil},
+		{"colorgroup", go3mf.Object{Mesh: &go3mf.Mesh{Triangles: []go3mf.Triangle{{PID: 2, P1: 0, P2: 1, P3: 0}}}}, [3]color.RGBA{green, {R: 255, G: 255, B: 255}, green}, true, nil},
+		{"composite", go3mf.Object{Mesh: &go3mf.Mesh{Triangles: []go3mf.Triangle{{PID: 3, P1: 0, P2: 1, P3: 1}}}}, [3]color.RGBA{{R: 188, B: 188, A: 255}, blue, blue}, true, nil},
+		{"texture", go3mf.Object{Mesh: &go3mf.Mesh{Triangles: []go3mf.Triangle{{PID: 8, P1: 0, P2: 1, P3: 2}}}}, [3]color.RGBA{red, blue, red}, true, nil},
+		{"multi", go3mf.Object{Mesh: &go3mf.Mesh{Triangles: []go3mf.Triangle{{PID: 4, P1: 0, P2: 1, P3: 2}}}}, [3]color.RGBA{green, blue, green}, true, nil},
+		{"multiply", go3mf.Object{Mesh: &go3mf.Mesh{Triangles: []go3mf.Triangle{{PID: 5}}}}, [3]color.RGBA{{A: 255}, {A: 255}, {A: 255}}, true, nil},
+		{"missing", go3mf.Object{Mesh: &go3mf.Mesh{Triangles: []go3mf.Triangle{{PID: 100}}}}, [3]color.RGBA{}, false, specerr.ErrMissingResource},
+		{"outOfBounds", go3mf.Object{Mesh: &go3mf.Mesh{Triangles: []go3mf.Triangle{{PID: 1, P1: 5}}}}, [3]color.RGBA{}, false, specerr.ErrIndexOutOfBounds},
+		{"texture2d", go3mf.Object{Mesh: &go3mf.Mesh{Triangles: []go3mf.Triangle{{PID: 7}}}}, [3]color.RGBA{}, false, ErrPropertyRef},
+		{"nestedMulti", go3mf.Object{Mesh: &go3mf.Mesh{Triangles: []go3mf.Triangle{{PID: 6}}}}, [3]color.RGBA{}, false, ErrMultiRefMulti},
+	}
+	r := NewResolver(newResolverModel(t))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := 0
+			if tt.obj.Mesh == nil {
+				tt.obj.Mesh = &go3mf.Mesh{Triangles: []go3mf.Triangle{{}}}
+			}
+			got, ok, err := r.TriangleColors("", &tt.obj, i)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Resolver.TriangleColors() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("Resolver.TriangleColors() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("Resolver.TriangleColors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolver_TriangleColorAt(t *testing.T) {
+	m := newResolverModel(t)
+	obj := &go3mf.Object{Mesh: &go3mf.Mesh{Triangles: []go3mf.Triangle{
+		{PID: 8, P1: 0, P2: 1, P3: 1},
+		{PID: 1, P1: 0, P2: 1, P3: 1},
+	}}}
+	tests := []struct {
+		name string
+		i    int
+		b    [3]float64
+		want color.RGBA
+	}{
+		{"textureNearest", 0, [3]float64{0.4, 0.6, 0}, color.RGBA{B: 255, A: 255}},
+		{"base", 1, [3]float64{0.5, 0.5, 0}, color.RGBA{R: 188, B: 188, A: 255}},
+	}
+	r := NewResolver(m)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := r.TriangleColorAt("", obj, tt.i, tt.b)
+			if err != nil || !ok {
+				t.Fatalf("Resolver.TriangleColorAt() ok = %v, error = %v", ok, err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolver.TriangleColorAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, _, err := r.TriangleColorAt("", obj, 2, [3]float64{1, 0, 0}); !errors.Is(err, specerr.ErrIndexOutOfBounds) {
+		t.Errorf("Resolver.TriangleColorAt() error = %v, want %v", err, specerr.ErrIndexOutOfBounds)
+	}
+	m.Resources.Assets[len(m.Resources.Assets)-2].(*Texture2D).Filter = TextureFilterAuto
+	r = NewResolver(m)
+	got, _, err := r.TriangleColorAt("", obj, 0, [3]float64{0.5, 0.5, 0})
+	if err != nil {
+		t.Fatalf("Resolver.TriangleColorAt() error = %v", err)
+	}
+	if want := (color.RGBA{R: 188, B: 188, A: 255}); got != want {
+		t.Errorf("Resolver.TriangleColorAt() = %v, want %v", got, want)
+	}
+}
+
+func TestResolver_PropertyColor(t *testing.T) {
+	r := NewResolver(newResolverModel(t))
+	got, err := r.PropertyColor("", 2, 0)
+	if err != nil {
+		t.Fatalf("Resolver.PropertyColor() error = %v", err)
+	}
+	if want := (color.RGBA{G: 255, A: 255}); got != want {
+		t.Errorf("Resolver.PropertyColor() = %v, want %v", got, want)
+	}
+	if _, err := r.PropertyColor("/other.model", 2, 0); !errors.Is(err, specerr.ErrMissingResource) {
+		t.Errorf("Resolver.PropertyColor() error = %v, want %v", err, specerr.ErrMissingResource)
+	}
+}
@@ -0,0 +1,96 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package materials
+
+import (
+	"image"
+	"math"
+)
+
+// A Sampler maps texture coordinates to the pixels of an image,
+// applying the tile styles and filter of a texture.
+//
+// TileNone is treated as TileClamp: coordinates outside [0, 1]
+// take the color of the nearest edge of the image.
+type Sampler struct {
+	Bounds     image.Rectangle
+	TileStyleU TileStyle
+	TileStyleV TileStyle
+	Filter     TextureFilter
+}
+
+// A Texel is a pixel of an image and its weight in a sample.
+type Texel struct {
+	X, Y   int
+	Weight float64
+}
+
+// Texels returns the pixels that contribute to the texture coordinate (u, v),
+// in image coordinates, with weights that add up to 1.
+// Unused texels have zero weight, and all of them do if the image is empty.
+// The texture origin is the bottom left corner.
+// Pixels are bilinearly interpolated unless the filter is TextureFilterNearest.
+func (s Sampler) Texels(u, v float64) (t [4]Texel) {
+	w, h := s.Bounds.Dx(), s.Bounds.Dy()
+	if w <= 0 || h <= 0 {
+		return
+	}
+	x, y := tile(u, s.TileStyleU)*float64(w), (1-tile(v, s.TileStyleV))*float64(h)
+	if s.Filter == TextureFilterNearest {
+		t[0] = Texel{X: s.x(int(math.Floor(x))), Y: s.y(int(math.Floor(y))), Weight: 1}
+		return
+	}
+	x, y = x-0.5, y-0.5
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := x-x0, y-y0
+	px0, px1 := s.x(int(x0)), s.x(int(x0)+1)
+	py0, py1 := s.y(int(y0)), s.y(int(y0)+1)
+	t[0] = Texel{X: px0, Y: py0, Weight: (1 - fx) * (1 - fy)}
+	t[1] = Texel{X: px1, Y: py0, Weight: fx * (1 - fy)}
+	t[2] = Texel{X: px0, Y: py1, Weight: (1 - fx) * fy}
+	t[3] = Texel{X: px1, Y: py1, Weight: fx * fy}
+	return
+}
+
+func (s Sampler) x(p int) int {
+	return s.Bounds.Min.X + pixel(p, s.Bounds.Dx(), s.TileStyleU)
+}
+
+func (s Sampler) y(p int) int {
+	return s.Bounds.Min.Y + pixel(p, s.Bounds.Dy(), s.TileStyleV)
+}
+
+// tile maps the texture coordinate to [0, 1].
+func tile(t float64, style TileStyle) float64 {
+	switch style {
+	case TileMirror:
+		t = math.Mod(math.Abs(t), 2)
+		if t > 1 {
+			t = 2 - t
+		}
+	case TileClamp, TileNone:
+		t = math.Max(0, math.Min(1, t))
+	default:
+		t -= math.Floor(t)
+	}
+	return t
+}
+
+// pixel maps a pixel coordinate to [0, size).
+func pixel(p, size int, style TileStyle) int {
+	if style == TileWrap {
+		p %= size
+		if p < 0 {
+			p += size
+		}
+		return p
+	}
+	if p < 0 {
+		return 0
+	}
+	if p >= size {
+		return size - 1
+	}
+	return p
+}
@@ -0,0 +1,64 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package materials
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestSampler_Texels(t *testing.T) {
+	bounds := image.Rect(10, 20, 12, 22)
+	tests := []struct {
+		name string
+		s    Sampler
+		u, v float64
+		want [4]Texel
+	}{
+		{"empty", Sampler{}, 0.5, 0.5, [4]Texel{}},
+		{"nearest", Sampler{Bounds: bounds, Filter: TextureFilterNearest}, 0.75, 0.25, [4]Texel{{X: 11, Y: 21, Weight: 1}}},
+		{"nearest-wrap", Sampler{Bounds: bounds, Filter: TextureFilterNearest}, -0.25, 1.75, [4]Texel{{X: 11, Y: 20, Weight: 1}}},
+		{"linear", Sampler{Bounds: bounds}, 0.5, 0.5, [4]Texel{
+			{X: 10, Y: 20, Weight: 0.25}, {X: 11, Y: 20, Weight: 0.25}, {X: 10, Y: 21, Weight: 0.25}, {X: 11, Y: 21, Weight: 0.25},
+		}},
+		{"linear-clamp", Sampler{Bounds: bounds, TileStyleU: TileClamp, TileStyleV: TileNone}, 1.5, -0.5, [4]Texel{
+			{X: 11, Y: 21, Weight: 0.25}, {X: 11, Y: 21, Weight: 0.25}, {X: 11, Y: 21, Weight: 0.25}, {X: 11, Y: 21, Weight: 0.25},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(tt.s.Texels(tt.u, tt.v), tt.want); diff != nil {
+				t.Errorf("Sampler.Texels() = %v", diff)
+			}
+		})
+	}
+}
+
+func Test_tile(t *testing.T) {
+	tests := []struct {
+		name  string
+		t     float64
+		style TileStyle
+		want  float64
+	}{
+		{"wrap", 1.25, TileWrap, 0.25},
+		{"wrap-negative", -0.25, TileWrap, 0.75},
+		{"mirror", 1.25, TileMirror, 0.75},
+		{"mirror-negative", -0.25, TileMirror, 0.25},
+		{"clamp", 1.25, TileClamp, 1},
+		{"clamp-negative", -0.25, TileClamp, 0},
+		{"none", 1.25, TileNone, 1},
+		{"none-negative", -0.25, TileNone, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tile(tt.t, tt.style); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("tile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package materials
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/MosaicManufacturing/go3mf"
+)
+
+// ErrTextureFormat is returned when the texture image is neither PNG nor JPEG.
+var ErrTextureFormat = errors.New("texture MUST be a PNG or JPEG image")
+
+// AddTexture2D encodes img with the contentType format and adds
+// it to m as with AddTexture2DFromBytes.
+func AddTexture2D(m *go3mf.Model, img image.Image, contentType Texture2DType) (*Texture2D, error) {
+	var buf bytes.Buffer
+	var err error
+	switch contentType {
+	case TextureTypePNG:
+		err = png.Encode(&buf, img)
+	case TextureTypeJPEG:
+		err = jpeg.Encode(&buf, img, nil)
+	default:
+		return nil, ErrTextureFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return AddTexture2DFromBytes(m, buf.Bytes())
+}
+
+// AddTexture2DFromBytes adds data, a PNG or JPEG encoded image, to m as
+// an attachment located under go3mf.Default3DTexturesDir, together with
+// its relationship from the root model, and appends to the root resources
+// a new Texture2D that references it.
+// The materials extension is added to m if not already present.
+func AddTexture2DFromBytes(m *go3mf.Model, data []byte) (*Texture2D, error) {
+	_, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var contentType Texture2DType
+	switch format {
+	case "png":
+		contentType = TextureTypePNG
+	case "jpeg":
+		contentType = TextureTypeJPEG
+	default:
+		return nil, ErrTextureFormat
+	}
+	t := &Texture2D{ID: m.Resources.UnusedID(), ContentType: contentType}
+	t.Path = texturePath(m, t.ID, format)
+	m.Attachments = append(m.Attachments, go3mf.Attachment{
+		Path:        t.Path,
+		ContentType: contentType.String(),
+		Stream:      bytes.NewBuffer(data),
+	})
+	m.Relationships = append(m.Relationships, go3mf.Relationship{Path: t.Path, Type: RelTypeTexture3D})
+	m.Resources.Assets = append(m.Resources.Assets, t)
+	var hasExt bool
+	for _, ext := range m.Extensions {
+		if ext.Namespace == Namespace {
+			hasExt = true
+			break
+		}
+	}
+	if !hasExt {
+		m.Extensions = append(m.Extensions, DefaultExtension)
+	}
+	return t, nil
+}
+
+// Image decodes the attachment of m referenced by t.
+func (t *Texture2D) Image(m *go3mf.Model) (image.Image, error) {
+	att, ok := m.FindAttachment(t.Path)
+	if !ok || att.Stream == nil {
+		return nil, ErrMissingTexturePart
+	}
+	data, err := att.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// texturePath returns an unused attachment path for the texture id.
+func texturePath(m *go3mf.Model, id uint32, ext string) string {
+	path := fmt.Sprintf("%stexture_%d.%s", go3mf.Default3DTexturesDir, id, ext)
+	for i := 1; ; i++ {
+		if _, ok := m.FindAttachment(path); !ok {
+			return path
+		}
+		path = fmt.Sprintf("%stexture_%d_%d.%s", go3mf.Default3DTexturesDir, id, i, ext)
+	}
+}
@@ -0,0 +1,96 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package materials
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+)
+
+func TestAddTexture2D(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(1, 0, color.NRGBA{R: 255, A: 255})
+	tests := []struct {
+		name        string
+		contentType Texture2DType
+		wantPath    string
+		wantErr     error
+	}{
+		{"png", TextureTypePNG, "/3D/Textures/texture_2.png", nil},
+		{"jpeg", TextureTypeJPEG, "/3D/Textures/texture_2.jpeg", nil},
+		{"unknown", 0, "", ErrTextureFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{&ColorGroup{ID: 1, Colors: []color.RGBA{{A: 255}}}}}}
+			tex, err := AddTexture2D(m, img, tt.contentType)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddTexture2D() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tex.ID != 2 || tex.Path != tt.wantPath || tex.ContentType != tt.contentType {
+				t.Errorf("AddTexture2D() = %v", tex)
+			}
+			if len(m.Resources.Assets) != 2 || m.Resources.Assets[1] != tex {
+				t.Errorf("AddTexture2D() resources = %v", m.Resources.Assets)
+			}
+			if att, ok := m.FindAttachment(tt.wantPath); !ok || att.ContentType != tt.contentType.String() {
+				t.Errorf("AddTexture2D() attachments = %v", m.Attachments)
+			}
+			if len(m.Extensions) != 1 || m.Extensions[0].Namespace != Namespace {
+				t.Errorf("AddTexture2D() extensions = %v", m.Extensions)
+			}
+			if len(m.Relationships) != 1 || m.Relationships[0].Path != tt.wantPath || m.Relationships[0].Type != RelTypeTexture3D {
+				t.Errorf("AddTexture2D() relationships = %v", m.Relationships)
+			}
+			if err := m.Validate(); err != nil {
+				t.Errorf("AddTexture2D() produced an invalid model: %v", err)
+			}
+			got, err := tex.Image(m)
+			if err != nil {
+				t.Fatalf("Texture2D.Image() error = %v", err)
+			}
+			if got.Bounds() != img.Bounds() {
+				t.Errorf("Texture2D.Image() bounds = %v, want %v", got.Bounds(), img.Bounds())
+			}
+			if tt.contentType == TextureTypePNG {
+				if r, _, _, _ := got.At(1, 0).RGBA(); r != 0xffff {
+					t.Errorf("Texture2D.Image() red = %d", r)
+				}
+			}
+		})
+	}
+}
+
+func TestAddTexture2DFromBytes(t *testing.T) {
+	m := &go3mf.Model{Attachments: []go3mf.Attachment{{Path: "/3D/Textures/texture_1.png"}}}
+	if _, err := AddTexture2DFromBytes(m, []byte("not an image")); err == nil {
+		t.Errorf("AddTexture2DFromBytes() expected error")
+	}
+	tex, err := AddTexture2D(m, image.NewGray(image.Rect(0, 0, 1, 1)), TextureTypePNG)
+	if err != nil {
+		t.Fatalf("AddTexture2D() error = %v", err)
+	}
+	if want := "/3D/Textures/texture_1_1.png"; tex.Path != want {
+		t.Errorf("AddTexture2D() path = %s, want %s", tex.Path, want)
+	}
+}
+
+func TestTexture2D_Image(t *testing.T) {
+	tex := &Texture2D{ID: 1, Path: "/3D/Textures/a.png"}
+	if _, err := tex.Image(new(go3mf.Model)); !errors.Is(err, ErrMissingTexturePart) {
+		t.Errorf("Texture2D.Image() error = %v, want %v", err, ErrMissingTexturePart)
+	}
+	m := &go3mf.Model{Attachments: []go3mf.Attachment{{Path: "/3D/Textures/a.png", Stream: bytes.NewBufferString("a")}}}
+	if _, err := tex.Image(m); err == nil {
+		t.Errorf("Texture2D.Image() expected error")
+	}
+}
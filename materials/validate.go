@@ -5,7 +5,6 @@ package materials
 
 import (
 	"image/color"
-	"strings"
 
 	"github.com/MosaicManufacturing/go3mf"
 	"github.com/MosaicManufacturing/go3mf/errors"
@@ -20,8 +19,18 @@ func (Spec) Validate(model interface{}, path string, asset interface{}) error {
 
 func validateAsset(m *go3mf.Model, path string, r go3mf.Asset) (errs error) {
 	switch r := r.(type) {
+	case *go3mf.BaseMaterials:
+		if attr := GetBaseMaterialsAttr(r); attr != nil && attr.DisplayPropertiesID != 0 {
+			errs = validateDisplayPropsRef(m, path, attr.DisplayPropertiesID, len(r.Materials), func(a go3mf.Asset) bool {
+				switch a.(type) {
+				case *PBSpecularDisplayProperties, *PBMetallicDisplayProperties, *TranslucentDisplayProperties:
+					return true
+				}
+				return false
+			})
+		}
 	case *ColorGroup:
-		errs = validateColorGroup(path, r)
+		errs = validateColorGroup(m, path, r)
 	case *Texture2DGroup:
 		errs = validateTexture2DGroup(m, path, r)
 	case *Texture2D:
@@ -30,11 +39,21 @@ func validateAsset(m *go3mf.Model, path string, r go3mf.Asset) (errs error) {
 		errs = validateMultiProps(m, path, r)
 	case *CompositeMaterials:
 		errs = validateCompositeMat(m, path, r)
+	case *PBSpecularDisplayProperties:
+		errs = validatePBSpecular(r)
+	case *PBSpecularTextureDisplayProperties:
+		errs = validatePBSpecularTexture(m, path, r)
+	case *PBMetallicDisplayProperties:
+		errs = validatePBMetallic(r)
+	case *PBMetallicTextureDisplayProperties:
+		errs = validatePBMetallicTexture(m, path, r)
+	case *TranslucentDisplayProperties:
+		errs = validateTranslucent(r)
 	}
 	return
 }
 
-func validateColorGroup(path string, r *ColorGroup) (errs error) {
+func validateColorGroup(m *go3mf.Model, path string, r *ColorGroup) (errs error) {
 	if r.ID == 0 {
 		errs = errors.Append(errs, errors.ErrMissingID)
 	}
@@ -46,6 +65,15 @@ func validateColorGroup(path string, r *ColorGroup) (errs error) {
 			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrColor), c, j))
 		}
 	}
+	if r.DisplayPropertiesID != 0 {
+		errs = errors.Append(errs, validateDisplayPropsRef(m, path, r.DisplayPropertiesID, len(r.Colors), func(a go3mf.Asset) bool {
+			switch a.(type) {
+			case *PBSpecularDisplayProperties, *PBMetallicDisplayProperties:
+				return true
+			}
+			return false
+		}))
+	}
 	return
 }
 
@@ -65,6 +93,15 @@ func validateTexture2DGroup(m *go3mf.Model, path string, r *Texture2DGroup) (err
 	if len(r.Coords) == 0 {
 		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
 	}
+	if r.DisplayPropertiesID != 0 {
+		errs = errors.Append(errs, validateDisplayPropsRef(m, path, r.DisplayPropertiesID, -1, func(a go3mf.Asset) bool {
+			switch a.(type) {
+			case *PBSpecularTextureDisplayProperties, *PBMetallicTextureDisplayProperties:
+				return true
+			}
+			return false
+		}))
+	}
 	return
 }
 
@@ -74,17 +111,8 @@ func validateTexture2D(m *go3mf.Model, path string, r *Texture2D) (errs error) {
 	}
 	if r.Path == "" {
 		errs = errors.Append(errs, errors.NewMissingFieldError(attrPath))
-	} else {
-		var hasTexture bool
-		for _, a := range m.Attachments {
-			if strings.EqualFold(a.Path, r.Path) {
-				hasTexture = true
-				break
-			}
-		}
-		if !hasTexture {
-			errs = errors.Append(errs, ErrMissingTexturePart)
-		}
+	} else if _, ok := m.FindAttachment(r.Path); !ok {
+		errs = errors.Append(errs, ErrMissingTexturePart)
 	}
 	if r.ContentType == 0 {
 		errs = errors.Append(errs, errors.NewMissingFieldError(attrContentType))
@@ -176,3 +204,121 @@ func validateCompositeMat(m *go3mf.Model, path string, r *CompositeMaterials) (e
 	}
 	return
 }
+
+// validateDisplayPropsRef checks that id references a display properties resource
+// accepted by compatible and, if count is not negative, that it defines count elements.
+func validateDisplayPropsRef(m *go3mf.Model, path string, id uint32, count int, compatible func(go3mf.Asset) bool) error {
+	a, ok := m.FindAsset(path, id)
+	if !ok {
+		return errors.ErrMissingResource
+	}
+	if !compatible(a) {
+		return ErrDisplayPropsRef
+	}
+	if count >= 0 {
+		if a, ok := a.(interface{ Len() int }); ok && a.Len() != count {
+			return ErrDisplayPropsCount
+		}
+	}
+	return nil
+}
+
+func validateTextureRef(m *go3mf.Model, path string, id uint32, name string) error {
+	if id == 0 {
+		return errors.NewMissingFieldError(name)
+	}
+	if text, ok := m.FindAsset(path, id); ok {
+		if _, ok := text.(*Texture2D); ok {
+			return nil
+		}
+	}
+	return ErrTextureReference
+}
+
+func inUnitRange(v float32) bool {
+	return v >= 0 && v <= 1
+}
+
+func validatePBSpecular(r *PBSpecularDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Specular) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for j, s := range r.Specular {
+		if s.Name == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrName), s, j))
+		}
+		if !inUnitRange(s.Glossiness) {
+			errs = errors.Append(errs, errors.WrapIndex(ErrDisplayPropsRange, s, j))
+		}
+	}
+	return
+}
+
+func validatePBSpecularTexture(m *go3mf.Model, path string, r *PBSpecularTextureDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Name == "" {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrName))
+	}
+	errs = errors.Append(errs, validateTextureRef(m, path, r.SpecularTextureID, attrSpecularTextureID))
+	errs = errors.Append(errs, validateTextureRef(m, path, r.GlossinessTextureID, attrGlossinessTexID))
+	if !inUnitRange(r.GlossinessFactor) {
+		errs = errors.Append(errs, ErrDisplayPropsRange)
+	}
+	return
+}
+
+func validatePBMetallic(r *PBMetallicDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Metallic) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for j, s := range r.Metallic {
+		if s.Name == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrName), s, j))
+		}
+		if !inUnitRange(s.Metallicness) || !inUnitRange(s.Roughness) {
+			errs = errors.Append(errs, errors.WrapIndex(ErrDisplayPropsRange, s, j))
+		}
+	}
+	return
+}
+
+func validatePBMetallicTexture(m *go3mf.Model, path string, r *PBMetallicTextureDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Name == "" {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrName))
+	}
+	errs = errors.Append(errs, validateTextureRef(m, path, r.MetallicTextureID, attrMetallicTextureID))
+	errs = errors.Append(errs, validateTextureRef(m, path, r.RoughnessTextureID, attrRoughnessTexID))
+	if !inUnitRange(r.MetallicFactor) || !inUnitRange(r.RoughnessFactor) {
+		errs = errors.Append(errs, ErrDisplayPropsRange)
+	}
+	return
+}
+
+func validateTranslucent(r *TranslucentDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Translucents) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for j, s := range r.Translucents {
+		if s.Name == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrName), s, j))
+		}
+		if !inUnitRange(s.Roughness) {
+			errs = errors.Append(errs, errors.WrapIndex(ErrDisplayPropsRange, s, j))
+		}
+	}
+	return
+}
@@ -112,6 +112,45 @@ func TestValidate(t *testing.T) {
 			fmt.Sprintf("Resources@CompositeMaterials#4: %v", ErrCompositeBase),
 			fmt.Sprintf("Resources@CompositeMaterials#5: %v", errors.ErrMissingResource),
 		}},
+		{"displayProperties", &go3mf.Model{
+			Attachments: []go3mf.Attachment{{Path: "/a.png"}},
+			Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&Texture2D{ID: 1, ContentType: TextureTypePNG, Path: "/a.png"},
+				&PBSpecularDisplayProperties{ID: 2},
+				&PBSpecularDisplayProperties{ID: 3, Specular: []PBSpecular{{Name: "a"}, {Glossiness: 2}}},
+				&PBMetallicDisplayProperties{ID: 4, Metallic: []PBMetallic{{Name: "a", Roughness: 1}, {Name: "b", Metallicness: -1}}},
+				&TranslucentDisplayProperties{ID: 5, Translucents: []Translucent{{Name: "a", Roughness: 1.5}}},
+				&PBSpecularTextureDisplayProperties{ID: 6, SpecularTextureID: 1, GlossinessTextureID: 100, GlossinessFactor: 1},
+				&PBMetallicTextureDisplayProperties{ID: 7, Name: "a", RoughnessTextureID: 3, MetallicFactor: 2, RoughnessFactor: 1},
+				&ColorGroup{ID: 8, DisplayPropertiesID: 4, Colors: []color.RGBA{{R: 1}, {G: 1}}},
+				&ColorGroup{ID: 9, DisplayPropertiesID: 3, Colors: []color.RGBA{{R: 1}}},
+				&ColorGroup{ID: 10, DisplayPropertiesID: 5, Colors: []color.RGBA{{R: 1}}},
+				&Texture2DGroup{ID: 11, TextureID: 1, DisplayPropertiesID: 6, Coords: []TextureCoord{{}}},
+				&Texture2DGroup{ID: 12, TextureID: 1, DisplayPropertiesID: 4, Coords: []TextureCoord{{}}},
+				&go3mf.BaseMaterials{ID: 13, Materials: []go3mf.Base{{Name: "a", Color: color.RGBA{R: 1}}},
+					AnyAttr: go3mf.AnyAttr{&BaseMaterialsAttr{DisplayPropertiesID: 5}}},
+				&go3mf.BaseMaterials{ID: 14, Materials: []go3mf.Base{{Name: "a", Color: color.RGBA{R: 1}}},
+					AnyAttr: go3mf.AnyAttr{&BaseMaterialsAttr{DisplayPropertiesID: 100}}},
+				&go3mf.BaseMaterials{ID: 15, Materials: []go3mf.Base{{Name: "a", Color: color.RGBA{R: 1}}},
+					AnyAttr: go3mf.AnyAttr{&BaseMaterialsAttr{DisplayPropertiesID: 7}}},
+			}},
+		}, []string{
+			fmt.Sprintf("Resources@PBSpecularDisplayProperties#1: %v", errors.ErrEmptyResourceProps),
+			fmt.Sprintf("Resources@PBSpecularDisplayProperties#2@PBSpecular#1: %v", &errors.MissingFieldError{Name: attrName}),
+			fmt.Sprintf("Resources@PBSpecularDisplayProperties#2@PBSpecular#1: %v", ErrDisplayPropsRange),
+			fmt.Sprintf("Resources@PBMetallicDisplayProperties#3@PBMetallic#1: %v", ErrDisplayPropsRange),
+			fmt.Sprintf("Resources@TranslucentDisplayProperties#4@Translucent#0: %v", ErrDisplayPropsRange),
+			fmt.Sprintf("Resources@PBSpecularTextureDisplayProperties#5: %v", &errors.MissingFieldError{Name: attrName}),
+			fmt.Sprintf("Resources@PBSpecularTextureDisplayProperties#5: %v", ErrTextureReference),
+			fmt.Sprintf("Resources@PBMetallicTextureDisplayProperties#6: %v", &errors.MissingFieldError{Name: attrMetallicTextureID}),
+			fmt.Sprintf("Resources@PBMetallicTextureDisplayProperties#6: %v", ErrTextureReference),
+			fmt.Sprintf("Resources@PBMetallicTextureDisplayProperties#6: %v", ErrDisplayPropsRange),
+			fmt.Sprintf("Resources@ColorGroup#8: %v", ErrDisplayPropsCount),
+			fmt.Sprintf("Resources@ColorGroup#9: %v", ErrDisplayPropsRef),
+			fmt.Sprintf("Resources@Texture2DGroup#11: %v", ErrDisplayPropsRef),
+			fmt.Sprintf("Resources@BaseMaterials#13: %v", errors.ErrMissingResource),
+			fmt.Sprintf("Resources@BaseMaterials#14: %v", ErrDisplayPropsRef),
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
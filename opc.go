@@ -118,6 +118,14 @@ func (o *opcReader) FindFileFromName(name string) (packageFile, bool) {
 	return findOPCFileFromName(name, o.r)
 }
 
+func (o *opcReader) Files() []packageFile {
+	files := make([]packageFile, len(o.r.Files))
+	for i, f := range o.r.Files {
+		files[i] = &opcFile{o.r, f}
+	}
+	return files
+}
+
 func resolveRelationship(source, rel string) string {
 	return opc.ResolveRelationship(source, rel)
 }
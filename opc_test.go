@@ -96,6 +96,14 @@ func Test_opcReader_FindFileFromName(t *testing.T) {
 	}
 }
 
+func Test_opcReader_Files(t *testing.T) {
+	reader := &opc.Reader{Files: []*opc.File{{Part: &opc.Part{Name: "/a.xml"}}, {Part: &opc.Part{Name: "/b.xml"}}}}
+	want := []packageFile{&opcFile{reader, reader.Files[0]}, &opcFile{reader, reader.Files[1]}}
+	if got := (&opcReader{nil, 0, reader}).Files(); !reflect.DeepEqual(got, want) {
+		t.Errorf("opcReader.Files() = %v, want %v", got, want)
+	}
+}
+
 func Test_opcFile_ContentType(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,237 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package painting
+
+import (
+	"encoding/xml"
+	"sort"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/spec"
+)
+
+// Read returns the painted triangles of the mesh, indexed by triangle,
+// decoded from either AttrPrusa or AttrBambu.
+func Read(mesh *go3mf.Mesh) (map[uint32]Tree, error) {
+	var errs error
+	painting := make(map[uint32]Tree)
+	for _, i := range sortedIndices(mesh) {
+		ua := mesh.TriangleAnyAttr[i].GetUnknownAttr()
+		if ua == nil {
+			continue
+		}
+		for _, a := range *ua {
+			if a.Name != AttrPrusa && a.Name != AttrBambu {
+				continue
+			}
+			var t Tree
+			if err := t.UnmarshalText([]byte(a.Value)); err != nil {
+				errs = errors.Append(errs, errors.WrapIndex(err, mesh.Triangles[i], int(i)))
+			} else if !t.IsEmpty() {
+				painting[i] = t
+			}
+			break
+		}
+	}
+	return painting, errs
+}
+
+// Write replaces the painting of the mesh triangles, storing
+// it in the attribute name, either AttrPrusa or AttrBambu.
+// Unpainted trees are not written.
+func Write(mesh *go3mf.Mesh, name xml.Name, painting map[uint32]Tree) error {
+	removePainting(mesh)
+	var errs error
+	for _, i := range sortedPainting(painting) {
+		t := painting[i]
+		if t.IsEmpty() {
+			continue
+		}
+		if int(i) >= len(mesh.Triangles) {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, t, int(i)))
+			continue
+		}
+		text, err := t.MarshalText()
+		if err != nil {
+			errs = errors.Append(errs, errors.WrapIndex(err, mesh.Triangles[i], int(i)))
+			continue
+		}
+		if mesh.TriangleAnyAttr == nil {
+			mesh.TriangleAnyAttr = make(map[uint32]go3mf.AnyAttr)
+		}
+		any := mesh.TriangleAnyAttr[i]
+		any.AddUnknownAttr(spec.Attr{Name: name, Value: text})
+		mesh.TriangleAnyAttr[i] = any
+	}
+	return errs
+}
+
+// ToProperties assigns the painted extruders to the mesh triangles as references
+// to the property group pid, extruder n being the property index n-1.
+// Triangles painted with a split tree are subdivided: the first leaf replaces
+// the triangle and the others are appended to the mesh, so the existing
+// triangle indices remain valid. Unpainted regions keep the triangle properties.
+// The painting is removed from the mesh afterwards.
+func ToProperties(mesh *go3mf.Mesh, pid uint32) error {
+	painting, err := Read(mesh)
+	if err != nil {
+		return err
+	}
+	midpoints := make(map[[2]uint32]uint32)
+	mid := func(a, b uint32) uint32 {
+		if a > b {
+			a, b = b, a
+		}
+		if v, ok := midpoints[[2]uint32{a, b}]; ok {
+			return v
+		}
+		va, vb := mesh.Vertices[a], mesh.Vertices[b]
+		mesh.Vertices = append(mesh.Vertices, go3mf.Point3D{
+			(va.X() + vb.X()) / 2, (va.Y() + vb.Y()) / 2, (va.Z() + vb.Z()) / 2,
+		})
+		v := uint32(len(mesh.Vertices) - 1)
+		midpoints[[2]uint32{a, b}] = v
+		return v
+	}
+	nvertices := uint32(len(mesh.Vertices))
+	for _, i := range sortedPainting(painting) {
+		t := painting[i]
+		src := mesh.Triangles[i]
+		if src.V1 >= nvertices || src.V2 >= nvertices || src.V3 >= nvertices {
+			return errors.WrapIndex(errors.ErrIndexOutOfBounds, src, int(i))
+		}
+		first := true
+		err := t.leaves([3]uint32{src.V1, src.V2, src.V3}, mid, func(v [3]uint32, s State) {
+			tri := src
+			tri.V1, tri.V2, tri.V3 = v[0], v[1], v[2]
+			if s != StateNone {
+				p := uint32(s) - 1
+				tri.PID, tri.P1, tri.P2, tri.P3 = pid, p, p, p
+			}
+			if first {
+				mesh.Triangles[i] = tri
+				first = false
+			} else {
+				mesh.Triangles = append(mesh.Triangles, tri)
+			}
+		})
+		if err != nil {
+			return errors.WrapIndex(err, src, int(i))
+		}
+	}
+	removePainting(mesh)
+	return nil
+}
+
+// FromProperties returns the painting of the triangles that reference
+// a single index of the property group pid, the inverse of ToProperties.
+func FromProperties(mesh *go3mf.Mesh, pid uint32) map[uint32]Tree {
+	painting := make(map[uint32]Tree)
+	for i, t := range mesh.Triangles {
+		if t.PID != pid || t.P1 != t.P2 || t.P1 != t.P3 || t.P1 >= uint32(MaxState) {
+			continue
+		}
+		painting[uint32(i)] = Tree{State: State(t.P1 + 1)}
+	}
+	return painting
+}
+
+// Walk calls fn for each leaf of the tree painted on the triangle (v1, v2, v3).
+func (t *Tree) Walk(v1, v2, v3 go3mf.Point3D, fn func(v1, v2, v3 go3mf.Point3D, s State)) error {
+	points := []go3mf.Point3D{v1, v2, v3}
+	mid := func(a, b uint32) uint32 {
+		va, vb := points[a], points[b]
+		points = append(points, go3mf.Point3D{
+			(va.X() + vb.X()) / 2, (va.Y() + vb.Y()) / 2, (va.Z() + vb.Z()) / 2,
+		})
+		return uint32(len(points) - 1)
+	}
+	return t.leaves([3]uint32{0, 1, 2}, mid, func(v [3]uint32, s State) {
+		fn(points[v[0]], points[v[1]], points[v[2]], s)
+	})
+}
+
+// leaves splits the triangle v following the PrusaSlicer TriangleSelector
+// conventions, where side i is the side opposite to vertex i.
+func (t *Tree) leaves(v [3]uint32, mid func(a, b uint32) uint32, fn func(v [3]uint32, s State)) error {
+	if t.SpecialSide > 2 {
+		return ErrInvalidTree
+	}
+	i := uint32(t.SpecialSide)
+	n, p := (i+1)%3, (i+2)%3
+	var children [][3]uint32
+	switch len(t.Children) {
+	case 0:
+		fn(v, t.State)
+		return nil
+	case 2:
+		m := mid(v[n], v[p])
+		children = [][3]uint32{{v[i], v[n], m}, {m, v[p], v[i]}}
+	case 3:
+		m1, m2 := mid(v[i], v[n]), mid(v[p], v[i])
+		children = [][3]uint32{{v[i], m1, m2}, {m1, v[n], m2}, {v[n], v[p], m2}}
+	case 4:
+		m01, m12, m20 := mid(v[0], v[1]), mid(v[1], v[2]), mid(v[2], v[0])
+		children = [][3]uint32{{v[0], m01, m20}, {m01, v[1], m12}, {m12, v[2], m20}, {m01, m12, m20}}
+	default:
+		return ErrInvalidTree
+	}
+	for j := range t.Children {
+		if err := t.Children[j].leaves(children[j], mid, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removePainting removes the painting attributes from the mesh triangles.
+func removePainting(mesh *go3mf.Mesh) {
+	for i, any := range mesh.TriangleAnyAttr {
+		ua := any.GetUnknownAttr()
+		if ua == nil {
+			continue
+		}
+		attrs := (*ua)[:0]
+		for _, a := range *ua {
+			if a.Name != AttrPrusa && a.Name != AttrBambu {
+				attrs = append(attrs, a)
+			}
+		}
+		*ua = attrs
+		if len(attrs) == 0 {
+			var rest go3mf.AnyAttr
+			for _, a := range any {
+				if a != ua {
+					rest = append(rest, a)
+				}
+			}
+			if len(rest) == 0 {
+				delete(mesh.TriangleAnyAttr, i)
+			} else {
+				mesh.TriangleAnyAttr[i] = rest
+			}
+		}
+	}
+}
+
+func sortedIndices(mesh *go3mf.Mesh) []uint32 {
+	indices := make([]uint32, 0, len(mesh.TriangleAnyAttr))
+	for i := range mesh.TriangleAnyAttr {
+		if int(i) < len(mesh.Triangles) {
+			indices = append(indices, i)
+		}
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
+
+func sortedPainting(painting map[uint32]Tree) []uint32 {
+	indices := make([]uint32, 0, len(painting))
+	for i := range painting {
+		indices = append(indices, i)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
@@ -0,0 +1,254 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package painting
+
+import (
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/spec"
+	"github.com/go-test/deep"
+)
+
+var fooName = xml.Name{Space: "http://www.foo.com", Local: "foo"}
+
+func paintedMesh() *go3mf.Mesh {
+	return &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {2, 0, 0}, {0, 2, 0}, {2, 2, 0}},
+		Triangles: []go3mf.Triangle{
+			{V1: 0, V2: 1, V3: 2, PID: 5},
+			{V1: 1, V2: 3, V3: 2},
+			{V1: 0, V2: 2, V3: 1},
+		},
+		TriangleAnyAttr: map[uint32]go3mf.AnyAttr{
+			0: {&spec.UnknownAttrs{{Name: AttrPrusa, Value: "481"}, {Name: fooName, Value: "bar"}}},
+			1: {&spec.UnknownAttrs{{Name: AttrBambu, Value: "8"}}},
+			2: {&spec.UnknownAttrs{{Name: AttrBambu, Value: "0"}}},
+		},
+	}
+}
+
+func TestRead(t *testing.T) {
+	got, err := Read(paintedMesh())
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := map[uint32]Tree{
+		0: {Children: []Tree{{State: 1}, {State: 2}}},
+		1: {State: 2},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Read() = %v", diff)
+	}
+}
+
+func TestRead_error(t *testing.T) {
+	mesh := paintedMesh()
+	mesh.TriangleAnyAttr[1] = go3mf.AnyAttr{&spec.UnknownAttrs{{Name: AttrBambu, Value: "X"}}}
+	_, err := Read(mesh)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	var errs []string
+	for _, err := range err.(*errors.List).Errors {
+		errs = append(errs, err.Error())
+	}
+	want := []string{fmt.Sprintf("Triangle#1: %v", ErrInvalidTree)}
+	if diff := deep.Equal(errs, want); diff != nil {
+		t.Errorf("Read() = %v", diff)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	mesh := paintedMesh()
+	err := Write(mesh, AttrPrusa, map[uint32]Tree{
+		1: {State: 3},
+		2: {},
+	})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	want := map[uint32]go3mf.AnyAttr{
+		0: {&spec.UnknownAttrs{{Name: fooName, Value: "bar"}}},
+		1: {&spec.UnknownAttrs{{Name: AttrPrusa, Value: "0C"}}},
+	}
+	if diff := deep.Equal(mesh.TriangleAnyAttr, want); diff != nil {
+		t.Errorf("Write() = %v", diff)
+	}
+}
+
+func TestWrite_error(t *testing.T) {
+	mesh := paintedMesh()
+	err := Write(mesh, AttrBambu, map[uint32]Tree{
+		0: {State: MaxState + 1},
+		5: {State: 1},
+	})
+	var errs []string
+	for _, err := range err.(*errors.List).Errors {
+		errs = append(errs, err.Error())
+	}
+	want := []string{
+		fmt.Sprintf("Triangle#0: %v", ErrMaxState),
+		fmt.Sprintf("Tree#5: %v", errors.ErrIndexOutOfBounds),
+	}
+	if diff := deep.Equal(errs, want); diff != nil {
+		t.Errorf("Write() = %v", diff)
+	}
+}
+
+func TestToProperties(t *testing.T) {
+	mesh := paintedMesh()
+	if err := ToProperties(mesh, 10); err != nil {
+		t.Fatalf("ToProperties() error = %v", err)
+	}
+	want := &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {2, 0, 0}, {0, 2, 0}, {2, 2, 0}, {1, 1, 0}},
+		Triangles: []go3mf.Triangle{
+			{V1: 0, V2: 1, V3: 4, PID: 10},
+			{V1: 1, V2: 3, V3: 2, PID: 10, P1: 1, P2: 1, P3: 1},
+			{V1: 0, V2: 2, V3: 1},
+			{V1: 4, V2: 2, V3: 0, PID: 10, P1: 1, P2: 1, P3: 1},
+		},
+		TriangleAnyAttr: map[uint32]go3mf.AnyAttr{
+			0: {&spec.UnknownAttrs{{Name: fooName, Value: "bar"}}},
+		},
+	}
+	if diff := deep.Equal(mesh, want); diff != nil {
+		t.Errorf("ToProperties() = %v", diff)
+	}
+}
+
+func TestToProperties_sharedMidpoint(t *testing.T) {
+	mesh := &go3mf.Mesh{
+		Vertices:  []go3mf.Point3D{{0, 0, 0}, {2, 0, 0}, {0, 2, 0}, {2, 2, 0}},
+		Triangles: []go3mf.Triangle{{V1: 0, V2: 1, V3: 2}, {V1: 3, V2: 2, V3: 1}},
+		TriangleAnyAttr: map[uint32]go3mf.AnyAttr{
+			0: {&spec.UnknownAttrs{{Name: AttrBambu, Value: "481"}}},
+			1: {&spec.UnknownAttrs{{Name: AttrBambu, Value: "481"}}},
+		},
+	}
+	if err := ToProperties(mesh, 10); err != nil {
+		t.Fatalf("ToProperties() error = %v", err)
+	}
+	if len(mesh.Vertices) != 5 {
+		t.Errorf("ToProperties() vertices = %d, want 5", len(mesh.Vertices))
+	}
+	if len(mesh.Triangles) != 4 {
+		t.Errorf("ToProperties() triangles = %d, want 4", len(mesh.Triangles))
+	}
+	if mesh.TriangleAnyAttr != nil && len(mesh.TriangleAnyAttr) != 0 {
+		t.Errorf("ToProperties() painting not removed = %v", mesh.TriangleAnyAttr)
+	}
+}
+
+func TestFromProperties(t *testing.T) {
+	mesh := &go3mf.Mesh{Triangles: []go3mf.Triangle{
+		{PID: 10, P1: 1, P2: 1, P3: 1},
+		{PID: 10, P1: 0, P2: 1, P3: 1},
+		{PID: 5},
+		{PID: 10},
+		{PID: 10, P1: 100, P2: 100, P3: 100},
+	}}
+	want := map[uint32]Tree{
+		0: {State: 2},
+		3: {State: 1},
+	}
+	if diff := deep.Equal(FromProperties(mesh, 10), want); diff != nil {
+		t.Errorf("FromProperties() = %v", diff)
+	}
+}
+
+func TestTree_Walk(t *testing.T) {
+	type leaf struct {
+		v1, v2, v3 go3mf.Point3D
+		s          State
+	}
+	tests := []struct {
+		name string
+		tree Tree
+		want []leaf
+	}{
+		{"leaf", Tree{State: 1}, []leaf{{go3mf.Point3D{0, 0, 0}, go3mf.Point3D{4, 0, 0}, go3mf.Point3D{0, 4, 0}, 1}}},
+		{"split1", Tree{SpecialSide: 1, Children: []Tree{{State: 1}, {State: 2}}}, []leaf{
+			{go3mf.Point3D{4, 0, 0}, go3mf.Point3D{0, 4, 0}, go3mf.Point3D{0, 2, 0}, 1},
+			{go3mf.Point3D{0, 2, 0}, go3mf.Point3D{0, 0, 0}, go3mf.Point3D{4, 0, 0}, 2},
+		}},
+		{"split2", Tree{Children: []Tree{{State: 1}, {State: 2}, {State: 3}}}, []leaf{
+			{go3mf.Point3D{0, 0, 0}, go3mf.Point3D{2, 0, 0}, go3mf.Point3D{0, 2, 0}, 1},
+			{go3mf.Point3D{2, 0, 0}, go3mf.Point3D{4, 0, 0}, go3mf.Point3D{0, 2, 0}, 2},
+			{go3mf.Point3D{4, 0, 0}, go3mf.Point3D{0, 4, 0}, go3mf.Point3D{0, 2, 0}, 3},
+		}},
+		{"split3", Tree{Children: []Tree{{State: 1}, {State: 2}, {State: 3}, {State: 4}}}, []leaf{
+			{go3mf.Point3D{0, 0, 0}, go3mf.Point3D{2, 0, 0}, go3mf.Point3D{0, 2, 0}, 1},
+			{go3mf.Point3D{2, 0, 0}, go3mf.Point3D{4, 0, 0}, go3mf.Point3D{2, 2, 0}, 2},
+			{go3mf.Point3D{2, 2, 0}, go3mf.Point3D{0, 4, 0}, go3mf.Point3D{0, 2, 0}, 3},
+			{go3mf.Point3D{2, 0, 0}, go3mf.Point3D{2, 2, 0}, go3mf.Point3D{0, 2, 0}, 4},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []leaf
+			err := tt.tree.Walk(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{4, 0, 0}, go3mf.Point3D{0, 4, 0}, func(v1, v2, v3 go3mf.Point3D, s State) {
+				got = append(got, leaf{v1, v2, v3, s})
+			})
+			if err != nil {
+				t.Fatalf("Tree.Walk() error = %v", err)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("Tree.Walk() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestRead_model(t *testing.T) {
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:slic3rpe="http://schemas.slic3r.org/3mf/2017/06">
+		<resources>
+			<object id="1" type="model">
+				<mesh>
+					<vertices>
+						<vertex x="0" y="0" z="0"/>
+						<vertex x="1" y="0" z="0"/>
+						<vertex x="0" y="1" z="0"/>
+					</vertices>
+					<triangles>
+						<triangle v1="0" v2="1" v3="2" slic3rpe:mmu_segmentation="1C"/>
+						<triangle v1="0" v2="2" v3="1" paint_color="8"/>
+					</triangles>
+				</mesh>
+			</object>
+		</resources>
+		<build/>
+		</model>
+		`
+	m := new(go3mf.Model)
+	m.Path = "/3D/3dmodel.model"
+	if err := go3mf.UnmarshalModel([]byte(rootFile), m); err != nil {
+		t.Fatalf("UnmarshalModel() error = %v", err)
+	}
+	want := map[uint32]Tree{0: {State: 4}, 1: {State: 2}}
+	got, err := Read(m.Resources.Objects[0].Mesh)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Read() = %v", diff)
+	}
+	b, err := go3mf.MarshalModel(m)
+	if err != nil {
+		t.Fatalf("MarshalModel() error = %v", err)
+	}
+	newModel := new(go3mf.Model)
+	newModel.Path = m.Path
+	if err := go3mf.UnmarshalModel(b, newModel); err != nil {
+		t.Fatalf("UnmarshalModel() error = %v, s = %s", err, b)
+	}
+	if diff := deep.Equal(newModel, m); diff != nil {
+		t.Errorf("MarshalModel() = %v, s = %s", diff, b)
+	}
+}
@@ -0,0 +1,210 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+// Package painting decodes and encodes the per-triangle multi-material
+// painting that PrusaSlicer and Bambu Studio store as triangle attributes.
+//
+// Each painted triangle holds a subdivision tree serialized as a hexadecimal
+// bit stream, where every leaf is a sub-triangle assigned to an extruder.
+package painting
+
+import (
+	"encoding/xml"
+	"errors"
+
+	"github.com/MosaicManufacturing/go3mf"
+)
+
+// Namespace is the canonical name of the PrusaSlicer extension.
+const Namespace = "http://schemas.slic3r.org/3mf/2017/06"
+
+// DefaultExtension declares the PrusaSlicer namespace, which must be added
+// to the model extensions when writing AttrPrusa.
+// It is not registered as a spec, as PrusaSlicer uses the namespace
+// for many other attributes that have to be preserved as unknown.
+var DefaultExtension = go3mf.Extension{
+	Namespace:  Namespace,
+	LocalName:  "slic3rpe",
+	IsRequired: false,
+}
+
+// Triangle attributes that store the painting.
+var (
+	AttrPrusa = xml.Name{Space: Namespace, Local: "mmu_segmentation"}
+	AttrBambu = xml.Name{Local: "paint_color"}
+)
+
+var (
+	ErrInvalidTree = errors.New("invalid painting tree")
+	ErrMaxState    = errors.New("painting state exceeds the maximum supported extruder")
+)
+
+// State is the extruder assigned to a painted region, starting at 1.
+// StateNone means the region is not painted and uses the object extruder.
+type State uint8
+
+// StateNone is the state of the regions that are not painted.
+const StateNone State = 0
+
+// MaxState is the highest extruder that can be serialized.
+const MaxState State = 18
+
+// Tree is the subdivision tree of a painted triangle.
+// A leaf has no children and is painted with State.
+// A split node has 2, 3 or 4 children, depending on whether
+// 1, 2 or 3 of its sides are split, and SpecialSide is the
+// split side when only one is split or the side that is not
+// split when two are split, following the PrusaSlicer conventions.
+type Tree struct {
+	State       State
+	SpecialSide uint8
+	Children    []Tree
+}
+
+// IsEmpty returns true if the tree is an unpainted leaf.
+func (t *Tree) IsEmpty() bool {
+	return len(t.Children) == 0 && t.State == StateNone
+}
+
+// MarshalText encodes the tree as a PrusaSlicer hexadecimal bit stream.
+func (t Tree) MarshalText() ([]byte, error) {
+	var w bitWriter
+	if err := w.write(&t); err != nil {
+		return nil, err
+	}
+	return w.text(), nil
+}
+
+// UnmarshalText decodes a PrusaSlicer hexadecimal bit stream.
+func (t *Tree) UnmarshalText(text []byte) error {
+	*t = Tree{}
+	if len(text) == 0 {
+		return nil
+	}
+	r := bitReader{text: text, pos: len(text)}
+	return r.read(t)
+}
+
+// bitWriter packs the bits in nibbles, least significant bit first.
+type bitWriter struct {
+	bits []bool
+}
+
+func (w *bitWriter) put(v uint8, n int) {
+	for i := 0; i < n; i++ {
+		w.bits = append(w.bits, v&(1<<i) != 0)
+	}
+}
+
+func (w *bitWriter) write(t *Tree) error {
+	n := len(t.Children)
+	if n == 0 {
+		if t.State > MaxState {
+			return ErrMaxState
+		}
+		w.put(0, 2)
+		if t.State < 3 {
+			w.put(uint8(t.State), 2)
+		} else {
+			w.put(3, 2)
+			w.put(uint8(t.State-3), 4)
+		}
+		return nil
+	}
+	if n < 2 || n > 4 || t.SpecialSide > 2 {
+		return ErrInvalidTree
+	}
+	w.put(uint8(n-1), 2)
+	w.put(t.SpecialSide, 2)
+	for i := n - 1; i >= 0; i-- {
+		if err := w.write(&t.Children[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// text returns the nibbles as hexadecimal digits, the first nibble being the last digit.
+func (w *bitWriter) text() []byte {
+	out := make([]byte, (len(w.bits)+3)/4)
+	for i := range out {
+		var code uint8
+		for j := 0; j < 4; j++ {
+			if k := i*4 + j; k < len(w.bits) && w.bits[k] {
+				code |= 1 << j
+			}
+		}
+		out[len(out)-1-i] = "0123456789ABCDEF"[code]
+	}
+	return out
+}
+
+// bitReader reads the nibbles from the last hexadecimal digit to the first one.
+type bitReader struct {
+	text   []byte
+	pos    int
+	nibble uint8
+	left   int
+}
+
+func (r *bitReader) get(n int) (uint8, error) {
+	var v uint8
+	for i := 0; i < n; i++ {
+		if r.left == 0 {
+			if r.pos == 0 {
+				return 0, ErrInvalidTree
+			}
+			r.pos--
+			c := r.text[r.pos]
+			switch {
+			case c >= '0' && c <= '9':
+				r.nibble = c - '0'
+			case c >= 'A' && c <= 'F':
+				r.nibble = c - 'A' + 10
+			case c >= 'a' && c <= 'f':
+				r.nibble = c - 'a' + 10
+			default:
+				return 0, ErrInvalidTree
+			}
+			r.left = 4
+		}
+		v |= (r.nibble & 1) << i
+		r.nibble >>= 1
+		r.left--
+	}
+	return v, nil
+}
+
+func (r *bitReader) read(t *Tree) error {
+	split, err := r.get(2)
+	if err != nil {
+		return err
+	}
+	if split == 0 {
+		state, err := r.get(2)
+		if err != nil {
+			return err
+		}
+		if state == 3 {
+			if state, err = r.get(4); err != nil {
+				return err
+			}
+			state += 3
+		}
+		t.State = State(state)
+		return nil
+	}
+	if t.SpecialSide, err = r.get(2); err != nil {
+		return err
+	}
+	if t.SpecialSide > 2 {
+		return ErrInvalidTree
+	}
+	t.Children = make([]Tree, split+1)
+	for i := int(split); i >= 0; i-- {
+		if err := r.read(&t.Children[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package painting
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestTree_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		tree Tree
+		want string
+	}{
+		{"none", Tree{}, "0"},
+		{"extruder1", Tree{State: 1}, "4"},
+		{"extruder2", Tree{State: 2}, "8"},
+		{"extruder3", Tree{State: 3}, "0C"},
+		{"extruder4", Tree{State: 4}, "1C"},
+		{"max", Tree{State: MaxState}, "FC"},
+		{"split1", Tree{Children: []Tree{{State: 1}, {State: 2}}}, "481"},
+		{"split2", Tree{SpecialSide: 2, Children: []Tree{{State: 1}, {}, {State: 3}}}, "400CA"},
+		{"nested", Tree{SpecialSide: 1, Children: []Tree{{Children: []Tree{{State: 2}, {}}}, {State: 1}}}, "80145"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.tree.MarshalText()
+			if err != nil {
+				t.Fatalf("Tree.MarshalText() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Tree.MarshalText() = %s, want %s", got, tt.want)
+			}
+			var tree Tree
+			if err := tree.UnmarshalText(got); err != nil {
+				t.Fatalf("Tree.UnmarshalText() error = %v", err)
+			}
+			if diff := deep.Equal(tree, tt.tree); diff != nil {
+				t.Errorf("Tree.UnmarshalText() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestTree_MarshalText_error(t *testing.T) {
+	tests := []struct {
+		name string
+		tree Tree
+		want error
+	}{
+		{"state", Tree{State: MaxState + 1}, ErrMaxState},
+		{"children", Tree{Children: []Tree{{}}}, ErrInvalidTree},
+		{"side", Tree{SpecialSide: 3, Children: []Tree{{}, {}}}, ErrInvalidTree},
+		{"nested", Tree{Children: []Tree{{}, {State: MaxState + 1}}}, ErrMaxState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.tree.MarshalText(); err != tt.want {
+				t.Errorf("Tree.MarshalText() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTree_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    Tree
+		wantErr error
+	}{
+		{"empty", "", Tree{}, nil},
+		{"lowercase", "1c", Tree{State: 4}, nil},
+		{"padding", "04", Tree{State: 1}, nil},
+		{"char", "G", Tree{}, ErrInvalidTree},
+		{"truncated", "1", Tree{}, ErrInvalidTree},
+		{"extended", "C", Tree{}, ErrInvalidTree},
+		{"side", "D", Tree{}, ErrInvalidTree},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Tree
+			err := got.UnmarshalText([]byte(tt.text))
+			if err != tt.wantErr {
+				t.Fatalf("Tree.UnmarshalText() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if diff := deep.Equal(got, tt.want); diff != nil {
+					t.Errorf("Tree.UnmarshalText() = %v", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestTree_IsEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		tree Tree
+		want bool
+	}{
+		{"empty", Tree{}, true},
+		{"painted", Tree{State: 1}, false},
+		{"split", Tree{Children: []Tree{{}, {}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tree.IsEmpty(); got != tt.want {
+				t.Errorf("Tree.IsEmpty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
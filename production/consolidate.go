@@ -0,0 +1,242 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package production
+
+import (
+	"sort"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+)
+
+// Remapper is implemented by the assets, and by the extension elements
+// and attributes of objects, meshes and base materials, whose ID and
+// references can be renumbered.
+//
+// fn is called with the path of each reference, empty for resources
+// of the same model file, and returns its new path and ID.
+type Remapper interface {
+	Remap(fn func(path string, id uint32) (string, uint32))
+}
+
+// Consolidate moves the resources of all the child models into the root model,
+// renumbering the IDs that collide with the root ones, and deletes the child models.
+// The references by path of the build items, the build definitions, the components and the extensions
+// that implement Remapper are rewritten to the root model.
+//
+// The relationships of the child models are merged into the root ones and
+// the production extension, if present, is no longer required,
+// so the result can be read by consumers that do not support it.
+// It fails without modifying m if an asset that does not implement Remapper
+// has an ID that is already in use, or if a lazily decoded child model fails to load.
+func Consolidate(m *go3mf.Model) error {
+	if len(m.Childs) == 0 {
+		return nil
+	}
+	paths := make([]string, 0, len(m.Childs))
+	for path := range m.Childs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := m.Childs[path].Load(); err != nil {
+			return err
+		}
+	}
+	used := make(map[uint32]bool)
+	for _, a := range m.Resources.Assets {
+		used[a.Identify()] = true
+	}
+	for _, o := range m.Resources.Objects {
+		used[o.ID] = true
+	}
+	ids := make(map[string]map[uint32]uint32, len(paths))
+	for _, path := range paths {
+		ids[path] = make(map[uint32]uint32)
+		child := m.Childs[path]
+		for i, a := range child.Resources.Assets {
+			if remappable(a) {
+				continue
+			}
+			id := a.Identify()
+			if used[id] {
+				return specerr.WrapPath(specerr.WrapIndex(specerr.ErrDuplicatedID, a, i), child.Resources, path)
+			}
+			used[id] = true
+			ids[path][id] = id
+		}
+	}
+	next := uint32(1)
+	alloc := func() uint32 {
+		for used[next] {
+			next++
+		}
+		used[next] = true
+		return next
+	}
+	// Resources shared by several child models are moved once.
+	shared := make(map[interface{}]uint32)
+	newID := func(r interface{}) (uint32, bool) {
+		if id, ok := shared[r]; ok {
+			return id, false
+		}
+		shared[r] = alloc()
+		return shared[r], true
+	}
+	var (
+		assets  []go3mf.Asset
+		objects []*go3mf.Object
+		remap   = make(map[interface{}]string)
+	)
+	for _, path := range paths {
+		child := m.Childs[path]
+		for _, a := range child.Resources.Assets {
+			if !remappable(a) {
+				assets = append(assets, a)
+				continue
+			}
+			id, first := newID(a)
+			ids[path][a.Identify()] = id
+			if first {
+				assets = append(assets, a)
+				remap[a] = path
+			}
+		}
+		for _, o := range child.Resources.Objects {
+			id, first := newID(o)
+			ids[path][o.ID] = id
+			if first {
+				objects = append(objects, o)
+				remap[o] = path
+			}
+		}
+	}
+	resolver := func(from string) func(string, uint32) (string, uint32) {
+		return func(path string, id uint32) (string, uint32) {
+			if path == "" {
+				path = from
+			}
+			if path == "" || path == m.PathOrDefault() {
+				return "", id
+			}
+			if n, ok := ids[path][id]; ok {
+				return "", n
+			}
+			return path, id
+		}
+	}
+	remapResources(&m.Resources, resolver(""))
+	for _, item := range m.Build.Items {
+		path, id := resolver("")(item.ObjectPath(), item.ObjectID)
+		item.ObjectID = id
+		if attr := GetItemAttr(item); attr != nil {
+			attr.Path = path
+		}
+	}
+	for _, b := range GetBuildDefinitions(m) {
+		for i, item := range b.Items {
+			b.Items[i].Path, b.Items[i].ObjectID = resolver("")(item.Path, item.ObjectID)
+		}
+	}
+	for _, a := range assets {
+		if path, ok := remap[a]; ok {
+			remapAsset(a, resolver(path))
+		}
+	}
+	for _, o := range objects {
+		remapObject(o, resolver(remap[o]))
+	}
+	// The child resources go first, as they can be referenced by the root ones.
+	m.Resources.Assets = append(assets, m.Resources.Assets...)
+	m.Resources.Objects = append(objects, m.Resources.Objects...)
+	for _, path := range paths {
+		child := m.Childs[path]
+		for _, r := range child.Relationships {
+			if !hasRelationship(m.Relationships, r) {
+				m.Relationships = append(m.Relationships, r)
+			}
+		}
+		m.Any = append(m.Any, child.Any...)
+	}
+	m.Childs = nil
+	for i, ext := range m.Extensions {
+		if ext.Namespace == Namespace {
+			m.Extensions[i].IsRequired = false
+		}
+	}
+	return nil
+}
+
+func remappable(a go3mf.Asset) bool {
+	if _, ok := a.(*go3mf.BaseMaterials); ok {
+		return true
+	}
+	_, ok := a.(Remapper)
+	return ok
+}
+
+func remapResources(res *go3mf.Resources, fn func(string, uint32) (string, uint32)) {
+	for _, a := range res.Assets {
+		remapAsset(a, fn)
+	}
+	for _, o := range res.Objects {
+		remapObject(o, fn)
+	}
+}
+
+func remapAsset(a go3mf.Asset, fn func(string, uint32) (string, uint32)) {
+	if r, ok := a.(Remapper); ok {
+		r.Remap(fn)
+	} else if b, ok := a.(*go3mf.BaseMaterials); ok {
+		_, b.ID = fn("", b.ID)
+		remapAny(nil, b.AnyAttr, fn)
+	}
+}
+
+func remapObject(obj *go3mf.Object, fn func(string, uint32) (string, uint32)) {
+	_, obj.ID = fn("", obj.ID)
+	if obj.PID != 0 {
+		_, obj.PID = fn("", obj.PID)
+	}
+	if obj.Mesh != nil {
+		for i, t := range obj.Mesh.Triangles {
+			if t.PID != 0 {
+				_, obj.Mesh.Triangles[i].PID = fn("", t.PID)
+			}
+		}
+		remapAny(obj.Mesh.Any, obj.Mesh.AnyAttr, fn)
+	}
+	if obj.Components != nil {
+		for _, c := range obj.Components.Component {
+			var path string
+			path, c.ObjectID = fn(c.ObjectPath(""), c.ObjectID)
+			if attr := GetComponentAttr(c); attr != nil {
+				attr.Path = path
+			}
+		}
+	}
+	remapAny(obj.Any, obj.AnyAttr, fn)
+}
+
+func remapAny(any go3mf.Any, attrs go3mf.AnyAttr, fn func(string, uint32) (string, uint32)) {
+	for _, a := range any {
+		if r, ok := a.(Remapper); ok {
+			r.Remap(fn)
+		}
+	}
+	for _, a := range attrs {
+		if r, ok := a.(Remapper); ok {
+			r.Remap(fn)
+		}
+	}
+}
+
+func hasRelationship(rels []go3mf.Relationship, r go3mf.Relationship) bool {
+	for _, r1 := range rels {
+		if r1.Path == r.Path && r1.Type == r.Type {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package production
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/go-test/deep"
+)
+
+func TestConsolidate(t *testing.T) {
+	rootColors := &materials.ColorGroup{ID: 1, Colors: []color.RGBA{{G: 255, A: 255}}}
+	childColors := &materials.ColorGroup{ID: 1, Colors: []color.RGBA{{R: 255, A: 255}}}
+	multi := &materials.MultiProperties{ID: 2, PIDs: []uint32{1}, Multis: []materials.Multi{{PIndices: []uint32{0}}}}
+	obj1 := tetrahedron(3, 2)
+	obj2 := tetrahedron(1, 0)
+	assembly := &go3mf.Object{ID: 2, Components: &go3mf.Components{Component: []*go3mf.Component{
+		{ObjectID: 3, AnyAttr: go3mf.AnyAttr{&ComponentAttr{UUID: "a", Path: "/3D/b.model"}}},
+		{ObjectID: 1, AnyAttr: go3mf.AnyAttr{&ComponentAttr{UUID: "b", Path: "/3D/a.model"}}},
+	}}}
+	m := &go3mf.Model{
+		Extensions:    []go3mf.Extension{DefaultExtension},
+		Relationships: []go3mf.Relationship{{Path: "/a.png", Type: materials.RelTypeTexture3D}},
+		Resources:     go3mf.Resources{Assets: []go3mf.Asset{rootColors}, Objects: []*go3mf.Object{assembly}},
+		Build: go3mf.Build{Items: []*go3mf.Item{
+			{ObjectID: 2},
+			{ObjectID: 3, AnyAttr: go3mf.AnyAttr{&ItemAttr{UUID: "c", Path: "/3D/b.model"}}},
+		}},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/a.model": {Resources: go3mf.Resources{Objects: []*go3mf.Object{obj2}}},
+			"/3D/b.model": {
+				Resources: go3mf.Resources{Assets: []go3mf.Asset{childColors, multi}, Objects: []*go3mf.Object{obj1}},
+				Relationships: []go3mf.Relationship{
+					{Path: "/a.png", Type: materials.RelTypeTexture3D},
+					{Path: "/b.png", Type: materials.RelTypeTexture3D},
+				},
+			},
+		},
+	}
+	if err := Consolidate(m); err != nil {
+		t.Fatalf("Consolidate() error = %v", err)
+	}
+	if m.Childs != nil {
+		t.Errorf("Consolidate() childs = %v", m.Childs)
+	}
+	// Child paths are processed in lexical order and placed before the root resources.
+	want := go3mf.Resources{
+		Assets:  []go3mf.Asset{childColors, multi, rootColors},
+		Objects: []*go3mf.Object{obj2, obj1, assembly},
+	}
+	if diff := deep.Equal(m.Resources, want); diff != nil {
+		t.Errorf("Consolidate() resources = %v", diff)
+	}
+	if obj2.ID != 3 || childColors.ID != 4 || multi.ID != 5 || obj1.ID != 6 {
+		t.Errorf("Consolidate() IDs = %d, %d, %d, %d", obj2.ID, childColors.ID, multi.ID, obj1.ID)
+	}
+	if diff := deep.Equal(multi.PIDs, []uint32{4}); diff != nil || obj1.PID != 5 {
+		t.Errorf("Consolidate() references = %v, %d", diff, obj1.PID)
+	}
+	for i, want := range []uint32{6, 3} {
+		c := assembly.Components.Component[i]
+		if c.ObjectID != want || c.ObjectPath("") != "" {
+			t.Errorf("Consolidate() component %d = %d %s, want %d", i, c.ObjectID, c.ObjectPath(""), want)
+		}
+	}
+	if item := m.Build.Items[1]; item.ObjectID != 6 || item.ObjectPath() != "" || GetItemAttr(item).UUID != "c" {
+		t.Errorf("Consolidate() item = %v", item)
+	}
+	if diff := deep.Equal(m.Relationships, []go3mf.Relationship{
+		{Path: "/a.png", Type: materials.RelTypeTexture3D},
+		{Path: "/b.png", Type: materials.RelTypeTexture3D},
+	}); diff != nil {
+		t.Errorf("Consolidate() relationships = %v", diff)
+	}
+	if m.Extensions[0].IsRequired {
+		t.Errorf("Consolidate() production extension is still required")
+	}
+}
+
+func TestConsolidate_split(t *testing.T) {
+	colors := &materials.ColorGroup{ID: 5, Colors: []color.RGBA{{R: 255, A: 255}}}
+	assembly := &go3mf.Object{ID: 3, Components: &go3mf.Components{Component: []*go3mf.Component{{ObjectID: 2}}}}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{
+			Assets:  []go3mf.Asset{colors},
+			Objects: []*go3mf.Object{tetrahedron(1, 5), tetrahedron(2, 5), assembly},
+		},
+		Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1}, {ObjectID: 3}}},
+	}
+	if err := Split(m); err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	if err := Consolidate(m); err != nil {
+		t.Fatalf("Consolidate() error = %v", err)
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("Consolidate() produced an invalid model: %v", err)
+	}
+	// The colors shared by both children are moved and renumbered once.
+	if len(m.Resources.Objects) != 3 || len(m.Resources.Assets) != 1 || m.Resources.Assets[0] != colors {
+		t.Fatalf("Consolidate() resources = %v", m.Resources)
+	}
+	for _, obj := range m.Resources.Objects[:2] {
+		if obj.PID != colors.ID {
+			t.Errorf("Consolidate() object %d pid = %d, want %d", obj.ID, obj.PID, colors.ID)
+		}
+	}
+}
+
+func TestConsolidate_error(t *testing.T) {
+	child := &go3mf.ChildModel{Resources: go3mf.Resources{Assets: []go3mf.Asset{&go3mf.UnknownAsset{}}}}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{{ID: 0}}},
+		Childs:    map[string]*go3mf.ChildModel{"/3D/a.model": child},
+	}
+	if err := Consolidate(m); !errors.Is(err, specerr.ErrDuplicatedID) {
+		t.Fatalf("Consolidate() error = %v, want %v", err, specerr.ErrDuplicatedID)
+	}
+	if len(m.Childs) != 1 {
+		t.Errorf("Consolidate() modified the model")
+	}
+	if err := Consolidate(new(go3mf.Model)); err != nil {
+		t.Errorf("Consolidate() error = %v", err)
+	}
+}
+
+func TestConsolidate_lazy(t *testing.T) {
+	colors := &materials.ColorGroup{ID: 5, Colors: []color.RGBA{{R: 255, A: 255}}}
+	assembly := &go3mf.Object{ID: 3, Components: &go3mf.Components{Component: []*go3mf.Component{{ObjectID: 2}}}}
+	m := &go3mf.Model{
+		Extensions: []go3mf.Extension{materials.DefaultExtension},
+		Resources: go3mf.Resources{
+			Assets:  []go3mf.Asset{colors},
+			Objects: []*go3mf.Object{tetrahedron(1, 5), tetrahedron(2, 5), assembly},
+		},
+		Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1}, {ObjectID: 3}}},
+	}
+	if err := Split(m); err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := go3mf.NewEncoder(&buf).Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	d := go3mf.NewDecoder(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	d.SetLazyLoading(true)
+	got := new(go3mf.Model)
+	if err := d.Decode(got); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	if err := Consolidate(got); err != nil {
+		t.Fatalf("Consolidate() error = %v", err)
+	}
+	if len(got.Resources.Objects) != 3 {
+		t.Errorf("Consolidate() resources = %v", got.Resources)
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("Consolidate() produced an invalid model: %v", err)
+	}
+}
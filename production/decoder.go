@@ -4,14 +4,27 @@
 package production
 
 import (
+	"encoding/xml"
+	"strconv"
+
 	"github.com/MosaicManufacturing/go3mf"
 	specerr "github.com/MosaicManufacturing/go3mf/errors"
 	"github.com/MosaicManufacturing/go3mf/spec"
 	"github.com/MosaicManufacturing/go3mf/uuid"
 )
 
-func (Spec) CreateElementDecoder(_ interface{}, _ string) spec.ElementDecoder {
-	return nil
+func (Spec) CreateElementDecoder(parent interface{}, name string) (child spec.ElementDecoder) {
+	switch parent := parent.(type) {
+	case *go3mf.Model:
+		if name == attrBuild {
+			child = &buildDefinitionDecoder{model: parent}
+		}
+	case *go3mf.Object:
+		if name == attrAlternatives {
+			child = &alternativesDecoder{obj: parent}
+		}
+	}
+	return
 }
 
 func (Spec) DecodeAttribute(parentNode interface{}, attr spec.Attr) (errs error) {
@@ -69,3 +82,160 @@ func (Spec) DecodeAttribute(parentNode interface{}, attr spec.Attr) (errs error)
 	}
 	return
 }
+
+type alternativesDecoder struct {
+	baseDecoder
+	obj          *go3mf.Object
+	alternatives *Alternatives
+}
+
+func (d *alternativesDecoder) Start([]spec.Attr) error {
+	d.alternatives = new(Alternatives)
+	d.obj.Any = append(d.obj.Any, d.alternatives)
+	return nil
+}
+
+func (d *alternativesDecoder) Wrap(err error) error {
+	return specerr.Wrap(err, d.alternatives)
+}
+
+func (d *alternativesDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrAlternative {
+		child = &alternativeDecoder{alternatives: d.alternatives}
+	}
+	return
+}
+
+type alternativeDecoder struct {
+	baseDecoder
+	alternatives *Alternatives
+}
+
+func (d *alternativeDecoder) Start(attrs []spec.Attr) error {
+	var (
+		alt  Alternative
+		errs error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrObjectID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			alt.ObjectID = uint32(val)
+		case attrProdUUID:
+			if err := uuid.Validate(string(a.Value)); err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			alt.UUID = string(a.Value)
+		case attrPath:
+			alt.Path = string(a.Value)
+		case attrModelResolution:
+			var ok bool
+			if alt.ModelResolution, ok = newModelResolution(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		}
+	}
+	d.alternatives.Alternatives = append(d.alternatives.Alternatives, alt)
+	if errs != nil {
+		return specerr.WrapIndex(errs, alt, len(d.alternatives.Alternatives)-1)
+	}
+	return nil
+}
+
+type buildDefinitionDecoder struct {
+	baseDecoder
+	model *go3mf.Model
+	build *BuildDefinition
+}
+
+func (d *buildDefinitionDecoder) Start(attrs []spec.Attr) error {
+	d.build = new(BuildDefinition)
+	d.model.Any = append(d.model.Any, d.build)
+	var errs error
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrProdUUID:
+			if err := uuid.Validate(string(a.Value)); err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.build.UUID = string(a.Value)
+		case attrName:
+			d.build.Name = string(a.Value)
+		}
+	}
+	if errs != nil {
+		return specerr.Wrap(errs, d.build)
+	}
+	return nil
+}
+
+func (d *buildDefinitionDecoder) Wrap(err error) error {
+	return specerr.Wrap(err, d.build)
+}
+
+func (d *buildDefinitionDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrItem {
+		child = &buildItemDecoder{build: d.build}
+	}
+	return
+}
+
+type buildItemDecoder struct {
+	baseDecoder
+	build *BuildDefinition
+}
+
+func (d *buildItemDecoder) Start(attrs []spec.Attr) error {
+	var (
+		item BuildItem
+		errs error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrObjectID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			item.ObjectID = uint32(val)
+		case attrProdUUID:
+			if err := uuid.Validate(string(a.Value)); err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			item.UUID = string(a.Value)
+		case attrPath:
+			item.Path = string(a.Value)
+		case attrPartNumber:
+			item.PartNumber = string(a.Value)
+		case attrTransform:
+			var ok bool
+			item.Transform, ok = spec.ParseMatrix(string(a.Value))
+			if !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		}
+	}
+	d.build.Items = append(d.build.Items, item)
+	if errs != nil {
+		return specerr.WrapIndex(errs, item, len(d.build.Items)-1)
+	}
+	return nil
+}
+
+type baseDecoder struct {
+}
+
+func (d *baseDecoder) Start([]spec.Attr) error { return nil }
+func (d *baseDecoder) End()                    {}
@@ -4,6 +4,7 @@
 package production
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -23,6 +24,10 @@ func TestDecode(t *testing.T) {
 			}},
 			ObjectID: 8, Transform: go3mf.Matrix{3, 0, 0, 0, 0, 1, 0, 0, 0, 0, 2, 0, -66.4, -87.1, 8.8, 1}},
 		}},
+		Any: go3mf.Any{&Alternatives{Alternatives: []Alternative{
+			{ObjectID: 9, UUID: "cb828680-8895-4e08-a1fc-be63e033df17", Path: "/3D/low.model", ModelResolution: ModelResolutionLowRes},
+			{ObjectID: 10, UUID: "cb828680-8895-4e08-a1fc-be63e033df18"},
+		}}},
 	}
 
 	want := &go3mf.Model{Path: "/3D/3dmodel.model", Resources: go3mf.Resources{
@@ -39,6 +44,10 @@ func TestDecode(t *testing.T) {
 			UUID: "e9e25302-6428-402e-8633-cc95528d0ed4",
 		}},
 	})
+	want.Any = go3mf.Any{&BuildDefinition{UUID: "e9e25302-6428-402e-8633-cc95528d0ed5", Name: "process B", Items: []BuildItem{
+		{ObjectID: 20, UUID: "e9e25302-6428-402e-8633-cc95528d0ed6", PartNumber: "a", Transform: go3mf.Matrix{1, 0, 0, 0, 0, 2, 0, 0, 0, 0, 3, 0, -66.4, -87.1, 8.8, 1}},
+		{ObjectID: 8, UUID: "e9e25302-6428-402e-8633-cc95528d0ed7", Path: "/3D/other.model"},
+	}}}
 	want.Extensions = []go3mf.Extension{DefaultExtension}
 	got := new(go3mf.Model)
 	got.Path = "/3D/3dmodel.model"
@@ -50,12 +59,20 @@ func TestDecode(t *testing.T) {
 				<components>
 					<component objectid="8" p:UUID="cb828680-8895-4e08-a1fc-be63e033df16" p:path="/3D/other.model" transform="3 0 0 0 1 0 0 0 2 -66.4 -87.1 8.8"/>
 				</components>
+				<p:alternatives>
+					<p:alternative objectid="9" UUID="cb828680-8895-4e08-a1fc-be63e033df17" path="/3D/low.model" modelresolution="lowres"/>
+					<p:alternative objectid="10" UUID="cb828680-8895-4e08-a1fc-be63e033df18" modelresolution="fullres"/>
+				</p:alternatives>
 			</object>
 		</resources>
 		<build p:UUID="e9e25302-6428-402e-8633-cc95528d0ed3">
 			<item objectid="20" p:UUID="e9e25302-6428-402e-8633-cc95528d0ed2" transform="1 0 0 0 2 0 0 0 3 -66.4 -87.1 8.8" />
 			<item objectid="8" p:UUID="e9e25302-6428-402e-8633-cc95528d0ed4" p:path="/3D/other.model" />
 		</build>
+		<p:build UUID="e9e25302-6428-402e-8633-cc95528d0ed5" name="process B">
+			<p:item objectid="20" UUID="e9e25302-6428-402e-8633-cc95528d0ed6" partnumber="a" transform="1 0 0 0 2 0 0 0 3 -66.4 -87.1 8.8" />
+			<p:item objectid="8" UUID="e9e25302-6428-402e-8633-cc95528d0ed7" path="/3D/other.model" />
+		</p:build>
 		</model>
 		`
 	t.Run("base", func(t *testing.T) {
@@ -72,10 +89,13 @@ func TestDecode(t *testing.T) {
 
 func TestDecode_warns(t *testing.T) {
 	want := []string{
+		fmt.Sprintf("Resources@Object#0@Alternatives@Alternative#0: %v", &errors.ParseAttrError{Required: false, Name: "modelresolution"}),
 		fmt.Sprintf("Resources@Object#1: %v", &errors.ParseAttrError{Required: true, Name: "UUID"}),
 		fmt.Sprintf("Resources@Object#1@Components@Component#0: %v", &errors.ParseAttrError{Required: true, Name: "UUID"}),
 		fmt.Sprintf("Build: %v", &errors.ParseAttrError{Required: true, Name: "UUID"}),
 		fmt.Sprintf("Build@Item#0: %v", &errors.ParseAttrError{Required: true, Name: "UUID"}),
+		fmt.Sprintf("BuildDefinition: %v", &errors.ParseAttrError{Required: true, Name: "UUID"}),
+		fmt.Sprintf("BuildDefinition@BuildItem#0: %v", &errors.ParseAttrError{Required: true, Name: "objectid"}),
 	}
 	got := new(go3mf.Model)
 	got.Path = "/3D/3dmodel.model"
@@ -84,7 +104,11 @@ func TestDecode_warns(t *testing.T) {
 			xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:p="http://schemas.microsoft.com/3dmanufacturing/production/2015/06"
 			requiredextensions="p">
 		<resources>
-			<object id="22" p:UUID="cb828680-8895-4e08-a1fc-be63e033df15" />
+			<object id="22" p:UUID="cb828680-8895-4e08-a1fc-be63e033df15">
+				<p:alternatives>
+					<p:alternative objectid="5" UUID="cb828680-8895-4e08-a1fc-be63e033df17" modelresolution="medres"/>
+				</p:alternatives>
+			</object>
 			<object id="20" p:UUID="cb8286808895-4e08-a1fc-be63e033df15">
 				<components>
 					<component objectid="8" p:path="/2d/2d.model" p:UUID="cb8286808895-4e08-a1fc-be63e033df16"/>
@@ -97,6 +121,9 @@ func TestDecode_warns(t *testing.T) {
 			<item objectid="8" p:path="/3D/other.model"/>
 			<item objectid="5" p:UUID="e9e25302-6428-402e-8633-cc95528d0ed4"/>
 		</build>
+		<p:build UUID="invalid-uuid">
+			<p:item objectid="a" UUID="e9e25302-6428-402e-8633-cc95528d0ed5"/>
+		</p:build>
 		</model>`
 
 	t.Run("base", func(t *testing.T) {
@@ -114,3 +141,42 @@ func TestDecode_warns(t *testing.T) {
 		}
 	})
 }
+
+func TestDecode_childBuild(t *testing.T) {
+	m := &go3mf.Model{
+		Extensions: []go3mf.Extension{DefaultExtension},
+		Build: go3mf.Build{
+			AnyAttr: go3mf.AnyAttr{&BuildAttr{UUID: "e9e25302-6428-402e-8633-cc95528d0ed3"}},
+			Items: []*go3mf.Item{{ObjectID: 1, AnyAttr: go3mf.AnyAttr{
+				&ItemAttr{UUID: "e9e25302-6428-402e-8633-cc95528d0ed4", Path: "/3D/other.model"},
+			}}},
+		},
+		Childs: map[string]*go3mf.ChildModel{"/3D/other.model": {
+			Resources: go3mf.Resources{Objects: []*go3mf.Object{{
+				ID: 1, AnyAttr: go3mf.AnyAttr{&ObjectAttr{UUID: "cb828680-8895-4e08-a1fc-be63e033df15"}},
+				Mesh: &go3mf.Mesh{
+					Vertices:  []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+					Triangles: []go3mf.Triangle{{V1: 0, V2: 1, V3: 2}, {V1: 0, V2: 3, V3: 1}, {V1: 0, V2: 2, V3: 3}, {V1: 1, V2: 3, V3: 2}},
+				},
+			}}},
+			Any: go3mf.Any{&BuildDefinition{
+				UUID:  "e9e25302-6428-402e-8633-cc95528d0ed5",
+				Items: []BuildItem{{ObjectID: 1, UUID: "cb828680-8895-4e08-a1fc-be63e033df16"}},
+			}},
+		}},
+	}
+	var buf bytes.Buffer
+	if err := go3mf.NewEncoder(&buf).Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	got := new(go3mf.Model)
+	if err := go3mf.NewDecoder(bytes.NewReader(buf.Bytes()), int64(buf.Len())).Decode(got); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	if defs := GetBuildDefinitions(got); len(defs) != 0 {
+		t.Errorf("Decoder.Decode() build definitions = %v, want none", defs)
+	}
+	if c := got.Childs["/3D/other.model"]; c == nil || len(c.Any) != 0 || len(c.Resources.Objects) != 1 {
+		t.Errorf("Decoder.Decode() child model = %v", c)
+	}
+}
@@ -5,6 +5,7 @@ package production
 
 import (
 	"encoding/xml"
+	"strconv"
 
 	"github.com/MosaicManufacturing/go3mf/spec"
 )
@@ -48,3 +49,57 @@ func (p *ComponentAttr) Marshal3MFAttr(_ spec.Encoder) ([]xml.Attr, error) {
 		{Name: xml.Name{Space: Namespace, Local: attrProdUUID}, Value: p.UUID},
 	}, nil
 }
+
+// Marshal3MF encodes the resource.
+func (a *Alternatives) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrAlternatives}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, alt := range a.Alternatives {
+		xa := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrAlternative}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrObjectID}, Value: strconv.FormatUint(uint64(alt.ObjectID), 10)},
+			{Name: xml.Name{Local: attrProdUUID}, Value: alt.UUID},
+		}}
+		if alt.Path != "" {
+			xa.Attr = append(xa.Attr, xml.Attr{Name: xml.Name{Local: attrPath}, Value: alt.Path})
+		}
+		if alt.ModelResolution != ModelResolutionFullRes {
+			xa.Attr = append(xa.Attr, xml.Attr{Name: xml.Name{Local: attrModelResolution}, Value: alt.ModelResolution.String()})
+		}
+		x.EncodeToken(xa)
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (b *BuildDefinition) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrBuild}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrProdUUID}, Value: b.UUID},
+	}}
+	if b.Name != "" {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrName}, Value: b.Name})
+	}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, item := range b.Items {
+		xi := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrItem}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrObjectID}, Value: strconv.FormatUint(uint64(item.ObjectID), 10)},
+			{Name: xml.Name{Local: attrProdUUID}, Value: item.UUID},
+		}}
+		if item.Path != "" {
+			xi.Attr = append(xi.Attr, xml.Attr{Name: xml.Name{Local: attrPath}, Value: item.Path})
+		}
+		if item.HasTransform() {
+			xi.Attr = append(xi.Attr, xml.Attr{Name: xml.Name{Local: attrTransform}, Value: item.Transform.String()})
+		}
+		if item.PartNumber != "" {
+			xi.Attr = append(xi.Attr, xml.Attr{Name: xml.Name{Local: attrPartNumber}, Value: item.PartNumber})
+		}
+		x.EncodeToken(xi)
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
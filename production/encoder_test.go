@@ -21,6 +21,10 @@ func TestMarshalModel(t *testing.T) {
 				UUID: "cb828680-8895-4e08-a1fc-be63e033df16",
 			}}},
 		}},
+		Any: go3mf.Any{&Alternatives{Alternatives: []Alternative{
+			{ObjectID: 9, UUID: "cb828680-8895-4e08-a1fc-be63e033df17", Path: "/3D/low.model", ModelResolution: ModelResolutionLowRes},
+			{ObjectID: 10, UUID: "cb828680-8895-4e08-a1fc-be63e033df18"},
+		}}},
 	}
 	m := &go3mf.Model{Path: "/3D/3dmodel.model", Build: go3mf.Build{
 		AnyAttr: go3mf.AnyAttr{&BuildAttr{UUID: "e9e25302-6428-402e-8633-cc95528d0ed3"}},
@@ -34,6 +38,10 @@ func TestMarshalModel(t *testing.T) {
 			UUID: "e9e25302-6428-402e-8633-cc95528d0ed4",
 		}},
 	})
+	m.Any = go3mf.Any{&BuildDefinition{UUID: "e9e25302-6428-402e-8633-cc95528d0ed5", Name: "process B", Items: []BuildItem{
+		{ObjectID: 20, UUID: "e9e25302-6428-402e-8633-cc95528d0ed6", PartNumber: "a", Transform: go3mf.Matrix{1, 0, 0, 0, 0, 2, 0, 0, 0, 0, 3, 0, -66.4, -87.1, 8.8, 1}},
+		{ObjectID: 8, UUID: "e9e25302-6428-402e-8633-cc95528d0ed7", Path: "/3D/other.model"},
+	}}}
 	m.Extensions = []go3mf.Extension{DefaultExtension}
 	b, err := go3mf.MarshalModel(m)
 	if err != nil {
@@ -0,0 +1,176 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package production
+
+import (
+	"sort"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/uuid"
+)
+
+// Element is a model element identified by a production UUID.
+//
+// Value is a *go3mf.Build, *go3mf.Item, *go3mf.Object, *go3mf.Component,
+// *BuildDefinition, *BuildItem or *Alternative.
+// Path is the model file that contains the element, empty for the root model.
+// Parent is the object that contains a component or an alternative,
+// nil for other elements.
+type Element struct {
+	Path   string
+	Value  interface{}
+	Parent *go3mf.Object
+}
+
+// Index maps the production UUIDs of a model to its elements.
+// UUIDs are compared by value, regardless of their form.
+type Index struct {
+	m     *go3mf.Model
+	elems map[[16]byte]Element
+}
+
+// NewIndex indexes the build, items, build definitions, objects, components
+// and alternatives of m by UUID.
+// Elements with a missing or invalid UUID are not indexed.
+//
+// If a UUID is used by more than one element, the first one wins and
+// an ErrDuplicatedUUID is returned for each of the others, together with the
+// index. The root build, items and build definitions are indexed first,
+// then the objects of the child models in lexical order and finally the root objects.
+func NewIndex(m *go3mf.Model) (*Index, error) {
+	idx := &Index{m: m, elems: make(map[[16]byte]Element)}
+	var errs error
+	if attr := GetBuildAttr(&m.Build); attr != nil {
+		errs = specerr.Append(errs, specerr.Wrap(idx.add(attr.UUID, Element{Value: &m.Build}), m.Build))
+	}
+	for i, item := range m.Build.Items {
+		if attr := GetItemAttr(item); attr != nil {
+			err := idx.add(attr.UUID, Element{Value: item})
+			errs = specerr.Append(errs, specerr.Wrap(specerr.WrapIndex(err, item, i), m.Build))
+		}
+	}
+	for i, b := range GetBuildDefinitions(m) {
+		bErrs := idx.add(b.UUID, Element{Value: b})
+		for j := range b.Items {
+			err := idx.add(b.Items[j].UUID, Element{Value: &b.Items[j]})
+			bErrs = specerr.Append(bErrs, specerr.WrapIndex(err, b.Items[j], j))
+		}
+		errs = specerr.Append(errs, specerr.WrapIndex(bErrs, b, i))
+	}
+	paths := make([]string, 0, len(m.Childs)+1)
+	for path := range m.Childs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range append(paths, "") {
+		if c, ok := m.Childs[path]; ok {
+			if err := c.Load(); err != nil {
+				errs = specerr.Append(errs, err)
+				continue
+			}
+		}
+		res, ok := m.FindResources(path)
+		if !ok {
+			continue
+		}
+		for i, obj := range res.Objects {
+			var oErrs error
+			if attr := GetObjectAttr(obj); attr != nil {
+				oErrs = specerr.Append(oErrs, idx.add(attr.UUID, Element{Path: path, Value: obj}))
+			}
+			if obj.Components != nil {
+				var cErrs error
+				for j, c := range obj.Components.Component {
+					if attr := GetComponentAttr(c); attr != nil {
+						err := idx.add(attr.UUID, Element{Path: path, Value: c, Parent: obj})
+						cErrs = specerr.Append(cErrs, specerr.WrapIndex(err, c, j))
+					}
+				}
+				oErrs = specerr.Append(oErrs, specerr.Wrap(cErrs, obj.Components))
+			}
+			if alts := GetAlternatives(obj); alts != nil {
+				var aErrs error
+				for j := range alts.Alternatives {
+					alt := &alts.Alternatives[j]
+					err := idx.add(alt.UUID, Element{Path: path, Value: alt, Parent: obj})
+					aErrs = specerr.Append(aErrs, specerr.WrapIndex(err, *alt, j))
+				}
+				oErrs = specerr.Append(oErrs, specerr.Wrap(aErrs, alts))
+			}
+			errs = specerr.Append(errs, specerr.WrapPath(specerr.WrapIndex(oErrs, obj, i), *res, path))
+		}
+	}
+	return idx, errs
+}
+
+func (idx *Index) add(id string, e Element) error {
+	key, err := uuid.Parse(id)
+	if err != nil {
+		return nil
+	}
+	if _, ok := idx.elems[key]; ok {
+		return ErrDuplicatedUUID
+	}
+	idx.elems[key] = e
+	return nil
+}
+
+// Len returns the number of indexed elements.
+func (idx *Index) Len() int {
+	return len(idx.elems)
+}
+
+// Find returns the element identified by id.
+func (idx *Index) Find(id string) (Element, bool) {
+	key, err := uuid.Parse(id)
+	if err != nil {
+		return Element{}, false
+	}
+	e, ok := idx.elems[key]
+	return e, ok
+}
+
+// FindObject returns the object identified by id, or the object referenced
+// by the item, component or alternative identified by id,
+// together with its model path.
+// The path is empty for objects of the root model.
+//
+// It allows tracing a printed part, usually identified by its build item
+// or component UUID, back to the object that defines its geometry.
+func (idx *Index) FindObject(id string) (string, *go3mf.Object, bool) {
+	e, ok := idx.Find(id)
+	if !ok {
+		return "", nil, false
+	}
+	var (
+		path  string
+		objID uint32
+	)
+	switch v := e.Value.(type) {
+	case *go3mf.Object:
+		return e.Path, v, true
+	case *go3mf.Item:
+		path, objID = v.ObjectPath(), v.ObjectID
+	case *go3mf.Component:
+		path, objID = v.ObjectPath(e.Path), v.ObjectID
+	case *BuildItem:
+		path, objID = v.Path, v.ObjectID
+	case *Alternative:
+		path, objID = v.Path, v.ObjectID
+		if path == "" {
+			path = e.Path
+		}
+	default:
+		return "", nil, false
+	}
+	obj, ok := idx.m.FindObject(path, objID)
+	if !ok {
+		return "", nil, false
+	}
+	if path == idx.m.PathOrDefault() {
+		path = ""
+	}
+	return path, obj, true
+}
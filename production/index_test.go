@@ -0,0 +1,99 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package production
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/go-test/deep"
+)
+
+func TestIndex_FindObject(t *testing.T) {
+	mesh := tetrahedron(1, 0)
+	mesh.AnyAttr = go3mf.AnyAttr{&ObjectAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d401"}}
+	assembly := &go3mf.Object{ID: 2, AnyAttr: go3mf.AnyAttr{&ObjectAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d402"}},
+		Components: &go3mf.Components{Component: []*go3mf.Component{
+			{ObjectID: 1, AnyAttr: go3mf.AnyAttr{&ComponentAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d403", Path: "/3D/a.model"}}},
+		}}}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{assembly}},
+		Build: go3mf.Build{AnyAttr: go3mf.AnyAttr{&BuildAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d404"}}, Items: []*go3mf.Item{
+			{ObjectID: 2, AnyAttr: go3mf.AnyAttr{&ItemAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d405"}}},
+			{ObjectID: 1, AnyAttr: go3mf.AnyAttr{&ItemAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d406", Path: "/3D/a.model"}}},
+			{ObjectID: 3, AnyAttr: go3mf.AnyAttr{&ItemAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d407"}}},
+		}},
+		Childs: map[string]*go3mf.ChildModel{"/3D/a.model": {Resources: go3mf.Resources{Objects: []*go3mf.Object{mesh}}}},
+	}
+	idx, err := NewIndex(m)
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+	if idx.Len() != 7 {
+		t.Errorf("Index.Len() = %d, want 7", idx.Len())
+	}
+	if e, ok := idx.Find("F47AC10B-58CC-0372-8567-0E02B2C3D403"); !ok || e.Path != "" || e.Parent != assembly {
+		t.Errorf("Index.Find() = %v, %t", e, ok)
+	}
+	tests := []struct {
+		name     string
+		id       string
+		wantPath string
+		want     *go3mf.Object
+		wantOk   bool
+	}{
+		{"build", "f47ac10b-58cc-0372-8567-0e02b2c3d404", "", nil, false},
+		{"object", "f47ac10b-58cc-0372-8567-0e02b2c3d401", "/3D/a.model", mesh, true},
+		{"component", "f47ac10b-58cc-0372-8567-0e02b2c3d403", "/3D/a.model", mesh, true},
+		{"itemRoot", "f47ac10b-58cc-0372-8567-0e02b2c3d405", "", assembly, true},
+		{"itemChild", "f47ac10b-58cc-0372-8567-0e02b2c3d406", "/3D/a.model", mesh, true},
+		{"itemMissingObject", "f47ac10b-58cc-0372-8567-0e02b2c3d407", "", nil, false},
+		{"unknown", "f47ac10b-58cc-0372-8567-0e02b2c3d408", "", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, obj, ok := idx.FindObject(tt.id)
+			if path != tt.wantPath || obj != tt.want || ok != tt.wantOk {
+				t.Errorf("Index.FindObject() = %s, %v, %t, want %s, %v, %t", path, obj, ok, tt.wantPath, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestNewIndex_duplicated(t *testing.T) {
+	// The objects use other forms of the UUID of the first item.
+	obj, braced, hex := tetrahedron(1, 0), tetrahedron(2, 0), tetrahedron(3, 0)
+	obj.AnyAttr = go3mf.AnyAttr{&ObjectAttr{UUID: "F47AC10B-58CC-0372-8567-0E02B2C3D401"}}
+	braced.AnyAttr = go3mf.AnyAttr{&ObjectAttr{UUID: "{f47ac10b-58cc-0372-8567-0e02b2c3d401}"}}
+	hex.AnyAttr = go3mf.AnyAttr{&ObjectAttr{UUID: "f47ac10b58cc037285670e02b2c3d401"}}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{obj, braced, hex}},
+		Build: go3mf.Build{Items: []*go3mf.Item{
+			{ObjectID: 1, AnyAttr: go3mf.AnyAttr{&ItemAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d401"}}},
+			{ObjectID: 1, AnyAttr: go3mf.AnyAttr{&ItemAttr{UUID: "a-b-c-d"}}},
+			{ObjectID: 1, AnyAttr: go3mf.AnyAttr{&ItemAttr{UUID: "a-b-c-d"}}},
+		}},
+	}
+	idx, err := NewIndex(m)
+	if !errors.Is(err, ErrDuplicatedUUID) {
+		t.Fatalf("NewIndex() error = %v, want %v", err, ErrDuplicatedUUID)
+	}
+	var got []string
+	for _, err := range err.(*specerr.List).Errors {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		"Resources@Object#0: " + ErrDuplicatedUUID.Error(),
+		"Resources@Object#1: " + ErrDuplicatedUUID.Error(),
+		"Resources@Object#2: " + ErrDuplicatedUUID.Error(),
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("NewIndex() error = %v", diff)
+	}
+	if e, _ := idx.Find("urn:uuid:f47ac10b-58cc-0372-8567-0e02b2c3d401"); e.Value != m.Build.Items[0] {
+		t.Errorf("Index.Find() = %v, want the first element", e)
+	}
+}
@@ -4,7 +4,11 @@
 package production
 
 import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
 	"errors"
+	"fmt"
 
 	"github.com/MosaicManufacturing/go3mf"
 	"github.com/MosaicManufacturing/go3mf/uuid"
@@ -22,11 +26,21 @@ var DefaultExtension = go3mf.Extension{
 var (
 	ErrUUID             = errors.New("UUID MUST be any of the four UUID variants described in IETF RFC 4122")
 	ErrProdRefInNonRoot = errors.New("non-root model file components MUST only reference objects in the same model file")
+	ErrDuplicatedUUID   = errors.New("UUID MUST be unique within the 3MF package")
 )
 
 const (
-	attrProdUUID = "UUID"
-	attrPath     = "path"
+	attrProdUUID        = "UUID"
+	attrPath            = "path"
+	attrAlternatives    = "alternatives"
+	attrAlternative     = "alternative"
+	attrObjectID        = "objectid"
+	attrModelResolution = "modelresolution"
+	attrBuild           = "build"
+	attrName            = "name"
+	attrItem            = "item"
+	attrTransform       = "transform"
+	attrPartNumber      = "partnumber"
 )
 
 type Spec struct{}
@@ -115,42 +129,250 @@ func (p *ComponentAttr) getUUID() string {
 	return p.UUID
 }
 
+// ModelResolution defines the resolution of an alternative object.
+type ModelResolution uint8
+
+// Supported model resolutions.
+const (
+	ModelResolutionFullRes ModelResolution = iota
+	ModelResolutionLowRes
+)
+
+func (m ModelResolution) String() string {
+	return map[ModelResolution]string{
+		ModelResolutionFullRes: "fullres",
+		ModelResolutionLowRes:  "lowres",
+	}[m]
+}
+
+// Alternative references an object that is an alternative representation
+// of the object that contains it, such as a low resolution version
+// or a version for a different process.
+type Alternative struct {
+	ObjectID        uint32
+	UUID            string
+	Path            string
+	ModelResolution ModelResolution
+}
+
+// ObjectPath returns the Path attribute.
+func (a *Alternative) ObjectPath() string {
+	return a.Path
+}
+
+func (a *Alternative) getUUID() string {
+	return a.UUID
+}
+
+// Alternatives defines the alternative representations of an object.
+type Alternatives struct {
+	Alternatives []Alternative
+}
+
+func GetAlternatives(obj *go3mf.Object) *Alternatives {
+	for _, a := range obj.Any {
+		if a, ok := a.(*Alternatives); ok {
+			return a
+		}
+	}
+	return nil
+}
+
+// References returns the IDs of the objects of the same model file referenced by a.
+func (a *Alternatives) References() []uint32 {
+	var refs []uint32
+	for _, alt := range a.Alternatives {
+		if alt.Path == "" && alt.ObjectID != 0 {
+			refs = append(refs, alt.ObjectID)
+		}
+	}
+	return refs
+}
+
+// Remap replaces the paths and IDs of the referenced objects with the ones returned by fn.
+func (a *Alternatives) Remap(fn func(path string, id uint32) (string, uint32)) {
+	for i, alt := range a.Alternatives {
+		a.Alternatives[i].Path, a.Alternatives[i].ObjectID = fn(alt.Path, alt.ObjectID)
+	}
+}
+
+// BuildItem is an item of a BuildDefinition.
+type BuildItem struct {
+	ObjectID   uint32
+	UUID       string
+	Path       string
+	PartNumber string
+	Transform  go3mf.Matrix
+}
+
+// ObjectPath returns the Path attribute.
+func (b *BuildItem) ObjectPath() string {
+	return b.Path
+}
+
+func (b *BuildItem) getUUID() string {
+	return b.UUID
+}
+
+// HasTransform returns true if the transform is different than the identity.
+func (b *BuildItem) HasTransform() bool {
+	return b.Transform != go3mf.Matrix{} && b.Transform != go3mf.Identity()
+}
+
+// BuildDefinition is a build of the root model additional to the core one,
+// such as the build for a different process or printer.
+type BuildDefinition struct {
+	UUID  string
+	Name  string
+	Items []BuildItem
+}
+
+// GetBuildDefinitions returns the additional build definitions of m.
+func GetBuildDefinitions(m *go3mf.Model) []*BuildDefinition {
+	var defs []*BuildDefinition
+	for _, a := range m.Any {
+		if a, ok := a.(*BuildDefinition); ok {
+			defs = append(defs, a)
+		}
+	}
+	return defs
+}
+
 // SetMissingUUIDs traverse all the model tree setting
 // all missing UUID attributes.
 func SetMissingUUIDs(m *go3mf.Model) {
+	setMissingUUIDs(m, func(func() []byte) string { return uuid.New() })
+}
+
+// SetMissingStableUUIDs traverse all the model tree setting all missing
+// UUID attributes to name-based UUIDs derived from namespace, so exporting
+// the same model again generates the same UUIDs.
+//
+// The object UUIDs are derived from their model path, ID, name, part number
+// and mesh geometry, the component and alternative UUIDs from their object and index,
+// and the build, build definition and item UUIDs from the root model path
+// and the content of all the objects of the model, so different models do not share them.
+// It returns an error if namespace is not a valid UUID.
+func SetMissingStableUUIDs(m *go3mf.Model, namespace string) error {
+	if err := uuid.Validate(namespace); err != nil {
+		return err
+	}
+	setMissingUUIDs(m, func(name func() []byte) string {
+		id, _ := uuid.NewSHA1(namespace, name())
+		return id
+	})
+	return nil
+}
+
+func setMissingUUIDs(m *go3mf.Model, newUUID func(name func() []byte) string) {
+	var digest []byte
+	modelName := func() []byte {
+		if digest == nil {
+			h := sha1.New()
+			h.Write([]byte(m.PathOrDefault()))
+			m.WalkObjects(func(s string, obj *go3mf.Object) error {
+				h.Write(objectName(s, obj))
+				return nil
+			})
+			digest = h.Sum(nil)
+		}
+		return digest
+	}
 	if GetBuildAttr(&m.Build) == nil {
-		m.Build.AnyAttr = append(m.Build.AnyAttr, &BuildAttr{UUID: uuid.New()})
+		m.Build.AnyAttr = append(m.Build.AnyAttr, &BuildAttr{UUID: newUUID(func() []byte {
+			return []byte(fmt.Sprintf("build:%x", modelName()))
+		})})
 	}
-	for _, item := range m.Build.Items {
+	for i, item := range m.Build.Items {
+		name := func() []byte {
+			return []byte(fmt.Sprintf("item:%x:%d:%s:%d", modelName(), i, item.ObjectPath(), item.ObjectID))
+		}
 		ext := GetItemAttr(item)
 		if ext == nil {
 			item.AnyAttr = append(item.AnyAttr, &ItemAttr{
-				UUID: uuid.New(),
+				UUID: newUUID(name),
 			})
 		} else if ext.UUID == "" {
-			ext.UUID = uuid.New()
+			ext.UUID = newUUID(name)
+		}
+	}
+	for i, b := range GetBuildDefinitions(m) {
+		if b.UUID == "" {
+			b.UUID = newUUID(func() []byte {
+				return []byte(fmt.Sprintf("builddefinition:%x:%d:%s", modelName(), i, b.Name))
+			})
+		}
+		for j := range b.Items {
+			item := &b.Items[j]
+			if item.UUID == "" {
+				item.UUID = newUUID(func() []byte {
+					return []byte(fmt.Sprintf("builditem:%x:%d:%d:%s:%d", modelName(), i, j, item.Path, item.ObjectID))
+				})
+			}
 		}
 	}
 	m.WalkObjects(func(s string, obj *go3mf.Object) error {
+		oname := func() []byte {
+			return objectName(s, obj)
+		}
 		oext := GetObjectAttr(obj)
 		if oext == nil {
-			obj.AnyAttr = append(obj.AnyAttr, &ObjectAttr{UUID: uuid.New()})
+			obj.AnyAttr = append(obj.AnyAttr, &ObjectAttr{UUID: newUUID(oname)})
 		} else if oext.UUID == "" {
-			oext.UUID = uuid.New()
+			oext.UUID = newUUID(oname)
 		}
 		if obj.Components != nil {
-			for _, c := range obj.Components.Component {
+			for i, c := range obj.Components.Component {
+				name := func() []byte {
+					return []byte(fmt.Sprintf("component:%s:%d:%d:%s:%d", s, obj.ID, i, c.ObjectPath(s), c.ObjectID))
+				}
 				ext := GetComponentAttr(c)
 				if ext == nil {
 					c.AnyAttr = append(c.AnyAttr, &ComponentAttr{
-						UUID: uuid.New(),
+						UUID: newUUID(name),
 					})
 				} else if ext.UUID == "" {
-					ext.UUID = uuid.New()
+					ext.UUID = newUUID(name)
+				}
+			}
+		}
+		if alts := GetAlternatives(obj); alts != nil {
+			for i := range alts.Alternatives {
+				alt := &alts.Alternatives[i]
+				if alt.UUID == "" {
+					alt.UUID = newUUID(func() []byte {
+						return []byte(fmt.Sprintf("alternative:%s:%d:%d:%s:%d", s, obj.ID, i, alt.Path, alt.ObjectID))
+					})
 				}
 			}
 		}
 		return nil
 	})
+}
+
+// objectName returns the content that identifies obj in path.
+func objectName(path string, obj *go3mf.Object) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "object:%s:%d:%s:%s", path, obj.ID, obj.Name, obj.PartNumber)
+	if obj.Mesh != nil {
+		coords := make([]float32, 0, 3*len(obj.Mesh.Vertices))
+		for _, v := range obj.Mesh.Vertices {
+			coords = append(coords, v[:]...)
+		}
+		indices := make([]uint32, 0, 3*len(obj.Mesh.Triangles))
+		for _, t := range obj.Mesh.Triangles {
+			indices = append(indices, t.V1, t.V2, t.V3)
+		}
+		binary.Write(&buf, binary.LittleEndian, coords)
+		binary.Write(&buf, binary.LittleEndian, indices)
+	}
+	return buf.Bytes()
+}
+
+func newModelResolution(s string) (r ModelResolution, ok bool) {
+	r, ok = map[string]ModelResolution{
+		"fullres": ModelResolutionFullRes,
+		"lowres":  ModelResolutionLowRes,
+	}[s]
 	return
 }
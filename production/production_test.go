@@ -8,6 +8,7 @@ import (
 
 	"github.com/MosaicManufacturing/go3mf"
 	"github.com/MosaicManufacturing/go3mf/spec"
+	"github.com/go-test/deep"
 )
 
 var _ spec.MarshalerAttr = new(BuildAttr)
@@ -75,3 +76,76 @@ func TestSetMissingUUIDs(t *testing.T) {
 		t.Errorf("SetMissingUUIDs() should have filled object attrs")
 	}
 }
+
+func TestSetMissingUUIDs_alternativesAndBuilds(t *testing.T) {
+	newModel := func() *go3mf.Model {
+		obj := tetrahedron(1, 0)
+		obj.Any = go3mf.Any{&Alternatives{Alternatives: []Alternative{{ObjectID: 2, ModelResolution: ModelResolutionLowRes}}}}
+		return &go3mf.Model{
+			Extensions: []go3mf.Extension{DefaultExtension},
+			Resources:  go3mf.Resources{Objects: []*go3mf.Object{obj, tetrahedron(2, 0)}},
+			Build:      go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1}}},
+			Any:        go3mf.Any{&BuildDefinition{Name: "process B", Items: []BuildItem{{ObjectID: 1}, {ObjectID: 2}}}},
+		}
+	}
+	tests := []struct {
+		name string
+		fn   func(*go3mf.Model) error
+	}{
+		{"random", func(m *go3mf.Model) error { SetMissingUUIDs(m); return nil }},
+		{"stable", func(m *go3mf.Model) error { return SetMissingStableUUIDs(m, "6ba7b810-9dad-11d1-80b4-00c04fd430c8") }},
+		{"split", Split},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel()
+			if err := tt.fn(m); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if err := m.Validate(); err != nil {
+				t.Errorf("Model.Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSetMissingStableUUIDs(t *testing.T) {
+	const ns = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	newModel := func() *go3mf.Model {
+		return &go3mf.Model{
+			Resources: go3mf.Resources{Objects: []*go3mf.Object{
+				tetrahedron(1, 0),
+				{ID: 2, Components: &go3mf.Components{Component: []*go3mf.Component{{ObjectID: 1}, {ObjectID: 1}}}},
+			}},
+			Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 2}, {ObjectID: 2}}},
+		}
+	}
+	m1, m2 := newModel(), newModel()
+	if err := SetMissingStableUUIDs(m1, ns); err != nil {
+		t.Fatalf("SetMissingStableUUIDs() error = %v", err)
+	}
+	if err := SetMissingStableUUIDs(m2, ns); err != nil {
+		t.Fatalf("SetMissingStableUUIDs() error = %v", err)
+	}
+	if diff := deep.Equal(m1, m2); diff != nil {
+		t.Errorf("SetMissingStableUUIDs() is not deterministic: %v", diff)
+	}
+	if _, err := NewIndex(m1); err != nil {
+		t.Errorf("SetMissingStableUUIDs() generated duplicated UUIDs: %v", err)
+	}
+	m3 := newModel()
+	m3.Resources.Objects[0].Mesh.Vertices[0] = go3mf.Point3D{1, 1, 1}
+	SetMissingStableUUIDs(m3, ns)
+	if GetObjectAttr(m3.Resources.Objects[0]).UUID == GetObjectAttr(m1.Resources.Objects[0]).UUID {
+		t.Errorf("SetMissingStableUUIDs() ignored the mesh content")
+	}
+	if GetBuildAttr(&m3.Build).UUID == GetBuildAttr(&m1.Build).UUID {
+		t.Errorf("SetMissingStableUUIDs() generated the same build UUID for different models")
+	}
+	if GetItemAttr(m3.Build.Items[0]).UUID == GetItemAttr(m1.Build.Items[0]).UUID {
+		t.Errorf("SetMissingStableUUIDs() generated the same item UUID for different models")
+	}
+	if err := SetMissingStableUUIDs(newModel(), "a-b-c-d"); err == nil {
+		t.Errorf("SetMissingStableUUIDs() expected error")
+	}
+}
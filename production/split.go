@@ -0,0 +1,297 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package production
+
+import (
+	"fmt"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/internal/reference"
+)
+
+// Referencer is implemented by the assets, and by the extension elements
+// and attributes of objects, meshes and base materials, that reference
+// other resources of their model file by ID.
+type Referencer interface {
+	References() []uint32
+}
+
+// Split moves each mesh object referenced from the root build items,
+// the build definitions and the assemblies into its own child model, together with the resources it uses,
+// and rewrites the root items and components to reference it by path.
+// Objects with components stay in the root model, as non-root model files
+// cannot reference other files.
+//
+// The resources keep their IDs. Assets used by several objects are shared by
+// the child models: the same asset is appended to the resources of each of them,
+// so modifying it through one child modifies it in all of them, and Consolidate
+// moves it back only once. Objects used by several children are copied
+// without their UUID. The resources left unused in the root model are removed,
+// the production extension is added to the model and SetMissingUUIDs is called.
+func Split(m *go3mf.Model) error {
+	s := splitter{m: m, units: make(map[uint32]bool), assemblies: make(map[uint32]bool)}
+	for i, item := range m.Build.Items {
+		if !s.isRoot(item.ObjectPath()) {
+			continue
+		}
+		if err := s.visit(item.ObjectID); err != nil {
+			return specerr.Wrap(specerr.WrapIndex(err, item, i), m.Build)
+		}
+	}
+	for i, b := range GetBuildDefinitions(m) {
+		for j, item := range b.Items {
+			if !s.isRoot(item.Path) {
+				continue
+			}
+			if err := s.visit(item.ObjectID); err != nil {
+				return specerr.WrapIndex(specerr.WrapIndex(err, item, j), b, i)
+			}
+		}
+	}
+	if len(s.order) == 0 {
+		return nil
+	}
+	paths := make([]string, len(s.order))
+	deps := make([]map[uint32]bool, len(s.order))
+	if m.Childs == nil {
+		m.Childs = make(map[string]*go3mf.ChildModel)
+	}
+	inChild := make(map[uint32]bool)
+	for i, id := range s.order {
+		paths[i] = s.childPath(id)
+		m.Childs[paths[i]] = new(go3mf.ChildModel)
+		deps[i] = s.closure([]uint32{id}, false)
+		for dep := range deps[i] {
+			inChild[dep] = true
+		}
+	}
+	// The assemblies and the objects not used by any child model stay in the root,
+	// together with the resources they use. The components of the latter are not
+	// rewritten, so the objects they reference stay too.
+	var assemblies, others []uint32
+	for _, o := range m.Resources.Objects {
+		if s.assemblies[o.ID] {
+			assemblies = append(assemblies, o.ID)
+		} else if !inChild[o.ID] {
+			others = append(others, o.ID)
+		}
+	}
+	rootDeps := s.closure(assemblies, true)
+	for id := range s.closure(others, false) {
+		rootDeps[id] = true
+	}
+	owners := make(map[uint32]string)
+	for id := range rootDeps {
+		owners[id] = ""
+	}
+	for i, id := range s.order {
+		if _, ok := owners[id]; !ok {
+			owners[id] = paths[i]
+		}
+	}
+	moved := make(map[uint32]bool)
+	for i := range s.order {
+		child := m.Childs[paths[i]]
+		for _, a := range m.Resources.Assets {
+			if deps[i][a.Identify()] {
+				child.Resources.Assets = append(child.Resources.Assets, a)
+				moved[a.Identify()] = true
+			}
+		}
+		for _, o := range m.Resources.Objects {
+			if !deps[i][o.ID] {
+				continue
+			}
+			moved[o.ID] = true
+			if owner, ok := owners[o.ID]; !ok {
+				owners[o.ID] = paths[i]
+			} else if owner != paths[i] {
+				o = cloneObject(o)
+			}
+			child.Resources.Objects = append(child.Resources.Objects, o)
+		}
+	}
+	s.rewrite(paths)
+	s.removeMoved(moved, rootDeps)
+	var hasExt bool
+	for _, ext := range m.Extensions {
+		if ext.Namespace == Namespace {
+			hasExt = true
+			break
+		}
+	}
+	if !hasExt {
+		m.Extensions = append(m.Extensions, DefaultExtension)
+	}
+	SetMissingUUIDs(m)
+	return nil
+}
+
+type splitter struct {
+	m          *go3mf.Model
+	order      []uint32
+	units      map[uint32]bool
+	assemblies map[uint32]bool
+}
+
+func (s *splitter) isRoot(path string) bool {
+	return path == "" || path == s.m.PathOrDefault()
+}
+
+// visit collects the mesh objects reachable from the root object id.
+func (s *splitter) visit(id uint32) error {
+	obj, ok := s.m.Resources.FindObject(id)
+	if !ok {
+		return specerr.ErrMissingResource
+	}
+	if obj.Components == nil {
+		if !s.units[id] {
+			s.units[id] = true
+			s.order = append(s.order, id)
+		}
+		return nil
+	}
+	if s.assemblies[id] {
+		return nil
+	}
+	s.assemblies[id] = true
+	for i, c := range obj.Components.Component {
+		if !s.isRoot(c.ObjectPath("")) {
+			continue
+		}
+		if err := s.visit(c.ObjectID); err != nil {
+			return specerr.Wrap(specerr.WrapIndex(err, c, i), obj)
+		}
+	}
+	return nil
+}
+
+// closure returns the IDs of the root resources reachable from ids.
+// If skipUnits is true the components referencing the moved objects are not followed.
+func (s *splitter) closure(ids []uint32, skipUnits bool) map[uint32]bool {
+	seen := make(map[uint32]bool)
+	for len(ids) > 0 {
+		id := ids[len(ids)-1]
+		ids = ids[:len(ids)-1]
+		if seen[id] {
+			continue
+		}
+		if obj, ok := s.m.Resources.FindObject(id); ok {
+			seen[id] = true
+			ids = append(ids, reference.Object(obj)...)
+			if obj.Components != nil {
+				for _, c := range obj.Components.Component {
+					if s.isRoot(c.ObjectPath("")) && (!skipUnits || !s.units[c.ObjectID]) {
+						ids = append(ids, c.ObjectID)
+					}
+				}
+			}
+		} else if a, ok := s.m.Resources.FindAsset(id); ok {
+			seen[id] = true
+			ids = append(ids, reference.Asset(a)...)
+		}
+	}
+	return seen
+}
+
+// childPath returns an unused path for the child model of the object id.
+func (s *splitter) childPath(id uint32) string {
+	path := fmt.Sprintf("/3D/Objects/object_%d.model", id)
+	for i := 2; s.m.Childs[path] != nil || path == s.m.PathOrDefault(); i++ {
+		path = fmt.Sprintf("/3D/Objects/object_%d_%d.model", id, i)
+	}
+	return path
+}
+
+// rewrite references the moved objects from the root items and assemblies.
+func (s *splitter) rewrite(paths []string) {
+	pathOf := make(map[uint32]string, len(s.order))
+	for i, id := range s.order {
+		pathOf[id] = paths[i]
+	}
+	for _, item := range s.m.Build.Items {
+		if path, ok := pathOf[item.ObjectID]; ok && s.isRoot(item.ObjectPath()) {
+			if attr := GetItemAttr(item); attr != nil {
+				attr.Path = path
+			} else {
+				item.AnyAttr = append(item.AnyAttr, &ItemAttr{Path: path})
+			}
+		}
+	}
+	for _, b := range GetBuildDefinitions(s.m) {
+		for i, item := range b.Items {
+			if path, ok := pathOf[item.ObjectID]; ok && s.isRoot(item.Path) {
+				b.Items[i].Path = path
+			}
+		}
+	}
+	for _, obj := range s.m.Resources.Objects {
+		if !s.assemblies[obj.ID] {
+			continue
+		}
+		for _, c := range obj.Components.Component {
+			if path, ok := pathOf[c.ObjectID]; ok && s.isRoot(c.ObjectPath("")) {
+				if attr := GetComponentAttr(c); attr != nil {
+					attr.Path = path
+				} else {
+					c.AnyAttr = append(c.AnyAttr, &ComponentAttr{Path: path})
+				}
+			}
+		}
+	}
+}
+
+// removeMoved removes from the root model the moved resources it does not use.
+func (s *splitter) removeMoved(moved, used map[uint32]bool) {
+	res := &s.m.Resources
+	assets := res.Assets[:0]
+	for _, a := range res.Assets {
+		if id := a.Identify(); !moved[id] || used[id] {
+			assets = append(assets, a)
+		}
+	}
+	res.Assets = assets
+	objects := res.Objects[:0]
+	for _, o := range res.Objects {
+		if !moved[o.ID] || used[o.ID] {
+			objects = append(objects, o)
+		}
+	}
+	res.Objects = objects
+}
+
+// cloneObject returns a shallow copy of obj without the production UUIDs.
+func cloneObject(obj *go3mf.Object) *go3mf.Object {
+	c := *obj
+	c.AnyAttr = withoutUUID(obj.AnyAttr)
+	if obj.Components != nil {
+		comps := *obj.Components
+		comps.Component = make([]*go3mf.Component, len(obj.Components.Component))
+		for i, comp := range obj.Components.Component {
+			cc := *comp
+			cc.AnyAttr = withoutUUID(comp.AnyAttr)
+			comps.Component[i] = &cc
+		}
+		c.Components = &comps
+	}
+	return &c
+}
+
+func withoutUUID(attrs go3mf.AnyAttr) go3mf.AnyAttr {
+	var out go3mf.AnyAttr
+	for _, a := range attrs {
+		switch a := a.(type) {
+		case *ObjectAttr:
+			continue
+		case *ComponentAttr:
+			if a.Path != "" {
+				out = append(out, &ComponentAttr{Path: a.Path})
+			}
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
@@ -0,0 +1,197 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package production
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/MosaicManufacturing/go3mf/spec"
+	"github.com/go-test/deep"
+)
+
+func tetrahedron(id, pid uint32) *go3mf.Object {
+	return &go3mf.Object{ID: id, PID: pid, Mesh: &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+		Triangles: []go3mf.Triangle{
+			{V1: 0, V2: 2, V3: 1}, {V1: 0, V2: 1, V3: 3}, {V1: 1, V2: 2, V3: 3}, {V1: 0, V2: 3, V3: 2},
+		},
+	}}
+}
+
+func TestSplit(t *testing.T) {
+	colors := &materials.ColorGroup{ID: 5, Colors: []color.RGBA{{R: 255, A: 255}}}
+	unused := &go3mf.BaseMaterials{ID: 7, Materials: []go3mf.Base{{Name: "a", Color: color.RGBA{A: 255}}}}
+	obj1, obj2 := tetrahedron(1, 5), tetrahedron(2, 0)
+	obj2.Mesh.Triangles[0].PID, obj2.Mesh.Triangles[0].P1, obj2.Mesh.Triangles[0].P2, obj2.Mesh.Triangles[0].P3 = 5, 0, 0, 0
+	assembly := &go3mf.Object{ID: 3, Components: &go3mf.Components{Component: []*go3mf.Component{
+		{ObjectID: 2}, {ObjectID: 2, Transform: go3mf.Identity().Translate(2, 0, 0)},
+	}}}
+	m := &go3mf.Model{
+		Extensions: []go3mf.Extension{materials.DefaultExtension},
+		Resources: go3mf.Resources{
+			Assets:  []go3mf.Asset{colors, unused},
+			Objects: []*go3mf.Object{obj1, obj2, assembly},
+		},
+		Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1}, {ObjectID: 3}, {ObjectID: 1}}},
+	}
+	if err := Split(m); err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	const path1, path2 = "/3D/Objects/object_1.model", "/3D/Objects/object_2.model"
+	if diff := deep.Equal(m.Resources, go3mf.Resources{Assets: []go3mf.Asset{unused}, Objects: []*go3mf.Object{assembly}}); diff != nil {
+		t.Errorf("Split() root resources = %v", diff)
+	}
+	if len(m.Childs) != 2 {
+		t.Fatalf("Split() childs = %v", m.Childs)
+	}
+	if diff := deep.Equal(m.Childs[path1].Resources, go3mf.Resources{Assets: []go3mf.Asset{colors}, Objects: []*go3mf.Object{obj1}}); diff != nil {
+		t.Errorf("Split() child 1 = %v", diff)
+	}
+	if diff := deep.Equal(m.Childs[path2].Resources, go3mf.Resources{Assets: []go3mf.Asset{colors}, Objects: []*go3mf.Object{obj2}}); diff != nil {
+		t.Errorf("Split() child 2 = %v", diff)
+	}
+	if m.Childs[path1].Resources.Assets[0] != m.Childs[path2].Resources.Assets[0] {
+		t.Error("Split() assets used by several children should be shared")
+	}
+	for i, want := range []string{path1, "", path1} {
+		if got := m.Build.Items[i].ObjectPath(); got != want {
+			t.Errorf("Split() item %d path = %v, want %v", i, got, want)
+		}
+	}
+	for i, c := range assembly.Components.Component {
+		if got := c.ObjectPath(""); got != path2 {
+			t.Errorf("Split() component %d path = %v, want %v", i, got, path2)
+		}
+	}
+	if m.Extensions[1] != DefaultExtension {
+		t.Errorf("Split() extensions = %v", m.Extensions)
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("Split() produced an invalid model: %v", err)
+	}
+}
+
+func TestSplit_sharedObject(t *testing.T) {
+	// Object 4 is the clipping mesh of both objects,
+	// the second child gets a copy with a different UUID.
+	obj1, obj2, clip := tetrahedron(1, 0), tetrahedron(2, 0), tetrahedron(4, 0)
+	clip.AnyAttr = append(clip.AnyAttr, &ObjectAttr{UUID: "cb828b1d-1f34-4d5e-8f1a-3c5f1e8a1a33"})
+	obj1.Any = append(obj1.Any, &clipRef{4})
+	obj2.Any = append(obj2.Any, &clipRef{4})
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{obj1, obj2, clip}},
+		Build:     go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1}, {ObjectID: 2}}},
+	}
+	if err := Split(m); err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	if len(m.Resources.Objects) != 0 {
+		t.Errorf("Split() root objects = %v", m.Resources.Objects)
+	}
+	c1 := m.Childs["/3D/Objects/object_1.model"].Resources.Objects
+	c2 := m.Childs["/3D/Objects/object_2.model"].Resources.Objects
+	if len(c1) != 2 || len(c2) != 2 || c1[1] != clip || c2[1] == clip {
+		t.Fatalf("Split() = %v, %v", c1, c2)
+	}
+	if diff := deep.Equal(c2[1].Mesh, clip.Mesh); diff != nil {
+		t.Errorf("Split() copy = %v", diff)
+	}
+	if GetObjectAttr(c2[1]).UUID == GetObjectAttr(clip).UUID {
+		t.Errorf("Split() copy kept the UUID")
+	}
+}
+
+// clipRef is an extension element referencing a resource.
+type clipRef struct {
+	id uint32
+}
+
+func (c *clipRef) Marshal3MF(spec.Encoder) error { return nil }
+
+func (c *clipRef) References() []uint32 { return []uint32{c.id} }
+
+func TestSplit_keepsRootReferences(t *testing.T) {
+	// The assembly references object 1 as a component and
+	// through an extension element, so the root keeps it.
+	obj1 := tetrahedron(1, 0)
+	assembly := &go3mf.Object{ID: 2, Any: go3mf.Any{&clipRef{1}}, Components: &go3mf.Components{Component: []*go3mf.Component{{ObjectID: 1}}}}
+	m := &go3mf.Model{
+		Path:      "/3D/model.model",
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{obj1, assembly}},
+		Build:     go3mf.Build{Items: []*go3mf.Item{{ObjectID: 2}}},
+		Childs:    map[string]*go3mf.ChildModel{"/3D/Objects/object_1.model": new(go3mf.ChildModel)},
+	}
+	if err := Split(m); err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	child, ok := m.Childs["/3D/Objects/object_1_2.model"]
+	if !ok {
+		t.Fatalf("Split() childs = %v", m.Childs)
+	}
+	if len(m.Resources.Objects) != 2 || m.Resources.Objects[0] != obj1 || child.Resources.Objects[0] == obj1 {
+		t.Errorf("Split() = %v, %v", m.Resources.Objects, child.Resources.Objects)
+	}
+	if got := assembly.Components.Component[0].ObjectPath(""); got != "/3D/Objects/object_1_2.model" {
+		t.Errorf("Split() component path = %v", got)
+	}
+}
+
+func TestSplit_keepsUnbuiltObjects(t *testing.T) {
+	// Object 3 is not built but stays in the root, so it keeps
+	// using the base materials moved with object 2.
+	base := &go3mf.BaseMaterials{ID: 1, Materials: []go3mf.Base{{Name: "a", Color: color.RGBA{A: 255}}}}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{
+			Assets:  []go3mf.Asset{base},
+			Objects: []*go3mf.Object{tetrahedron(2, 1), tetrahedron(3, 1)},
+		},
+		Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 2}}},
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatalf("Model.Validate() before Split() error = %v", err)
+	}
+	if err := Split(m); err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("Model.Validate() after Split() error = %v", err)
+	}
+	if len(m.Resources.Assets) != 1 || len(m.Resources.Objects) != 1 || m.Resources.Objects[0].ID != 3 {
+		t.Errorf("Split() = %v", m.Resources)
+	}
+}
+
+func TestSplit_error(t *testing.T) {
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Components: &go3mf.Components{Component: []*go3mf.Component{{ObjectID: 2}}}},
+		}},
+		Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1}}},
+	}
+	err := Split(m)
+	if !errors.Is(err, specerr.ErrMissingResource) {
+		t.Fatalf("Split() error = %v, want %v", err, specerr.ErrMissingResource)
+	}
+	if want := "Build@Item#0@Object@Component#0: " + specerr.ErrMissingResource.Error(); err.Error() != want {
+		t.Errorf("Split() error = %v, want %v", err, want)
+	}
+	if len(m.Childs) != 0 || len(m.Extensions) != 0 {
+		t.Errorf("Split() modified the model")
+	}
+}
+
+func TestSplit_empty(t *testing.T) {
+	m := &go3mf.Model{Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1, AnyAttr: go3mf.AnyAttr{&ItemAttr{Path: "/other.model"}}}}}}
+	if err := Split(m); err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	if len(m.Childs) != 0 || len(m.Extensions) != 0 {
+		t.Errorf("Split() modified the model")
+	}
+}
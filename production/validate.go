@@ -44,7 +44,26 @@ func validateModel(m *go3mf.Model) error {
 			errs = errors.Append(errs, errors.Wrap(errors.WrapIndex(iErrs, item, i), m.Build))
 		}
 	}
-	return errs
+	for i, b := range GetBuildDefinitions(m) {
+		var bErrs error
+		if b.UUID == "" {
+			bErrs = errors.Append(bErrs, errors.NewMissingFieldError(attrProdUUID))
+		} else if uuid.Validate(b.UUID) != nil {
+			bErrs = errors.Append(bErrs, ErrUUID)
+		}
+		for j := range b.Items {
+			item := &b.Items[j]
+			err := validatePathUUID(m, "", item)
+			err = errors.Append(err, validateObjectRef(m, item.Path, item.ObjectID))
+			if obj, ok := m.FindObject(item.Path, item.ObjectID); ok && obj.Type == go3mf.ObjectTypeOther {
+				err = errors.Append(err, errors.ErrOtherItem)
+			}
+			bErrs = errors.Append(bErrs, errors.WrapIndex(err, *item, j))
+		}
+		errs = errors.Append(errs, errors.WrapIndex(bErrs, b, i))
+	}
+	_, err := NewIndex(m)
+	return errors.Append(errs, err)
 }
 
 func validateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
@@ -72,9 +91,36 @@ func validateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
 			errs = errors.Append(errs, errors.Wrap(cErrs, obj.Components))
 		}
 	}
+	if alts := GetAlternatives(obj); alts != nil {
+		var aErrs error
+		for i := range alts.Alternatives {
+			alt := &alts.Alternatives[i]
+			err := validatePathUUID(m, path, alt)
+			refPath := alt.Path
+			if refPath == "" {
+				refPath = path
+			}
+			err = errors.Append(err, validateObjectRef(m, refPath, alt.ObjectID))
+			if alt.ObjectID == obj.ID && (alt.Path == "" || alt.Path == path) {
+				err = errors.Append(err, errors.ErrRecursion)
+			}
+			aErrs = errors.Append(aErrs, errors.WrapIndex(err, *alt, i))
+		}
+		errs = errors.Append(errs, errors.Wrap(aErrs, alts))
+	}
 	return errs
 }
 
+func validateObjectRef(m *go3mf.Model, path string, id uint32) error {
+	if id == 0 {
+		return errors.NewMissingFieldError(attrObjectID)
+	}
+	if _, ok := m.FindObject(path, id); !ok {
+		return errors.ErrMissingResource
+	}
+	return nil
+}
+
 func validatePathUUID(m *go3mf.Model, path string, p uuidPath) error {
 	var errs error
 	if p.getUUID() == "" {
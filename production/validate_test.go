@@ -74,6 +74,46 @@ func TestValidate(t *testing.T) {
 			fmt.Sprintf("Resources@Object#1@Components@Component#1: %v", ErrUUID),
 			fmt.Sprintf("Resources@Object#1@Components@Component#2: %v", &errors.MissingFieldError{Name: attrProdUUID}),
 		}},
+		{"duplicatedUUID", &go3mf.Model{Build: go3mf.Build{
+			AnyAttr: go3mf.AnyAttr{&BuildAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d479"}}, Items: []*go3mf.Item{
+				{ObjectID: 1, AnyAttr: go3mf.AnyAttr{&ItemAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d479"}}},
+			}},
+			Resources: go3mf.Resources{Objects: []*go3mf.Object{{ID: 1, Mesh: validMesh.Mesh, AnyAttr: go3mf.AnyAttr{
+				&ObjectAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d479"}}}}}}, []string{
+			fmt.Sprintf("Build@Item#0: %v", ErrDuplicatedUUID),
+			fmt.Sprintf("Resources@Object#0: %v", ErrDuplicatedUUID),
+		}},
+		{"alternatives", &go3mf.Model{Build: go3mf.Build{AnyAttr: go3mf.AnyAttr{&BuildAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d479"}}},
+			Resources: go3mf.Resources{Objects: []*go3mf.Object{
+				{ID: 1, Mesh: validMesh.Mesh, AnyAttr: go3mf.AnyAttr{&ObjectAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d480"}},
+					Any: go3mf.Any{&Alternatives{Alternatives: []Alternative{
+						{ObjectID: 2, UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d481"},
+						{UUID: "a-b-c-d"},
+						{ObjectID: 1, UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d482"},
+						{ObjectID: 3, UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d483"},
+					}}}},
+				{ID: 2, Mesh: validMesh.Mesh, AnyAttr: go3mf.AnyAttr{&ObjectAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d484"}}},
+			}}}, []string{
+			fmt.Sprintf("Resources@Object#0@Alternatives@Alternative#1: %v", ErrUUID),
+			fmt.Sprintf("Resources@Object#0@Alternatives@Alternative#1: %v", &errors.MissingFieldError{Name: attrObjectID}),
+			fmt.Sprintf("Resources@Object#0@Alternatives@Alternative#2: %v", errors.ErrRecursion),
+			fmt.Sprintf("Resources@Object#0@Alternatives@Alternative#3: %v", errors.ErrMissingResource),
+		}},
+		{"buildDefinitions", &go3mf.Model{Build: go3mf.Build{AnyAttr: go3mf.AnyAttr{&BuildAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d479"}}},
+			Resources: go3mf.Resources{Objects: []*go3mf.Object{
+				{ID: 1, Mesh: validMesh.Mesh, AnyAttr: go3mf.AnyAttr{&ObjectAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d480"}}},
+				{ID: 2, Type: go3mf.ObjectTypeOther, Mesh: validMesh.Mesh, AnyAttr: go3mf.AnyAttr{&ObjectAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d481"}}},
+			}},
+			Any: go3mf.Any{&BuildDefinition{Items: []BuildItem{
+				{ObjectID: 1, UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d482"},
+				{ObjectID: 2, UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d483"},
+				{ObjectID: 3},
+			}}}}, []string{
+			fmt.Sprintf("BuildDefinition#0: %v", &errors.MissingFieldError{Name: attrProdUUID}),
+			fmt.Sprintf("BuildDefinition#0@BuildItem#1: %v", errors.ErrOtherItem),
+			fmt.Sprintf("BuildDefinition#0@BuildItem#2: %v", &errors.MissingFieldError{Name: attrProdUUID}),
+			fmt.Sprintf("BuildDefinition#0@BuildItem#2: %v", errors.ErrMissingResource),
+		}},
 		{"child", &go3mf.Model{Build: go3mf.Build{AnyAttr: go3mf.AnyAttr{&BuildAttr{UUID: "f47ac10b-58cc-0372-8567-0e02b2c3d479"}}},
 			Childs: map[string]*go3mf.ChildModel{
 				"/b.model": {Resources: go3mf.Resources{Objects: []*go3mf.Object{validMesh}}},
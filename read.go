@@ -34,6 +34,16 @@ type packageReader interface {
 	Open(func(r io.Reader) io.ReadCloser) error
 	FindFileFromName(string) (packageFile, bool)
 	Relationships() []Relationship
+	Files() []packageFile
+}
+
+// A PartReader processes the raw content of the package parts
+// before they are decoded, e.g. to decrypt them.
+type PartReader interface {
+	// ReadPart returns the content of the part located at path.
+	// When called, m already contains the package relationships and attachments.
+	// The KeyStore part is removed from m once the package is decoded.
+	ReadPart(m *Model, path string, r io.Reader) (io.Reader, error)
 }
 
 // ReadCloser wrapps a Decoder than can be closed.
@@ -157,6 +167,9 @@ type Decoder struct {
 	p             packageReader
 	flate         func(r io.Reader) io.ReadCloser
 	nonRootModels []packageFile
+	partReader    PartReader
+	attFilter     func(path string) bool
+	lazy          bool
 }
 
 // NewDecoder returns a new Decoder reading a 3mf file from r.
@@ -167,6 +180,29 @@ func NewDecoder(r io.ReaderAt, size int64) *Decoder {
 	}
 }
 
+// SetPartReader sets the PartReader used to process the model
+// and attachment parts before decoding them.
+func (d *Decoder) SetPartReader(r PartReader) {
+	d.partReader = r
+}
+
+// SetAttachmentFilter sets a filter that selects the package parts
+// that must be extracted as attachments even if they are not
+// the target of any relationship, such as slicer project files.
+func (d *Decoder) SetAttachmentFilter(filter func(path string) bool) {
+	d.attFilter = filter
+}
+
+// SetLazyLoading enables or disables the lazy decoding of the non-root model files.
+//
+// When enabled, the Childs of the decoded model are placeholders
+// that are decoded on first access, either explicitly with ChildModel.Load
+// or implicitly by FindResources, FindObject, FindAsset, WalkAssets and WalkObjects.
+// The underlying package must remain open until all the child models are loaded.
+func (d *Decoder) SetLazyLoading(lazy bool) {
+	d.lazy = lazy
+}
+
 // Decode reads the 3mf file and unmarshall its content into the model.
 func (d *Decoder) Decode(model *Model) error {
 	return d.DecodeContext(context.Background(), model)
@@ -178,10 +214,40 @@ func (d *Decoder) DecodeContext(ctx context.Context, model *Model) error {
 	if err != nil {
 		return err
 	}
-	if err := d.processNonRootModels(ctx, model); err != nil {
+	if err := d.processAttachments(model); err != nil {
+		return err
+	}
+	if d.lazy {
+		d.deferNonRootModels(model)
+	} else if err := d.processNonRootModels(ctx, model); err != nil {
 		return err
 	}
-	return d.processRootModel(ctx, rootFile, model)
+	if err := d.processRootModel(ctx, rootFile, model); err != nil {
+		return err
+	}
+	if d.partReader != nil {
+		removeKeyStore(model)
+	}
+	return nil
+}
+
+// removeKeyStore removes the KeyStore part and its relationship from a decrypted model,
+// as it describes the encryption of the decoded package and not of the model.
+func removeKeyStore(model *Model) {
+	rels := model.RootRelationships[:0]
+	for _, r := range model.RootRelationships {
+		if r.Type != RelTypeKeyStore {
+			rels = append(rels, r)
+			continue
+		}
+		for i, att := range model.Attachments {
+			if strings.EqualFold(att.Path, r.Path) {
+				model.Attachments = append(model.Attachments[:i], model.Attachments[i+1:]...)
+				break
+			}
+		}
+	}
+	model.RootRelationships = rels
 }
 
 // UnmarshalModel fills a model with the data of a root model file
@@ -193,7 +259,7 @@ func UnmarshalModel(data []byte, model *Model) error {
 }
 
 func (d *Decoder) processRootModel(ctx context.Context, rootFile packageFile, model *Model) error {
-	f, err := rootFile.Open()
+	f, err := d.openPart(rootFile, model)
 	if err != nil {
 		return err
 	}
@@ -230,12 +296,46 @@ func (d *Decoder) processNonRootModels(ctx context.Context, model *Model) (errs
 	return nil
 }
 
+func (d *Decoder) deferNonRootModels(model *Model) {
+	mu := new(sync.Mutex)
+	for i, file := range d.nonRootModels {
+		if child, ok := model.Childs[file.Name()]; ok {
+			child.lazy = &lazyChild{mu: mu, d: d, model: model, i: i}
+		}
+	}
+}
+
+// lazyChild decodes a non-root model file on demand.
+// The decoding of all the child models of a model is serialized,
+// as they share some of the model state.
+type lazyChild struct {
+	mu    *sync.Mutex
+	d     *Decoder
+	model *Model
+	i     int
+	done  bool
+	err   error
+}
+
+func (l *lazyChild) load() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.done {
+		l.err = l.d.readChildModel(context.Background(), l.i, l.model)
+		l.done = true
+	}
+	return l.err
+}
+
 func (d *Decoder) processOPC(model *Model) (packageFile, error) {
 	if err := d.p.Open(d.flate); err != nil {
 		return nil, err
 	}
 	var rootFile packageFile
 	for _, r := range d.p.Relationships() {
+		if r.Type == RelTypeKeyStore && d.partReader == nil {
+			return nil, specerr.ErrEncryptedPackage
+		}
 		if r.Type == RelType3DModel {
 			var ok bool
 			rootFile, ok = d.p.FindFileFromName(r.Path)
@@ -255,6 +355,13 @@ func (d *Decoder) processOPC(model *Model) (packageFile, error) {
 	if rootFile == nil {
 		return nil, errors.New("package does not have root model")
 	}
+	if d.attFilter != nil {
+		for _, file := range d.p.Files() {
+			if d.attFilter(file.Name()) {
+				model.Attachments = d.addAttachment(model.Attachments, file)
+			}
+		}
+	}
 	return rootFile, nil
 }
 
@@ -300,7 +407,7 @@ func (d *Decoder) addAttachment(attachments []Attachment, file packageFile) []At
 
 func (d *Decoder) readChildModel(ctx context.Context, i int, model *Model) error {
 	attachment := d.nonRootModels[i]
-	file, err := attachment.Open()
+	file, err := d.openPart(attachment, model)
 	if err != nil {
 		return err
 	}
@@ -314,6 +421,43 @@ func (d *Decoder) readChildModel(ctx context.Context, i int, model *Model) error
 	return err
 }
 
+func (d *Decoder) processAttachments(model *Model) error {
+	if d.partReader == nil {
+		return nil
+	}
+	for i := range model.Attachments {
+		att := &model.Attachments[i]
+		r, err := d.partReader.ReadPart(model, att.Path, att.Stream)
+		if err != nil {
+			return err
+		}
+		att.Stream = r
+	}
+	return nil
+}
+
+func (d *Decoder) openPart(file packageFile, model *Model) (io.ReadCloser, error) {
+	f, err := file.Open()
+	if err != nil || d.partReader == nil {
+		return f, err
+	}
+	r, err := d.partReader.ReadPart(model, file.Name(), f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &partReadCloser{Reader: r, c: f}, nil
+}
+
+type partReadCloser struct {
+	io.Reader
+	c io.Closer
+}
+
+func (p *partReadCloser) Close() error {
+	return p.c.Close()
+}
+
 func copyFile(file packageFile) (io.Reader, error) {
 	stream, err := file.Open()
 	if err != nil {
@@ -215,6 +215,11 @@ func newMockPackage(other *mockFile) *mockPackage {
 	m.On("Create", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	m.On("Relationships").Return([]Relationship{{Path: DefaultModelPath, Type: RelType3DModel}}).Maybe()
 	m.On("FindFileFromName", mock.Anything).Return(other, other != nil).Maybe()
+	var files []packageFile
+	if other != nil {
+		files = append(files, other)
+	}
+	m.On("Files").Return(files).Maybe()
 	return m
 }
 
@@ -242,6 +247,11 @@ func (m *mockPackage) Open(f func(r io.Reader) io.ReadCloser) error {
 	return args.Error(0)
 }
 
+func (m *mockPackage) Files() []packageFile {
+	args := m.Called()
+	return args.Get(0).([]packageFile)
+}
+
 func (m *mockPackage) FindFileFromName(args0 string) (packageFile, bool) {
 	args := m.Called(args0)
 	return args.Get(0).(packageFile), args.Bool(1)
@@ -285,6 +295,10 @@ func TestDecoder_processOPC(t *testing.T) {
 		{"withModelAttachment", &Decoder{
 			p: newMockPackage(newMockFile("/a.model", []Relationship{{Type: RelType3DModel, Path: "/other.model"}}, otherModel, false)),
 		}, &Model{Path: "/a.model", Childs: map[string]*ChildModel{"/other.model": new(ChildModel)}}, false},
+		{"withFilterNoMatch", &Decoder{
+			p:         newMockPackage(newMockFile("/a.model", nil, nil, false)),
+			attFilter: func(path string) bool { return strings.HasSuffix(path, ".config") },
+		}, &Model{Path: "/a.model"}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -347,6 +361,10 @@ func TestDecoder_processRootModel(t *testing.T) {
 		{100, 100, 100},
 		{0, 100, 100},
 	}...)
+	meshRes.Mesh.TriangleAnyAttr = map[uint32]AnyAttr{
+		0: {&spec.UnknownAttrs{{Name: fooName, Value: "fooval10"}}},
+		2: {&spec.UnknownAttrs{{Name: xml.Name{Local: "paint_color"}, Value: "1C"}}},
+	}
 	meshRes.Mesh.Triangles = append(meshRes.Mesh.Triangles, []Triangle{
 		{V1: 3, V2: 2, V3: 1, PID: 5, P1: 0, P2: 0, P3: 0},
 		{V1: 1, V2: 0, V3: 3, PID: 5, P1: 0, P2: 0, P3: 0},
@@ -450,9 +468,9 @@ func TestDecoder_processRootModel(t *testing.T) {
 						<vertex x="0" y="100.00000" z="100.00000" />
 					</vertices>
 					<triangles>
-						<triangle v1="3" v2="2" v3="1" />
+						<triangle v1="3" v2="2" v3="1" foo:fooname="fooval10" />
 						<triangle v1="1" v2="0" v3="3" />
-						<triangle v1="4" v2="5" v3="6" p1="1" />
+						<triangle v1="4" v2="5" v3="6" p1="1" paint_color="1C" />
 						<triangle v1="6" v2="7" v3="4" pid="5" p1="1" />
 						<triangle v1="0" v2="1" v3="5" pid="5" p1="0" p2="1" p3="2"/>
 						<triangle v1="5" v2="4" v3="0" pid="5" p1="3" p2="0" p3="2"/>
@@ -779,3 +797,130 @@ func TestOpenReader(t *testing.T) {
 		return
 	}
 }
+
+func TestDecoder_SetAttachmentFilter(t *testing.T) {
+	m := &Model{Attachments: []Attachment{
+		{Path: "/Metadata/a.config", ContentType: "text/plain", Stream: bytes.NewBufferString("; a = 1\n")},
+	}}
+	buff := new(bytes.Buffer)
+	if err := NewEncoder(buff).Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	tests := []struct {
+		name   string
+		filter func(string) bool
+		want   []string
+	}{
+		{"nil", nil, nil},
+		{"match", func(path string) bool { return strings.HasPrefix(path, "/Metadata/") }, []string{"/Metadata/a.config"}},
+		{"nomatch", func(path string) bool { return false }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+			d.SetAttachmentFilter(tt.filter)
+			newModel := new(Model)
+			if err := d.Decode(newModel); err != nil {
+				t.Fatalf("Decoder.Decode() error = %v", err)
+			}
+			var got []string
+			for _, a := range newModel.Attachments {
+				got = append(got, a.Path)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("Decoder.SetAttachmentFilter() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_SetLazyLoading(t *testing.T) {
+	m := &Model{
+		Childs: map[string]*ChildModel{
+			"/3D/a.model": {Resources: Resources{Assets: []Asset{&BaseMaterials{ID: 2, Materials: []Base{{Name: "a", Color: color.RGBA{A: 255}}}}}}},
+			"/3D/b.model": {Resources: Resources{Assets: []Asset{&BaseMaterials{ID: 3, Materials: []Base{{Name: "b", Color: color.RGBA{A: 255}}}}}}},
+		},
+	}
+	buff := new(bytes.Buffer)
+	if err := NewEncoder(buff).Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	d := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+	d.SetLazyLoading(true)
+	newModel := new(Model)
+	if err := d.Decode(newModel); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	if len(newModel.Childs) != 2 {
+		t.Fatalf("Decoder.Decode() childs = %v", newModel.Childs)
+	}
+	for path, c := range newModel.Childs {
+		if len(c.Resources.Assets) != 0 {
+			t.Errorf("Decoder.Decode() child %s decoded eagerly", path)
+		}
+	}
+	done := make(chan bool)
+	for i := 0; i < 4; i++ {
+		go func() {
+			_, ok := newModel.FindAsset("/3D/a.model", 2)
+			done <- ok
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		if !<-done {
+			t.Errorf("Model.FindAsset() not found")
+		}
+	}
+	if got := len(newModel.Childs["/3D/a.model"].Resources.Assets); got != 1 {
+		t.Errorf("Model.FindAsset() assets = %d, want 1", got)
+	}
+	if got := len(newModel.Childs["/3D/b.model"].Resources.Assets); got != 0 {
+		t.Errorf("Model.FindAsset() loaded an unrelated child")
+	}
+	if err := newModel.Validate(); err != nil {
+		t.Errorf("Model.Validate() error = %v", err)
+	}
+	if got := len(newModel.Childs["/3D/b.model"].Resources.Assets); got != 1 {
+		t.Errorf("Model.Validate() assets = %d, want 1", got)
+	}
+}
+
+// corruptPart is a PartReader that replaces the content of the part at its path
+// with malformed XML.
+type corruptPart string
+
+func (c corruptPart) ReadPart(_ *Model, path string, r io.Reader) (io.Reader, error) {
+	if path == string(c) {
+		return strings.NewReader("<model"), nil
+	}
+	return r, nil
+}
+
+func TestDecoder_SetLazyLoading_error(t *testing.T) {
+	m := &Model{
+		Childs: map[string]*ChildModel{
+			"/3D/a.model": {Resources: Resources{Assets: []Asset{&BaseMaterials{ID: 2, Materials: []Base{{Name: "a", Color: color.RGBA{A: 255}}}}}}},
+		},
+	}
+	buff := new(bytes.Buffer)
+	if err := NewEncoder(buff).Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	d := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+	d.SetLazyLoading(true)
+	d.SetPartReader(corruptPart("/3D/a.model"))
+	newModel := new(Model)
+	if err := d.Decode(newModel); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	if _, ok := newModel.FindResources("/3D/a.model"); ok {
+		t.Error("Model.FindResources() found a child that fails to load")
+	}
+	err := newModel.Childs["/3D/a.model"].Load()
+	if err == nil {
+		t.Fatal("ChildModel.Load() expected error")
+	}
+	if got := newModel.Validate(); got != err {
+		t.Errorf("Model.Validate() error = %v, want %v", got, err)
+	}
+}
@@ -0,0 +1,258 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package securecontent
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rsa"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/MosaicManufacturing/go3mf"
+)
+
+type xmlKeyStore struct {
+	XMLName            xml.Name               `xml:"http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/07 keystore"`
+	UUID               string                 `xml:"UUID,attr"`
+	Consumers          []xmlConsumer          `xml:"consumer"`
+	ResourceDataGroups []xmlResourceDataGroup `xml:"resourcedatagroup"`
+}
+
+type xmlConsumer struct {
+	ConsumerID string `xml:"consumerid,attr"`
+	KeyID      string `xml:"keyid,attr,omitempty"`
+	KeyValue   string `xml:"keyvalue,omitempty"`
+}
+
+type xmlResourceDataGroup struct {
+	KeyUUID      string            `xml:"keyuuid,attr"`
+	AccessRights []xmlAccessRight  `xml:"accessright"`
+	ResourceData []xmlResourceData `xml:"resourcedata"`
+}
+
+type xmlAccessRight struct {
+	ConsumerIndex uint32       `xml:"consumerindex,attr"`
+	KEKParams     xmlKEKParams `xml:"kekparams"`
+	CipherData    struct {
+		CipherValue string `xml:"http://www.w3.org/2001/04/xmlenc# CipherValue"`
+	} `xml:"cipherdata"`
+}
+
+type xmlKEKParams struct {
+	WrappingAlgorithm string `xml:"wrappingalgorithm,attr"`
+	MGFAlgorithm      string `xml:"mgfalgorithm,attr,omitempty"`
+	DigestMethod      string `xml:"digestmethod,attr,omitempty"`
+}
+
+type xmlResourceData struct {
+	Path      string       `xml:"path,attr"`
+	CEKParams xmlCEKParams `xml:"cekparams"`
+}
+
+type xmlCEKParams struct {
+	EncryptionAlgorithm string `xml:"encryptionalgorithm,attr"`
+	Compression         string `xml:"compression,attr,omitempty"`
+	IV                  string `xml:"iv"`
+	Tag                 string `xml:"tag"`
+	AAD                 string `xml:"aad,omitempty"`
+}
+
+// UnmarshalKeyStore decodes the content of a KeyStore part.
+func UnmarshalKeyStore(data []byte) (*KeyStore, error) {
+	var xks xmlKeyStore
+	if err := xml.Unmarshal(data, &xks); err != nil {
+		return nil, err
+	}
+	ks := &KeyStore{
+		UUID:               xks.UUID,
+		Consumers:          make([]Consumer, len(xks.Consumers)),
+		ResourceDataGroups: make([]ResourceDataGroup, len(xks.ResourceDataGroups)),
+	}
+	for i, c := range xks.Consumers {
+		ks.Consumers[i] = Consumer(c)
+	}
+	for i, xg := range xks.ResourceDataGroups {
+		g := ResourceDataGroup{
+			KeyUUID:      xg.KeyUUID,
+			AccessRights: make([]AccessRight, len(xg.AccessRights)),
+			ResourceData: make([]ResourceData, len(xg.ResourceData)),
+		}
+		for j, ar := range xg.AccessRights {
+			cipherValue, err := decodeBase64(ar.CipherData.CipherValue)
+			if err != nil {
+				return nil, err
+			}
+			g.AccessRights[j] = AccessRight{
+				ConsumerIndex: ar.ConsumerIndex,
+				KEKParams:     KEKParams(ar.KEKParams),
+				CipherValue:   cipherValue,
+			}
+		}
+		for j, rd := range xg.ResourceData {
+			params, err := newCEKParams(&rd.CEKParams)
+			if err != nil {
+				return nil, err
+			}
+			g.ResourceData[j] = ResourceData{Path: rd.Path, CEKParams: params}
+		}
+		ks.ResourceDataGroups[i] = g
+	}
+	return ks, nil
+}
+
+func newCEKParams(x *xmlCEKParams) (params CEKParams, err error) {
+	var ok bool
+	params.EncryptionAlgorithm = x.EncryptionAlgorithm
+	if params.Compression, ok = newCompression(x.Compression); !ok {
+		return params, fmt.Errorf("securecontent: unsupported compression %q", x.Compression)
+	}
+	if params.IV, err = decodeBase64(x.IV); err != nil {
+		return
+	}
+	if params.Tag, err = decodeBase64(x.Tag); err != nil {
+		return
+	}
+	params.AAD, err = decodeBase64(x.AAD)
+	return
+}
+
+// KeyUnwrapFunc returns the content encryption key contained in the access right
+// granted to the consumer. It should return an error if the consumer
+// private key is not available.
+type KeyUnwrapFunc func(c *Consumer, ar *AccessRight) ([]byte, error)
+
+// RSAKeyUnwrapper returns a KeyUnwrapFunc that unwraps RSA-OAEP wrapped keys using priv.
+func RSAKeyUnwrapper(priv *rsa.PrivateKey) KeyUnwrapFunc {
+	return func(_ *Consumer, ar *AccessRight) ([]byte, error) {
+		h, err := oaepHash(&ar.KEKParams)
+		if err != nil {
+			return nil, err
+		}
+		return rsa.DecryptOAEP(h, nil, priv, ar.CipherValue, nil)
+	}
+}
+
+// Decrypter implements go3mf.PartReader, decrypting the parts
+// listed in the package KeyStore.
+//
+// It is safe to use a Decrypter from multiple goroutines, but
+// it must not be shared across different packages.
+type Decrypter struct {
+	Unwrap KeyUnwrapFunc
+
+	mu     sync.Mutex
+	loaded bool
+	ks     *KeyStore
+	keys   map[string][]byte
+}
+
+// NewDecrypter returns a Decrypter that unwraps the content
+// encryption keys using unwrap.
+func NewDecrypter(unwrap KeyUnwrapFunc) *Decrypter {
+	return &Decrypter{Unwrap: unwrap}
+}
+
+// KeyStore returns the decoded KeyStore, or nil if it is not loaded yet
+// or the package does not contain one.
+func (d *Decrypter) KeyStore() *KeyStore {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.ks
+}
+
+// ReadPart decrypts the content of the part located at path
+// if it is listed in the KeyStore, else r is returned unmodified.
+func (d *Decrypter) ReadPart(m *go3mf.Model, path string, r io.Reader) (io.Reader, error) {
+	key, params, err := d.partKey(m, path)
+	if err != nil || key == nil {
+		return r, err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err = decrypt(key, params, data)
+	if err != nil {
+		return nil, fmt.Errorf("securecontent: cannot decrypt %s: %v", path, err)
+	}
+	return bytes.NewReader(data), nil
+}
+
+func (d *Decrypter) partKey(m *go3mf.Model, path string) ([]byte, *CEKParams, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.loaded {
+		ks, err := GetKeyStore(m)
+		if err != nil && err != ErrMissingKeyStore {
+			return nil, nil, err
+		}
+		d.ks, d.loaded = ks, true
+	}
+	if d.ks == nil {
+		return nil, nil, nil
+	}
+	g, rd, ok := d.ks.FindResourceData(path)
+	if !ok {
+		return nil, nil, nil
+	}
+	if key, ok := d.keys[g.KeyUUID]; ok {
+		return key, &rd.CEKParams, nil
+	}
+	key, err := d.unwrap(g)
+	if err != nil {
+		return nil, nil, err
+	}
+	if d.keys == nil {
+		d.keys = make(map[string][]byte)
+	}
+	d.keys[g.KeyUUID] = key
+	return key, &rd.CEKParams, nil
+}
+
+func (d *Decrypter) unwrap(g *ResourceDataGroup) ([]byte, error) {
+	if d.Unwrap == nil {
+		return nil, ErrNoAccess
+	}
+	for i := range g.AccessRights {
+		ar := &g.AccessRights[i]
+		if int(ar.ConsumerIndex) >= len(d.ks.Consumers) {
+			continue
+		}
+		if key, err := d.Unwrap(&d.ks.Consumers[ar.ConsumerIndex], ar); err == nil {
+			return key, nil
+		}
+	}
+	return nil, ErrNoAccess
+}
+
+func decrypt(key []byte, params *CEKParams, data []byte) ([]byte, error) {
+	if params.EncryptionAlgorithm != EncryptionAES256GCM {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(params.IV))
+	if err != nil {
+		return nil, err
+	}
+	sealed := make([]byte, 0, len(data)+len(params.Tag))
+	sealed = append(append(sealed, data...), params.Tag...)
+	plain, err := gcm.Open(nil, params.IV, sealed, params.AAD)
+	if err != nil {
+		return nil, err
+	}
+	if params.Compression == CompressionDeflate {
+		fr := flate.NewReader(bytes.NewReader(plain))
+		defer fr.Close()
+		return io.ReadAll(fr)
+	}
+	return plain, nil
+}
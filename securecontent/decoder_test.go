@@ -0,0 +1,146 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package securecontent
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/go-test/deep"
+)
+
+func TestUnmarshalKeyStore(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+	<keystore xmlns="http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/07" UUID="b7aa9c75-5fbd-48c1-a4bc-4ad0ed2dbc9b">
+		<consumer consumerid="HP#MOP44B#SG5693454" keyid="KEK_xxx">
+			<keyvalue>-----BEGIN PUBLIC KEY-----</keyvalue>
+		</consumer>
+		<resourcedatagroup keyuuid="4ad0ed2d-5fbd-48c1-a4bc-b7aa9c75bc9b">
+			<accessright consumerindex="0">
+				<kekparams wrappingalgorithm="http://www.w3.org/2001/04/xmlenc#rsa-oaep-mgf1p" mgfalgorithm="http://www.w3.org/2009/xmlenc11#mgf1sha1" digestmethod="http://www.w3.org/2000/09/xmldsig#sha1"/>
+				<cipherdata>
+					<xenc:CipherValue xmlns:xenc="http://www.w3.org/2001/04/xmlenc#">AQID</xenc:CipherValue>
+				</cipherdata>
+			</accessright>
+			<resourcedata path="/3D/3dmodel.model">
+				<cekparams encryptionalgorithm="http://www.w3.org/2009/xmlenc11#aes256-gcm" compression="deflate">
+					<iv>BAUG</iv>
+					<tag>BwgJ</tag>
+					<aad>Cgs=</aad>
+				</cekparams>
+			</resourcedata>
+		</resourcedatagroup>
+	</keystore>`)
+	want := &KeyStore{
+		UUID:      "b7aa9c75-5fbd-48c1-a4bc-4ad0ed2dbc9b",
+		Consumers: []Consumer{{ConsumerID: "HP#MOP44B#SG5693454", KeyID: "KEK_xxx", KeyValue: "-----BEGIN PUBLIC KEY-----"}},
+		ResourceDataGroups: []ResourceDataGroup{{
+			KeyUUID: "4ad0ed2d-5fbd-48c1-a4bc-b7aa9c75bc9b",
+			AccessRights: []AccessRight{{
+				KEKParams:   KEKParams{WrappingAlgorithm: WrappingRSAOAEPMGF1, MGFAlgorithm: MGF1SHA1, DigestMethod: DigestSHA1},
+				CipherValue: []byte{1, 2, 3},
+			}},
+			ResourceData: []ResourceData{{Path: "/3D/3dmodel.model", CEKParams: CEKParams{
+				EncryptionAlgorithm: EncryptionAES256GCM,
+				Compression:         CompressionDeflate,
+				IV:                  []byte{4, 5, 6},
+				Tag:                 []byte{7, 8, 9},
+				AAD:                 []byte{10, 11},
+			}}},
+		}},
+	}
+	got, err := UnmarshalKeyStore(data)
+	if err != nil {
+		t.Fatalf("UnmarshalKeyStore() error = %v", err)
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("UnmarshalKeyStore() = %v", diff)
+	}
+	b, err := MarshalKeyStore(want)
+	if err != nil {
+		t.Fatalf("MarshalKeyStore() error = %v", err)
+	}
+	got, err = UnmarshalKeyStore(b)
+	if err != nil {
+		t.Fatalf("UnmarshalKeyStore() error = %v", err)
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("MarshalKeyStore() = %v", diff)
+	}
+}
+
+func TestUnmarshalKeyStore_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"malformed", `<keystore`},
+		{"namespace", `<keystore xmlns="other"/>`},
+		{"cipherValue", `<keystore xmlns="http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/07"><resourcedatagroup><accessright><cipherdata><CipherValue xmlns="http://www.w3.org/2001/04/xmlenc#">*</CipherValue></cipherdata></accessright></resourcedatagroup></keystore>`},
+		{"compression", `<keystore xmlns="http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/07"><resourcedatagroup><resourcedata><cekparams compression="lzma"/></resourcedata></resourcedatagroup></keystore>`},
+		{"iv", `<keystore xmlns="http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/07"><resourcedatagroup><resourcedata><cekparams><iv>*</iv></cekparams></resourcedata></resourcedatagroup></keystore>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := UnmarshalKeyStore([]byte(tt.data)); err == nil {
+				t.Error("UnmarshalKeyStore() expected error")
+			}
+		})
+	}
+}
+
+func TestDecrypter_ReadPart(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, cekSize)
+	e := &Encrypter{Rand: bytes.NewReader(bytes.Repeat([]byte{2}, cekSize+ivSize))}
+	e.key = key
+	cipherText, err := e.encrypt("/3D/a.model", []byte("content"))
+	if err != nil {
+		t.Fatalf("Encrypter.encrypt() error = %v", err)
+	}
+	ks := &KeyStore{
+		Consumers:          []Consumer{{ConsumerID: "a"}},
+		ResourceDataGroups: []ResourceDataGroup{e.group},
+	}
+	ks.ResourceDataGroups[0].AccessRights = []AccessRight{{ConsumerIndex: 1}, {ConsumerIndex: 0}}
+	ksData, _ := MarshalKeyStore(ks)
+	m := &go3mf.Model{
+		RootRelationships: []go3mf.Relationship{{Path: DefaultKeyStorePath, Type: RelTypeKeyStore}},
+		Attachments:       []go3mf.Attachment{{Path: DefaultKeyStorePath, Stream: bytes.NewBuffer(ksData)}},
+	}
+	unwrap := func(c *Consumer, _ *AccessRight) ([]byte, error) {
+		if c.ConsumerID != "a" {
+			return nil, ErrUnsupportedKey
+		}
+		return key, nil
+	}
+	tests := []struct {
+		name    string
+		path    string
+		content []byte
+		want    string
+		wantErr bool
+	}{
+		{"encrypted", "/3D/a.model", cipherText, "content", false},
+		{"plain", "/3D/b.model", []byte("plain"), "plain", false},
+		{"tampered", "/3D/a.model", append([]byte{0}, cipherText[1:]...), "", true},
+	}
+	d := NewDecrypter(unwrap)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := d.ReadPart(m, tt.path, bytes.NewReader(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decrypter.ReadPart() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, _ := io.ReadAll(r)
+			if string(got) != tt.want {
+				t.Errorf("Decrypter.ReadPart() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package securecontent
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/uuid"
+)
+
+const (
+	cekSize = 32
+	ivSize  = 12
+)
+
+// MarshalKeyStore returns the XML encoding of ks.
+func MarshalKeyStore(ks *KeyStore) ([]byte, error) {
+	xks := xmlKeyStore{
+		UUID:               ks.UUID,
+		Consumers:          make([]xmlConsumer, len(ks.Consumers)),
+		ResourceDataGroups: make([]xmlResourceDataGroup, len(ks.ResourceDataGroups)),
+	}
+	for i, c := range ks.Consumers {
+		xks.Consumers[i] = xmlConsumer(c)
+	}
+	for i, g := range ks.ResourceDataGroups {
+		xg := xmlResourceDataGroup{
+			KeyUUID:      g.KeyUUID,
+			AccessRights: make([]xmlAccessRight, len(g.AccessRights)),
+			ResourceData: make([]xmlResourceData, len(g.ResourceData)),
+		}
+		for j, ar := range g.AccessRights {
+			xar := xmlAccessRight{
+				ConsumerIndex: ar.ConsumerIndex,
+				KEKParams:     xmlKEKParams(ar.KEKParams),
+			}
+			xar.CipherData.CipherValue = base64.StdEncoding.EncodeToString(ar.CipherValue)
+			xg.AccessRights[j] = xar
+		}
+		for j, rd := range g.ResourceData {
+			xg.ResourceData[j] = xmlResourceData{Path: rd.Path, CEKParams: xmlCEKParams{
+				EncryptionAlgorithm: rd.CEKParams.EncryptionAlgorithm,
+				Compression:         rd.CEKParams.Compression.String(),
+				IV:                  base64.StdEncoding.EncodeToString(rd.CEKParams.IV),
+				Tag:                 base64.StdEncoding.EncodeToString(rd.CEKParams.Tag),
+				AAD:                 base64.StdEncoding.EncodeToString(rd.CEKParams.AAD),
+			}}
+		}
+		xks.ResourceDataGroups[i] = xg
+	}
+	b, err := xml.Marshal(&xks)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+// KeyWrapFunc returns the access right that grants the consumer
+// access to the content encryption key.
+type KeyWrapFunc func(c *Consumer, key []byte) (*AccessRight, error)
+
+// RSAKeyWrap wraps the key using RSA-OAEP with SHA-1
+// and the PEM encoded public key stored in the consumer KeyValue.
+func RSAKeyWrap(c *Consumer, key []byte) (*AccessRight, error) {
+	pub, err := parsePublicKey(c.KeyValue)
+	if err != nil {
+		return nil, err
+	}
+	cipherValue, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, pub, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AccessRight{
+		KEKParams: KEKParams{
+			WrappingAlgorithm: WrappingRSAOAEP,
+			MGFAlgorithm:      MGF1SHA1,
+			DigestMethod:      DigestSHA1,
+		},
+		CipherValue: cipherValue,
+	}, nil
+}
+
+func parsePublicKey(s string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, ErrUnsupportedKey
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if key, ok := key.(*rsa.PublicKey); ok {
+		return key, nil
+	}
+	return nil, ErrUnsupportedKey
+}
+
+// Encrypter implements go3mf.PartWriter, encrypting the parts
+// listed in Paths with a single content encryption key
+// and writing the KeyStore part once all the parts are written.
+//
+// An Encrypter must only be used once.
+type Encrypter struct {
+	// Consumers are granted access to all the encrypted parts.
+	Consumers []Consumer
+	// Paths of the parts to encrypt.
+	Paths       []string
+	Compression Compression
+	// Wrap defaults to RSAKeyWrap.
+	Wrap KeyWrapFunc
+	// KeyStorePath defaults to DefaultKeyStorePath.
+	KeyStorePath string
+	// Rand defaults to crypto/rand.Reader.
+	Rand io.Reader
+
+	key   []byte
+	group ResourceDataGroup
+}
+
+// WritePart returns a writer that encrypts the content of the part
+// located at path if it is listed in Paths.
+func (e *Encrypter) WritePart(path string, w io.Writer) (io.WriteCloser, error) {
+	for _, p := range e.Paths {
+		if strings.EqualFold(p, path) {
+			return &encryptWriter{e: e, path: path, w: w}, nil
+		}
+	}
+	return nopWriteCloser{w}, nil
+}
+
+// Flush writes the KeyStore part if any part has been encrypted.
+func (e *Encrypter) Flush(p go3mf.PackageWriter) error {
+	if len(e.group.ResourceData) == 0 {
+		return nil
+	}
+	wrap := e.Wrap
+	if wrap == nil {
+		wrap = RSAKeyWrap
+	}
+	ks := KeyStore{
+		UUID:      uuid.New(),
+		Consumers: e.Consumers,
+	}
+	e.group.KeyUUID = uuid.New()
+	e.group.AccessRights = make([]AccessRight, 0, len(e.Consumers))
+	for i := range e.Consumers {
+		ar, err := wrap(&e.Consumers[i], e.key)
+		if err != nil {
+			return err
+		}
+		ar.ConsumerIndex = uint32(i)
+		e.group.AccessRights = append(e.group.AccessRights, *ar)
+	}
+	ks.ResourceDataGroups = []ResourceDataGroup{e.group}
+	b, err := MarshalKeyStore(&ks)
+	if err != nil {
+		return err
+	}
+	path := e.KeyStorePath
+	if path == "" {
+		path = DefaultKeyStorePath
+	}
+	w, err := p.Create(path, ContentTypeKeyStore)
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(b); err != nil {
+		return err
+	}
+	p.AddRelationship(go3mf.Relationship{Path: path, Type: RelTypeKeyStore})
+	return nil
+}
+
+func (e *Encrypter) rand() io.Reader {
+	if e.Rand == nil {
+		return rand.Reader
+	}
+	return e.Rand
+}
+
+func (e *Encrypter) encrypt(path string, data []byte) ([]byte, error) {
+	if e.key == nil {
+		e.key = make([]byte, cekSize)
+		if _, err := io.ReadFull(e.rand(), e.key); err != nil {
+			return nil, err
+		}
+	}
+	if e.Compression == CompressionDeflate {
+		var buf bytes.Buffer
+		fw, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+		fw.Write(data)
+		if err := fw.Close(); err != nil {
+			return nil, err
+		}
+		data = buf.Bytes()
+	}
+	iv := make([]byte, ivSize)
+	if _, err := io.ReadFull(e.rand(), iv); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(e.key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	sealed := gcm.Seal(nil, iv, data, nil)
+	n := len(sealed) - gcm.Overhead()
+	e.group.ResourceData = append(e.group.ResourceData, ResourceData{
+		Path: path,
+		CEKParams: CEKParams{
+			EncryptionAlgorithm: EncryptionAES256GCM,
+			Compression:         e.Compression,
+			IV:                  iv,
+			Tag:                 sealed[n:],
+		},
+	})
+	return sealed[:n], nil
+}
+
+type encryptWriter struct {
+	e    *Encrypter
+	path string
+	w    io.Writer
+	buf  bytes.Buffer
+}
+
+func (w *encryptWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *encryptWriter) Close() error {
+	data, err := w.e.encrypt(w.path, w.buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(data)
+	return err
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
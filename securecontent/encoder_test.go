@@ -0,0 +1,224 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package securecontent
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/go-test/deep"
+)
+
+var (
+	testKeyOnce sync.Once
+	testKey     *rsa.PrivateKey
+)
+
+func privateKey(t *testing.T) *rsa.PrivateKey {
+	testKeyOnce.Do(func() {
+		var err error
+		if testKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			t.Fatal(err)
+		}
+	})
+	return testKey
+}
+
+func testConsumer(t *testing.T) Consumer {
+	der, err := x509.MarshalPKIXPublicKey(&privateKey(t).PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Consumer{
+		ConsumerID: "printer",
+		KeyID:      "key1",
+		KeyValue:   string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}
+}
+
+func testModel() *go3mf.Model {
+	return &go3mf.Model{
+		Path: "/3D/3dmodel.model",
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {1, 0, 0}, {0, 1, 0}}, Triangles: []go3mf.Triangle{{V1: 0, V2: 1, V3: 2}}}},
+		}},
+		Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1}}},
+		Relationships: []go3mf.Relationship{
+			{Path: "/3D/Textures/tex.png", Type: "http://schemas.microsoft.com/3dmanufacturing/2013/01/3dtexture", ID: "rel1"},
+		},
+		Attachments: []go3mf.Attachment{
+			{Path: "/3D/Textures/tex.png", ContentType: "image/png", Stream: bytes.NewBufferString("fake texture")},
+		},
+	}
+}
+
+func encodeModel(t *testing.T, m *go3mf.Model, e *Encrypter) []byte {
+	var buf bytes.Buffer
+	enc := go3mf.NewEncoder(&buf)
+	enc.SetPartWriter(e)
+	if err := enc.Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestEncrypter_Roundtrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression Compression
+	}{
+		{"none", CompressionNone},
+		{"deflate", CompressionDeflate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Encrypter{
+				Consumers:   []Consumer{testConsumer(t)},
+				Paths:       []string{"/3D/3dmodel.model", "/3D/Textures/tex.png"},
+				Compression: tt.compression,
+			}
+			data := encodeModel(t, testModel(), e)
+
+			newModel := new(go3mf.Model)
+			d := go3mf.NewDecoder(bytes.NewReader(data), int64(len(data)))
+			dec := NewDecrypter(RSAKeyUnwrapper(privateKey(t)))
+			d.SetPartReader(dec)
+			if err := d.Decode(newModel); err != nil {
+				t.Fatalf("Decoder.Decode() error = %v", err)
+			}
+			want := testModel()
+			if diff := deep.Equal(newModel.Resources, want.Resources); diff != nil {
+				t.Errorf("Decoder.Decode() resources = %v", diff)
+			}
+			var tex []byte
+			for _, att := range newModel.Attachments {
+				if att.Path == "/3D/Textures/tex.png" {
+					tex, _ = io.ReadAll(att.Stream)
+				}
+			}
+			if string(tex) != "fake texture" {
+				t.Errorf("Decoder.Decode() texture = %q", tex)
+			}
+			ks := dec.KeyStore()
+			if ks == nil || len(ks.ResourceDataGroups) != 1 || len(ks.ResourceDataGroups[0].ResourceData) != 2 {
+				t.Fatalf("Decrypter.KeyStore() = %v", ks)
+			}
+			for _, rd := range ks.ResourceDataGroups[0].ResourceData {
+				if rd.CEKParams.Compression != tt.compression {
+					t.Errorf("ResourceData.Compression = %v, want %v", rd.CEKParams.Compression, tt.compression)
+				}
+			}
+		})
+	}
+}
+
+func TestEncrypter_Encode_Encrypted(t *testing.T) {
+	e := &Encrypter{Consumers: []Consumer{testConsumer(t)}, Paths: []string{"/3D/3dmodel.model"}}
+	data := encodeModel(t, testModel(), e)
+
+	d := go3mf.NewDecoder(bytes.NewReader(data), int64(len(data)))
+	if err := d.Decode(new(go3mf.Model)); err != specerr.ErrEncryptedPackage {
+		t.Errorf("Decoder.Decode() error = %v, want %v", err, specerr.ErrEncryptedPackage)
+	}
+
+	d = go3mf.NewDecoder(bytes.NewReader(data), int64(len(data)))
+	d.SetPartReader(NewDecrypter(func(*Consumer, *AccessRight) ([]byte, error) {
+		return nil, ErrUnsupportedKey
+	}))
+	if err := d.Decode(new(go3mf.Model)); err != ErrNoAccess {
+		t.Errorf("Decoder.Decode() error = %v, want %v", err, ErrNoAccess)
+	}
+}
+
+func TestDecrypter_Reencode(t *testing.T) {
+	decode := func(t *testing.T, data []byte, r go3mf.PartReader) *go3mf.Model {
+		m := new(go3mf.Model)
+		d := go3mf.NewDecoder(bytes.NewReader(data), int64(len(data)))
+		if r != nil {
+			d.SetPartReader(r)
+		}
+		if err := d.Decode(m); err != nil {
+			t.Fatalf("Decoder.Decode() error = %v", err)
+		}
+		return m
+	}
+	tests := []struct {
+		name    string
+		encrypt bool
+	}{
+		{"plain", false},
+		{"encrypted", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Encrypter{Consumers: []Consumer{testConsumer(t)}, Paths: []string{"/3D/3dmodel.model", "/3D/Textures/tex.png"}}
+			m := decode(t, encodeModel(t, testModel(), e), NewDecrypter(RSAKeyUnwrapper(privateKey(t))))
+			if _, err := GetKeyStore(m); err != ErrMissingKeyStore {
+				t.Errorf("GetKeyStore() error = %v, want %v", err, ErrMissingKeyStore)
+			}
+			var (
+				data []byte
+				r    go3mf.PartReader
+			)
+			if tt.encrypt {
+				e = &Encrypter{Consumers: []Consumer{testConsumer(t)}, Paths: []string{"/3D/3dmodel.model"}}
+				data = encodeModel(t, m, e)
+				r = NewDecrypter(RSAKeyUnwrapper(privateKey(t)))
+			} else {
+				var buf bytes.Buffer
+				if err := go3mf.NewEncoder(&buf).Encode(m); err != nil {
+					t.Fatalf("Encoder.Encode() error = %v", err)
+				}
+				data = buf.Bytes()
+			}
+			got := decode(t, data, r)
+			if diff := deep.Equal(got.Resources, testModel().Resources); diff != nil {
+				t.Errorf("Decoder.Decode() resources = %v", diff)
+			}
+		})
+	}
+}
+
+func TestEncrypter_Flush_Empty(t *testing.T) {
+	data := encodeModel(t, testModel(), &Encrypter{Paths: []string{"/3D/other.model"}})
+	if bytes.Contains(data, []byte(strings.TrimPrefix(DefaultKeyStorePath, "/"))) {
+		t.Error("Encrypter.Flush() should not write a KeyStore when no part is encrypted")
+	}
+}
+
+func Test_parsePublicKey(t *testing.T) {
+	pub := &privateKey(t).PublicKey
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(pub)})
+	tests := []struct {
+		name    string
+		s       string
+		wantErr bool
+	}{
+		{"pkix", testConsumer(t).KeyValue, false},
+		{"pkcs1", string(pkcs1), false},
+		{"empty", "", true},
+		{"invalid", "-----BEGIN PUBLIC KEY-----\nAAAA\n-----END PUBLIC KEY-----\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePublicKey(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parsePublicKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.N.Cmp(pub.N) != 0 {
+				t.Error("parsePublicKey() returned a different key")
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package securecontent
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"hash"
+	"strings"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/spec"
+)
+
+// Namespace is the canonical name of this extension.
+const Namespace = "http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/07"
+
+var DefaultExtension = go3mf.Extension{
+	Namespace:  Namespace,
+	LocalName:  "sc",
+	IsRequired: false,
+}
+
+const (
+	// RelTypeKeyStore is the relationship type from the package root to the KeyStore part.
+	RelTypeKeyStore = go3mf.RelTypeKeyStore
+	// ContentTypeKeyStore is the content type of the KeyStore part.
+	ContentTypeKeyStore = "application/vnd.ms-package.3dmanufacturing-keystore+xml"
+	// DefaultKeyStorePath is the default location of the KeyStore part.
+	DefaultKeyStorePath = "/Secure/keystore.xml"
+)
+
+// Supported algorithms.
+const (
+	EncryptionAES256GCM = "http://www.w3.org/2009/xmlenc11#aes256-gcm"
+	WrappingRSAOAEP     = "http://www.w3.org/2009/xmlenc11#rsa-oaep"
+	WrappingRSAOAEPMGF1 = "http://www.w3.org/2001/04/xmlenc#rsa-oaep-mgf1p"
+	MGF1SHA1            = "http://www.w3.org/2009/xmlenc11#mgf1sha1"
+	MGF1SHA256          = "http://www.w3.org/2009/xmlenc11#mgf1sha256"
+	DigestSHA1          = "http://www.w3.org/2000/09/xmldsig#sha1"
+	DigestSHA256        = "http://www.w3.org/2001/04/xmlenc#sha256"
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("securecontent: unsupported algorithm")
+	ErrUnsupportedKey       = errors.New("securecontent: unsupported consumer key")
+	ErrNoAccess             = errors.New("securecontent: no access right could be unwrapped")
+	ErrMissingKeyStore      = errors.New("securecontent: keystore part not found")
+)
+
+type Spec struct{}
+
+func init() {
+	go3mf.Register(Namespace, Spec{})
+}
+
+func (Spec) CreateElementDecoder(_ interface{}, _ string) spec.ElementDecoder {
+	return nil
+}
+
+func (Spec) DecodeAttribute(_ interface{}, _ spec.Attr) error {
+	return nil
+}
+
+// Compression defines the algorithm applied to the content before encrypting it.
+type Compression uint8
+
+// Supported compressions.
+const (
+	CompressionNone Compression = iota
+	CompressionDeflate
+)
+
+func (c Compression) String() string {
+	if c == CompressionDeflate {
+		return "deflate"
+	}
+	return "none"
+}
+
+func newCompression(s string) (c Compression, ok bool) {
+	switch s {
+	case "", "none":
+		return CompressionNone, true
+	case "deflate":
+		return CompressionDeflate, true
+	}
+	return CompressionNone, false
+}
+
+// KeyStore holds the information needed by the consumers
+// to decrypt the encrypted parts of a package.
+type KeyStore struct {
+	UUID               string
+	Consumers          []Consumer
+	ResourceDataGroups []ResourceDataGroup
+}
+
+// FindResourceData returns the group and the resource data of the part located at path.
+func (k *KeyStore) FindResourceData(path string) (*ResourceDataGroup, *ResourceData, bool) {
+	for i := range k.ResourceDataGroups {
+		g := &k.ResourceDataGroups[i]
+		for j := range g.ResourceData {
+			if strings.EqualFold(g.ResourceData[j].Path, path) {
+				return g, &g.ResourceData[j], true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// Consumer identifies a party that can decrypt the content.
+// KeyValue contains the PEM encoded public key of the consumer.
+type Consumer struct {
+	ConsumerID string
+	KeyID      string
+	KeyValue   string
+}
+
+// ResourceDataGroup groups the resources encrypted with the same content encryption key.
+type ResourceDataGroup struct {
+	KeyUUID      string
+	AccessRights []AccessRight
+	ResourceData []ResourceData
+}
+
+// AccessRight contains the content encryption key
+// wrapped for the consumer at ConsumerIndex.
+type AccessRight struct {
+	ConsumerIndex uint32
+	KEKParams     KEKParams
+	CipherValue   []byte
+}
+
+// KEKParams defines the algorithms used to wrap the content encryption key.
+type KEKParams struct {
+	WrappingAlgorithm string
+	MGFAlgorithm      string
+	DigestMethod      string
+}
+
+// ResourceData describes an encrypted part.
+type ResourceData struct {
+	Path      string
+	CEKParams CEKParams
+}
+
+// CEKParams defines how a part was encrypted.
+type CEKParams struct {
+	EncryptionAlgorithm string
+	Compression         Compression
+	IV                  []byte
+	Tag                 []byte
+	AAD                 []byte
+}
+
+// GetKeyStore decodes the KeyStore part of the model.
+// The KeyStore part is kept as a model attachment until the
+// package is decrypted, see Decrypter.KeyStore.
+func GetKeyStore(m *go3mf.Model) (*KeyStore, error) {
+	for _, r := range m.RootRelationships {
+		if r.Type != RelTypeKeyStore {
+			continue
+		}
+		for i := range m.Attachments {
+			att := &m.Attachments[i]
+			if strings.EqualFold(att.Path, r.Path) {
+				data, err := att.Bytes()
+				if err != nil {
+					return nil, err
+				}
+				return UnmarshalKeyStore(data)
+			}
+		}
+	}
+	return nil, ErrMissingKeyStore
+}
+
+func oaepHash(params *KEKParams) (hash.Hash, error) {
+	switch params.WrappingAlgorithm {
+	case WrappingRSAOAEP, WrappingRSAOAEPMGF1:
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+	// crypto/rsa uses the same hash for the digest and the mask generation.
+	switch {
+	case (params.DigestMethod == "" || params.DigestMethod == DigestSHA1) &&
+		(params.MGFAlgorithm == "" || params.MGFAlgorithm == MGF1SHA1):
+		return sha1.New(), nil
+	case params.DigestMethod == DigestSHA256 && params.MGFAlgorithm == MGF1SHA256:
+		return sha256.New(), nil
+	}
+	return nil, ErrUnsupportedAlgorithm
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.Join(strings.Fields(s), "")
+	if s == "" {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
@@ -0,0 +1,107 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package securecontent
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/go-test/deep"
+)
+
+func TestCompression_String(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Compression
+		want string
+	}{
+		{"none", CompressionNone, "none"},
+		{"deflate", CompressionDeflate, "deflate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.want {
+				t.Errorf("Compression.String() = %v, want %v", got, tt.want)
+			}
+			if got, ok := newCompression(tt.want); !ok || got != tt.c {
+				t.Errorf("newCompression() = %v, want %v", got, tt.c)
+			}
+		})
+	}
+}
+
+func TestKeyStore_FindResourceData(t *testing.T) {
+	ks := &KeyStore{ResourceDataGroups: []ResourceDataGroup{
+		{KeyUUID: "a", ResourceData: []ResourceData{{Path: "/3D/a.model"}}},
+		{KeyUUID: "b", ResourceData: []ResourceData{{Path: "/3D/b.model"}, {Path: "/3D/Textures/c.png"}}},
+	}}
+	tests := []struct {
+		name      string
+		path      string
+		wantGroup *ResourceDataGroup
+		wantData  *ResourceData
+		wantOk    bool
+	}{
+		{"first", "/3D/a.model", &ks.ResourceDataGroups[0], &ks.ResourceDataGroups[0].ResourceData[0], true},
+		{"caseInsensitive", "/3d/textures/C.png", &ks.ResourceDataGroups[1], &ks.ResourceDataGroups[1].ResourceData[1], true},
+		{"notFound", "/3D/3dmodel.model", nil, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, rd, ok := ks.FindResourceData(tt.path)
+			if g != tt.wantGroup || rd != tt.wantData || ok != tt.wantOk {
+				t.Errorf("KeyStore.FindResourceData() = %v, %v, %v", g, rd, ok)
+			}
+		})
+	}
+}
+
+func TestGetKeyStore(t *testing.T) {
+	ks := &KeyStore{UUID: "ks", Consumers: []Consumer{{ConsumerID: "c"}}, ResourceDataGroups: []ResourceDataGroup{}}
+	data, err := MarshalKeyStore(ks)
+	if err != nil {
+		t.Fatalf("MarshalKeyStore() error = %v", err)
+	}
+	m := &go3mf.Model{
+		RootRelationships: []go3mf.Relationship{{Path: DefaultKeyStorePath, Type: RelTypeKeyStore}},
+		Attachments: []go3mf.Attachment{
+			{Path: "/Metadata/a.png", Stream: bytes.NewBufferString("a")},
+			{Path: DefaultKeyStorePath, Stream: bytes.NewBuffer(data)},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		got, err := GetKeyStore(m)
+		if err != nil {
+			t.Fatalf("GetKeyStore() error = %v", err)
+		}
+		if diff := deep.Equal(got, ks); diff != nil {
+			t.Errorf("GetKeyStore() = %v", diff)
+		}
+	}
+	if _, err := GetKeyStore(new(go3mf.Model)); err != ErrMissingKeyStore {
+		t.Errorf("GetKeyStore() error = %v, want %v", err, ErrMissingKeyStore)
+	}
+}
+
+func Test_oaepHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  KEKParams
+		wantErr bool
+	}{
+		{"default", KEKParams{WrappingAlgorithm: WrappingRSAOAEPMGF1}, false},
+		{"sha1", KEKParams{WrappingAlgorithm: WrappingRSAOAEP, MGFAlgorithm: MGF1SHA1, DigestMethod: DigestSHA1}, false},
+		{"sha256", KEKParams{WrappingAlgorithm: WrappingRSAOAEP, MGFAlgorithm: MGF1SHA256, DigestMethod: DigestSHA256}, false},
+		{"mixed", KEKParams{WrappingAlgorithm: WrappingRSAOAEP, MGFAlgorithm: MGF1SHA1, DigestMethod: DigestSHA256}, true},
+		{"unknown", KEKParams{WrappingAlgorithm: "other"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := oaepHash(&tt.params); (err != nil) != tt.wantErr {
+				t.Errorf("oaepHash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slicerconfig
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/MosaicManufacturing/go3mf"
+)
+
+// BambuConfig defines the Bambu Studio project configuration.
+// Extra holds the model settings elements that are not objects,
+// such as plates and assembly information.
+type BambuConfig struct {
+	Settings Settings
+	Objects  Objects
+	Extra    []Element
+}
+
+// ReadBambu returns the Bambu Studio configuration stored in the model
+// attachments, or nil if the model does not have any.
+func ReadBambu(m *go3mf.Model) (*BambuConfig, error) {
+	data, okProject, err := readAttachment(m, PathBambuProject)
+	if err != nil {
+		return nil, err
+	}
+	modelData, okModel, err := readAttachment(m, PathBambuModel)
+	if err != nil {
+		return nil, err
+	}
+	if !okProject && !okModel {
+		return nil, nil
+	}
+	c := new(BambuConfig)
+	if len(bytes.TrimSpace(data)) != 0 {
+		if c.Settings, err = decodeBambuSettings(data); err != nil {
+			return nil, err
+		}
+	}
+	if len(bytes.TrimSpace(modelData)) != 0 {
+		if c.Objects, c.Extra, err = decodeModelConfig(modelData); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// WriteBambu stores the Bambu Studio configuration in the model attachments,
+// replacing the existing one. Empty parts are removed.
+func WriteBambu(m *go3mf.Model, c *BambuConfig) error {
+	if len(c.Settings) == 0 {
+		removeAttachment(m, PathBambuProject)
+	} else {
+		writeAttachment(m, PathBambuProject, ContentTypeJSON, encodeBambuSettings(c.Settings))
+	}
+	if len(c.Objects) == 0 && len(c.Extra) == 0 {
+		removeAttachment(m, PathBambuModel)
+		return nil
+	}
+	data, err := encodeModelConfig(c.Objects, c.Extra, false)
+	if err != nil {
+		return err
+	}
+	writeAttachment(m, PathBambuModel, ContentTypeXML, data)
+	return nil
+}
+
+// decodeBambuSettings parses a flat JSON object whose values are
+// strings or arrays of strings, keeping the keys order.
+func decodeBambuSettings(data []byte) (Settings, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tk, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tk != json.Delim('{') {
+		return nil, ErrUnsupportedValue
+	}
+	var settings Settings
+	for dec.More() {
+		tk, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		s := Setting{Key: tk.(string)}
+		if tk, err = dec.Token(); err != nil {
+			return nil, err
+		}
+		switch v := tk.(type) {
+		case string:
+			s.Value = v
+		case json.Delim:
+			if v != '[' {
+				return nil, ErrUnsupportedValue
+			}
+			s.List = []string{}
+			for dec.More() {
+				if tk, err = dec.Token(); err != nil {
+					return nil, err
+				}
+				str, ok := tk.(string)
+				if !ok {
+					return nil, ErrUnsupportedValue
+				}
+				s.List = append(s.List, str)
+			}
+			if _, err = dec.Token(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ErrUnsupportedValue
+		}
+		settings = append(settings, s)
+	}
+	return settings, nil
+}
+
+// encodeBambuSettings writes the settings with the Bambu Studio indentation.
+func encodeBambuSettings(settings Settings) []byte {
+	var b bytes.Buffer
+	b.WriteString("{\n")
+	for i, s := range settings {
+		b.WriteString("    ")
+		writeJSON(&b, s.Key)
+		b.WriteString(": ")
+		if s.List == nil {
+			writeJSON(&b, s.Value)
+		} else if len(s.List) == 0 {
+			b.WriteString("[]")
+		} else {
+			b.WriteString("[\n")
+			for j, v := range s.List {
+				b.WriteString("        ")
+				writeJSON(&b, v)
+				if j < len(s.List)-1 {
+					b.WriteByte(',')
+				}
+				b.WriteByte('\n')
+			}
+			b.WriteString("    ]")
+		}
+		if i < len(settings)-1 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// writeJSON writes s as a JSON string without escaping HTML characters,
+// which are common in G-code templates.
+func writeJSON(b *bytes.Buffer, s string) {
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	b.Truncate(b.Len() - 1) // Encode appends a newline.
+}
@@ -0,0 +1,167 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slicerconfig
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/go-test/deep"
+)
+
+const bambuProject = `{
+    "layer_height": "0.2",
+    "filament_colour": [
+        "#FF0000",
+        "#00FF00"
+    ],
+    "different_settings_to_system": [],
+    "machine_start_gcode": "G28 ; home\nM104 S{temp} > 0"
+}
+`
+
+const bambuModel = `<?xml version="1.0" encoding="UTF-8"?>
+<config>
+  <object id="2">
+    <metadata key="name" value="Cube"/>
+    <metadata key="extruder" value="1"/>
+    <part id="1" subtype="normal_part">
+      <metadata key="name" value="Cube"/>
+      <mesh_stat edges_fixed="0"/>
+    </part>
+  </object>
+  <plate>
+    <metadata key="plater_id" value="1"/>
+  </plate>
+</config>
+`
+
+func bambuModelWithConfig() *go3mf.Model {
+	return &go3mf.Model{
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{{ID: 2}}},
+		Attachments: []go3mf.Attachment{
+			{Path: PathBambuProject, ContentType: ContentTypeJSON, Stream: bytes.NewBufferString(bambuProject)},
+			{Path: PathBambuModel, ContentType: ContentTypeXML, Stream: bytes.NewBufferString(bambuModel)},
+		},
+	}
+}
+
+func TestReadBambu(t *testing.T) {
+	want := &BambuConfig{
+		Settings: Settings{
+			{Key: "layer_height", Value: "0.2"},
+			{Key: "filament_colour", List: []string{"#FF0000", "#00FF00"}},
+			{Key: "different_settings_to_system", List: []string{}},
+			{Key: "machine_start_gcode", Value: "G28 ; home\nM104 S{temp} > 0"},
+		},
+		Objects: Objects{{
+			ObjectID: 2,
+			Settings: Settings{{Key: "name", Value: "Cube"}, {Key: "extruder", Value: "1"}},
+			Parts: []PartSettings{{
+				Attrs: []xml.Attr{
+					{Name: xml.Name{Local: "id"}, Value: "1"},
+					{Name: xml.Name{Local: "subtype"}, Value: "normal_part"},
+				},
+				Settings: Settings{{Key: "name", Value: "Cube"}},
+				Extra: []Element{{
+					XMLName: xml.Name{Local: "mesh_stat"},
+					Attrs:   []xml.Attr{{Name: xml.Name{Local: "edges_fixed"}, Value: "0"}},
+				}},
+			}},
+		}},
+		Extra: []Element{{
+			XMLName: xml.Name{Local: "plate"},
+			Inner:   "\n    <metadata key=\"plater_id\" value=\"1\"/>\n  ",
+		}},
+	}
+	tests := []struct {
+		name    string
+		m       *go3mf.Model
+		want    *BambuConfig
+		wantErr bool
+	}{
+		{"empty", new(go3mf.Model), nil, false},
+		{"base", bambuModelWithConfig(), want, false},
+		{"invalidJSON", &go3mf.Model{Attachments: []go3mf.Attachment{
+			{Path: PathBambuProject, Stream: bytes.NewBufferString(`{"a": `)},
+		}}, nil, true},
+		{"invalidModel", &go3mf.Model{Attachments: []go3mf.Attachment{
+			{Path: PathBambuModel, Stream: bytes.NewBufferString(`<config>`)},
+		}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadBambu(tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadBambu() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("ReadBambu() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestWriteBambu(t *testing.T) {
+	m := bambuModelWithConfig()
+	c, err := ReadBambu(m)
+	if err != nil {
+		t.Fatalf("ReadBambu() error = %v", err)
+	}
+	c.Settings.SetList("filament_colour", []string{"#0000FF"})
+	c.Objects[0].Parts[0].Settings.Set("extruder", "2")
+	if err := WriteBambu(m, c); err != nil {
+		t.Fatalf("WriteBambu() error = %v", err)
+	}
+	newModel := roundtrip(t, m)
+	got, err := ReadBambu(newModel)
+	if err != nil {
+		t.Fatalf("ReadBambu() error = %v", err)
+	}
+	if diff := deep.Equal(got, c); diff != nil {
+		t.Errorf("WriteBambu() = %v", diff)
+	}
+}
+
+func TestWriteBambu_empty(t *testing.T) {
+	m := bambuModelWithConfig()
+	if err := WriteBambu(m, new(BambuConfig)); err != nil {
+		t.Fatalf("WriteBambu() error = %v", err)
+	}
+	if len(m.Attachments) != 0 {
+		t.Errorf("WriteBambu() attachments = %v", m.Attachments)
+	}
+}
+
+func Test_decodeBambuSettings_error(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"array", `["a"]`},
+		{"number", `{"a": 1}`},
+		{"object", `{"a": {}}`},
+		{"numberList", `{"a": [1]}`},
+		{"truncatedList", `{"a": ["b"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeBambuSettings([]byte(tt.data)); err == nil {
+				t.Error("decodeBambuSettings() expected error")
+			}
+		})
+	}
+}
+
+func Test_encodeBambuSettings(t *testing.T) {
+	settings, err := decodeBambuSettings([]byte(bambuProject))
+	if err != nil {
+		t.Fatalf("decodeBambuSettings() error = %v", err)
+	}
+	if got := string(encodeBambuSettings(settings)); got != bambuProject {
+		t.Errorf("encodeBambuSettings() = %s, want %s", got, bambuProject)
+	}
+}
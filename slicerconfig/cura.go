@@ -0,0 +1,202 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slicerconfig
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+)
+
+// CuraNamespace is the namespace of the Cura object metadata.
+const CuraNamespace = "http://software.ultimaker.com/xml/cura/3mf/2015/10"
+
+const curaPrefix = "cura"
+
+// CuraSection is a section of a Cura settings stack.
+type CuraSection struct {
+	Name     string
+	Settings Settings
+}
+
+// CuraStack is a Cura settings stack stored as an INI file
+// under the Cura directory, such as the global or the extruder stacks.
+type CuraStack struct {
+	Path     string
+	Sections []CuraSection
+}
+
+// CuraConfig defines the Cura project configuration.
+// The per-object settings are stored by Cura as object metadata
+// prefixed by cura, so the object Parts are not used.
+type CuraConfig struct {
+	Stacks  []CuraStack
+	Objects Objects
+}
+
+func isCuraPath(path string) bool {
+	path = strings.ToLower(path)
+	return strings.HasPrefix(path, strings.ToLower(PathCuraDir)) && strings.HasSuffix(path, ".cfg")
+}
+
+// ReadCura returns the Cura configuration stored in the model attachments
+// and in the root model objects metadata, or nil if the model does not have any.
+func ReadCura(m *go3mf.Model) (*CuraConfig, error) {
+	c := new(CuraConfig)
+	for _, a := range m.Attachments {
+		if !isCuraPath(a.Path) {
+			continue
+		}
+		data, _, err := readAttachment(m, a.Path)
+		if err != nil {
+			return nil, err
+		}
+		c.Stacks = append(c.Stacks, CuraStack{Path: a.Path, Sections: decodeINI(data)})
+	}
+	for _, o := range m.Resources.Objects {
+		var settings Settings
+		for _, md := range o.Metadata {
+			if key, ok := curaKey(md.Name); ok {
+				settings = append(settings, Setting{Key: key, Value: md.Value})
+			}
+		}
+		if len(settings) != 0 {
+			c.Objects = append(c.Objects, ObjectSettings{ObjectID: o.ID, Settings: settings})
+		}
+	}
+	if len(c.Stacks) == 0 && len(c.Objects) == 0 {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// WriteCura stores the Cura configuration in the model attachments
+// and in the root model objects metadata, replacing the existing one.
+func WriteCura(m *go3mf.Model, c *CuraConfig) error {
+	var errs error
+	for i, o := range c.Objects {
+		if _, ok := o.Object(m); !ok {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrMissingResource, o, i))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	for i := len(m.Attachments) - 1; i >= 0; i-- {
+		path := m.Attachments[i].Path
+		if isCuraPath(path) && !hasStack(c.Stacks, path) {
+			removeAttachment(m, path)
+		}
+	}
+	for _, s := range c.Stacks {
+		writeAttachment(m, s.Path, ContentTypeText, encodeINI(s.Sections))
+	}
+	for _, o := range m.Resources.Objects {
+		metadata := o.Metadata[:0]
+		for _, md := range o.Metadata {
+			if _, ok := curaKey(md.Name); !ok {
+				metadata = append(metadata, md)
+			}
+		}
+		o.Metadata = metadata
+	}
+	for _, obj := range c.Objects {
+		o, _ := obj.Object(m)
+		for _, s := range obj.Settings {
+			o.Metadata = append(o.Metadata, go3mf.Metadata{
+				Name: xml.Name{Space: curaPrefix, Local: s.Key}, Value: s.Value, Type: "xs:string", Preserve: true,
+			})
+		}
+		if len(obj.Settings) != 0 && !hasCuraExtension(m) {
+			m.Extensions = append(m.Extensions, go3mf.Extension{Namespace: CuraNamespace, LocalName: curaPrefix})
+		}
+	}
+	return nil
+}
+
+// curaKey returns the setting key of a Cura metadata name,
+// which is only split into prefix and local name when the namespace is declared.
+func curaKey(name xml.Name) (string, bool) {
+	if name.Space == curaPrefix {
+		return name.Local, true
+	}
+	if name.Space == "" && strings.HasPrefix(name.Local, curaPrefix+":") {
+		return name.Local[len(curaPrefix)+1:], true
+	}
+	return "", false
+}
+
+func hasCuraExtension(m *go3mf.Model) bool {
+	for _, ext := range m.Extensions {
+		if ext.Namespace == CuraNamespace {
+			return true
+		}
+	}
+	return false
+}
+
+func hasStack(stacks []CuraStack, path string) bool {
+	for _, s := range stacks {
+		if strings.EqualFold(s.Path, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeINI parses the INI files written by the Python configparser,
+// where indented lines continue the previous value.
+func decodeINI(data []byte) []CuraSection {
+	var (
+		sections []CuraSection
+		last     *Setting
+	)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || trimmed[0] == ';' || trimmed[0] == '#':
+			continue
+		case line[0] == ' ' || line[0] == '\t':
+			if last != nil {
+				last.Value += "\n" + trimmed
+			}
+			continue
+		case trimmed[0] == '[' && trimmed[len(trimmed)-1] == ']':
+			sections = append(sections, CuraSection{Name: trimmed[1 : len(trimmed)-1]})
+			last = nil
+			continue
+		}
+		if len(sections) == 0 {
+			sections = append(sections, CuraSection{})
+		}
+		sec := &sections[len(sections)-1]
+		s := Setting{Key: trimmed}
+		if i := strings.IndexByte(trimmed, '='); i >= 0 {
+			s.Key, s.Value = strings.TrimSpace(trimmed[:i]), strings.TrimSpace(trimmed[i+1:])
+		}
+		sec.Settings = append(sec.Settings, s)
+		last = &sec.Settings[len(sec.Settings)-1]
+	}
+	return sections
+}
+
+func encodeINI(sections []CuraSection) []byte {
+	var b bytes.Buffer
+	for i, sec := range sections {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		if sec.Name != "" || i > 0 {
+			b.WriteString("[" + sec.Name + "]\n")
+		}
+		for _, s := range sec.Settings {
+			b.WriteString(s.Key + " = " + strings.Replace(s.Value, "\n", "\n\t", -1) + "\n")
+		}
+	}
+	return b.Bytes()
+}
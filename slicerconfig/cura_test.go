@@ -0,0 +1,147 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slicerconfig
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/go-test/deep"
+)
+
+const curaStack = `[general]
+version = 4
+name = Generic PLA
+
+[metadata]
+type = quality_changes
+; comment
+
+[values]
+layer_height = 0.15
+machine_start_gcode = G28
+	G1 Z15
+`
+
+func curaModel() *go3mf.Model {
+	return &go3mf.Model{
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Metadata: []go3mf.Metadata{
+				{Name: xml.Name{Space: "cura", Local: "infill_sparse_density"}, Value: "20", Type: "xs:string", Preserve: true},
+				{Name: xml.Name{Local: "Title"}, Value: "a"},
+			}},
+			{ID: 2, Metadata: []go3mf.Metadata{
+				{Name: xml.Name{Local: "cura:extruder_nr"}, Value: "1"},
+			}},
+			{ID: 3},
+		}},
+		Attachments: []go3mf.Attachment{
+			{Path: "/Cura/Generic.global.cfg", ContentType: ContentTypeText, Stream: bytes.NewBufferString(curaStack)},
+			{Path: "/Metadata/thumbnail.png", ContentType: "image/png", Stream: new(bytes.Buffer)},
+		},
+	}
+}
+
+func wantCura() *CuraConfig {
+	return &CuraConfig{
+		Stacks: []CuraStack{{Path: "/Cura/Generic.global.cfg", Sections: []CuraSection{
+			{Name: "general", Settings: Settings{{Key: "version", Value: "4"}, {Key: "name", Value: "Generic PLA"}}},
+			{Name: "metadata", Settings: Settings{{Key: "type", Value: "quality_changes"}}},
+			{Name: "values", Settings: Settings{
+				{Key: "layer_height", Value: "0.15"}, {Key: "machine_start_gcode", Value: "G28\nG1 Z15"},
+			}},
+		}}},
+		Objects: Objects{
+			{ObjectID: 1, Settings: Settings{{Key: "infill_sparse_density", Value: "20"}}},
+			{ObjectID: 2, Settings: Settings{{Key: "extruder_nr", Value: "1"}}},
+		},
+	}
+}
+
+func TestReadCura(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *go3mf.Model
+		want *CuraConfig
+	}{
+		{"empty", new(go3mf.Model), nil},
+		{"base", curaModel(), wantCura()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadCura(tt.m)
+			if err != nil {
+				t.Fatalf("ReadCura() error = %v", err)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("ReadCura() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestWriteCura(t *testing.T) {
+	m := curaModel()
+	m.Attachments = append(m.Attachments, go3mf.Attachment{
+		Path: "/Cura/old.cfg", ContentType: ContentTypeText, Stream: new(bytes.Buffer),
+	})
+	c := wantCura()
+	c.Objects = Objects{{ObjectID: 3, Settings: Settings{{Key: "extruder_nr", Value: "0"}}}}
+	c.Stacks[0].Sections[2].Settings.Set("layer_height", "0.1")
+	if err := WriteCura(m, c); err != nil {
+		t.Fatalf("WriteCura() error = %v", err)
+	}
+	want := []go3mf.Metadata{{Name: xml.Name{Local: "Title"}, Value: "a"}}
+	if diff := deep.Equal(m.Resources.Objects[0].Metadata, want); diff != nil {
+		t.Errorf("WriteCura() metadata = %v", diff)
+	}
+	newModel := roundtrip(t, m)
+	if len(newModel.Attachments) != 1 {
+		t.Errorf("WriteCura() attachments = %v", newModel.Attachments)
+	}
+	got, err := ReadCura(newModel)
+	if err != nil {
+		t.Fatalf("ReadCura() error = %v", err)
+	}
+	if diff := deep.Equal(got, c); diff != nil {
+		t.Errorf("WriteCura() = %v", diff)
+	}
+}
+
+func TestWriteCura_error(t *testing.T) {
+	m := curaModel()
+	err := WriteCura(m, &CuraConfig{Objects: Objects{{ObjectID: 1}, {ObjectID: 5}}})
+	if err == nil {
+		t.Fatal("WriteCura() expected error")
+	}
+	var errs []string
+	for _, err := range err.(*errors.List).Errors {
+		errs = append(errs, err.Error())
+	}
+	want := []string{fmt.Sprintf("ObjectSettings#1: %v", errors.ErrMissingResource)}
+	if diff := deep.Equal(errs, want); diff != nil {
+		t.Errorf("WriteCura() = %v", diff)
+	}
+	if diff := deep.Equal(m, curaModel()); diff != nil {
+		t.Errorf("WriteCura() modified the model = %v", diff)
+	}
+}
+
+func Test_encodeINI(t *testing.T) {
+	sections := decodeINI([]byte("a = 1\n[b]\nc\n"))
+	want := []CuraSection{
+		{Settings: Settings{{Key: "a", Value: "1"}}},
+		{Name: "b", Settings: Settings{{Key: "c"}}},
+	}
+	if diff := deep.Equal(sections, want); diff != nil {
+		t.Errorf("decodeINI() = %v", diff)
+	}
+	if got := string(encodeINI(sections)); got != "a = 1\n\n[b]\nc = \n" {
+		t.Errorf("encodeINI() = %q", got)
+	}
+}
@@ -0,0 +1,122 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slicerconfig
+
+import (
+	"encoding/xml"
+)
+
+// xmlConfig is the per-object configuration shared by PrusaSlicer
+// and Bambu Studio, which only differ in the part element name
+// and in the metadata type attribute, only used by PrusaSlicer.
+type xmlConfig struct {
+	XMLName xml.Name    `xml:"config"`
+	Objects []xmlObject `xml:"object"`
+	Extra   []Element   `xml:",any"`
+}
+
+type xmlObject struct {
+	ID       uint32        `xml:"id,attr"`
+	Attrs    []xml.Attr    `xml:",any,attr"`
+	Metadata []xmlMetadata `xml:"metadata"`
+	Volumes  []xmlPart     `xml:"volume"`
+	Parts    []xmlPart     `xml:"part"`
+	Extra    []Element     `xml:",any"`
+}
+
+type xmlPart struct {
+	Attrs    []xml.Attr    `xml:",any,attr"`
+	Metadata []xmlMetadata `xml:"metadata"`
+	Extra    []Element     `xml:",any"`
+}
+
+type xmlMetadata struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Key   string `xml:"key,attr"`
+	Value string `xml:"value,attr"`
+}
+
+const (
+	metadataObject = "object"
+	metadataVolume = "volume"
+)
+
+func decodeModelConfig(data []byte) (Objects, []Element, error) {
+	var cfg xmlConfig
+	if err := xml.Unmarshal(data, &cfg); err != nil {
+		return nil, nil, err
+	}
+	objs := make(Objects, len(cfg.Objects))
+	for i, o := range cfg.Objects {
+		objs[i] = ObjectSettings{
+			ObjectID: o.ID,
+			Attrs:    o.Attrs,
+			Settings: decodeMetadata(o.Metadata),
+			Extra:    o.Extra,
+		}
+		for _, p := range append(o.Volumes, o.Parts...) {
+			objs[i].Parts = append(objs[i].Parts, PartSettings{
+				Attrs:    p.Attrs,
+				Settings: decodeMetadata(p.Metadata),
+				Extra:    p.Extra,
+			})
+		}
+	}
+	return objs, cfg.Extra, nil
+}
+
+// encodeModelConfig encodes the objects using the PrusaSlicer
+// flavor when prusa is true and the Bambu Studio one otherwise.
+func encodeModelConfig(objs Objects, extra []Element, prusa bool) ([]byte, error) {
+	cfg := xmlConfig{Objects: make([]xmlObject, len(objs)), Extra: extra}
+	var objType, partType string
+	if prusa {
+		objType, partType = metadataObject, metadataVolume
+	}
+	for i, o := range objs {
+		xo := xmlObject{
+			ID:       o.ObjectID,
+			Attrs:    o.Attrs,
+			Metadata: encodeMetadata(o.Settings, objType),
+			Extra:    o.Extra,
+		}
+		for _, p := range o.Parts {
+			xp := xmlPart{Attrs: p.Attrs, Metadata: encodeMetadata(p.Settings, partType), Extra: p.Extra}
+			if prusa {
+				xo.Volumes = append(xo.Volumes, xp)
+			} else {
+				xo.Parts = append(xo.Parts, xp)
+			}
+		}
+		cfg.Objects[i] = xo
+	}
+	indent := "  "
+	if prusa {
+		indent = " "
+	}
+	b, err := xml.MarshalIndent(&cfg, "", indent)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(b, '\n')...), nil
+}
+
+func decodeMetadata(md []xmlMetadata) Settings {
+	if len(md) == 0 {
+		return nil
+	}
+	s := make(Settings, len(md))
+	for i, m := range md {
+		s[i] = Setting{Key: m.Key, Value: m.Value}
+	}
+	return s
+}
+
+func encodeMetadata(s Settings, typ string) []xmlMetadata {
+	md := make([]xmlMetadata, len(s))
+	for i, st := range s {
+		md[i] = xmlMetadata{Type: typ, Key: st.Key, Value: st.Value}
+	}
+	return md
+}
@@ -0,0 +1,62 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slicerconfig
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func Test_encodeModelConfig(t *testing.T) {
+	objs := Objects{{
+		ObjectID: 1,
+		Attrs:    []xml.Attr{{Name: xml.Name{Local: "instances_count"}, Value: "1"}},
+		Settings: Settings{{Key: "name", Value: "a&b"}},
+		Parts:    []PartSettings{{Settings: Settings{{Key: "name", Value: "c"}}}},
+	}}
+	extra := []Element{{XMLName: xml.Name{Local: "plate"}, Inner: `<metadata key="plater_id" value="1"/>`}}
+	tests := []struct {
+		name  string
+		prusa bool
+		extra []Element
+		want  string
+	}{
+		{"prusa", true, nil, xml.Header + `<config>
+ <object id="1" instances_count="1">
+  <metadata type="object" key="name" value="a&amp;b"></metadata>
+  <volume>
+   <metadata type="volume" key="name" value="c"></metadata>
+  </volume>
+ </object>
+</config>
+`},
+		{"bambu", false, extra, xml.Header + `<config>
+  <object id="1" instances_count="1">
+    <metadata key="name" value="a&amp;b"></metadata>
+    <part>
+      <metadata key="name" value="c"></metadata>
+    </part>
+  </object>
+  <plate><metadata key="plater_id" value="1"/></plate>
+</config>
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeModelConfig(objs, tt.extra, tt.prusa)
+			if err != nil {
+				t.Fatalf("encodeModelConfig() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("encodeModelConfig() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_decodeModelConfig_error(t *testing.T) {
+	if _, _, err := decodeModelConfig([]byte(`<config><object id="-1"/></config>`)); err == nil {
+		t.Error("decodeModelConfig() expected error")
+	}
+}
@@ -0,0 +1,101 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slicerconfig
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/MosaicManufacturing/go3mf"
+)
+
+// PrusaConfig defines the PrusaSlicer project configuration.
+// Header holds the comment lines that are not settings,
+// such as the generator one.
+type PrusaConfig struct {
+	Header   []string
+	Settings Settings
+	Objects  Objects
+}
+
+// ReadPrusa returns the PrusaSlicer configuration stored in the model
+// attachments, or nil if the model does not have any.
+func ReadPrusa(m *go3mf.Model) (*PrusaConfig, error) {
+	data, okCfg, err := readAttachment(m, PathPrusaConfig)
+	if err != nil {
+		return nil, err
+	}
+	modelData, okModel, err := readAttachment(m, PathPrusaModelConfig)
+	if err != nil {
+		return nil, err
+	}
+	if !okCfg && !okModel {
+		return nil, nil
+	}
+	c := new(PrusaConfig)
+	c.Header, c.Settings = decodePrusaSettings(data)
+	if len(bytes.TrimSpace(modelData)) != 0 {
+		if c.Objects, _, err = decodeModelConfig(modelData); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// WritePrusa stores the PrusaSlicer configuration in the model attachments,
+// replacing the existing one. Empty parts are removed.
+func WritePrusa(m *go3mf.Model, c *PrusaConfig) error {
+	if len(c.Header) == 0 && len(c.Settings) == 0 {
+		removeAttachment(m, PathPrusaConfig)
+	} else {
+		writeAttachment(m, PathPrusaConfig, ContentTypeText, encodePrusaSettings(c.Header, c.Settings))
+	}
+	if len(c.Objects) == 0 {
+		removeAttachment(m, PathPrusaModelConfig)
+		return nil
+	}
+	data, err := encodeModelConfig(c.Objects, nil, true)
+	if err != nil {
+		return err
+	}
+	writeAttachment(m, PathPrusaModelConfig, ContentTypeXML, data)
+	return nil
+}
+
+// decodePrusaSettings parses the "; key = value" lines.
+func decodePrusaSettings(data []byte) ([]string, Settings) {
+	var (
+		header   []string
+		settings Settings
+	)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), ";"))
+		if line == "" {
+			continue
+		}
+		if i := strings.Index(line, " ="); i > 0 {
+			settings = append(settings, Setting{
+				Key:   strings.TrimSpace(line[:i]),
+				Value: strings.TrimSpace(line[i+2:]),
+			})
+		} else {
+			header = append(header, line)
+		}
+	}
+	return header, settings
+}
+
+func encodePrusaSettings(header []string, settings Settings) []byte {
+	var b bytes.Buffer
+	for _, h := range header {
+		b.WriteString("; " + h + "\n")
+	}
+	if len(header) != 0 {
+		b.WriteByte('\n')
+	}
+	for _, s := range settings {
+		b.WriteString("; " + s.Key + " = " + s.Value + "\n")
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,151 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slicerconfig
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/go-test/deep"
+)
+
+const prusaConfig = `; generated by PrusaSlicer 2.4.0+linux-x64 on 2021-12-01 at 10:00:00 UTC
+
+; avoid_crossing_perimeters = 0
+; bed_shape = 0x0,250x0,250x210,0x210
+; end_gcode = M104 S0 ; turn off temperature\nG28 X0
+; notes =
+`
+
+const prusaModelConfig = `<?xml version="1.0" encoding="UTF-8"?>
+<config>
+ <object id="1" instances_count="1">
+  <metadata type="object" key="name" value="Cube"/>
+  <metadata type="object" key="extruder" value="2"/>
+  <volume firstid="0" lastid="11">
+   <metadata type="volume" key="name" value="Cube"/>
+   <metadata type="volume" key="volume_type" value="ModelPart"/>
+   <mesh edges_fixed="0" degenerate_facets="0"/>
+  </volume>
+ </object>
+</config>
+`
+
+func prusaModel() *go3mf.Model {
+	return &go3mf.Model{
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{{ID: 1}}},
+		Attachments: []go3mf.Attachment{
+			{Path: PathPrusaConfig, ContentType: ContentTypeText, Stream: bytes.NewBufferString(prusaConfig)},
+			{Path: PathPrusaModelConfig, ContentType: ContentTypeXML, Stream: bytes.NewBufferString(prusaModelConfig)},
+		},
+	}
+}
+
+func wantPrusa() *PrusaConfig {
+	return &PrusaConfig{
+		Header: []string{"generated by PrusaSlicer 2.4.0+linux-x64 on 2021-12-01 at 10:00:00 UTC"},
+		Settings: Settings{
+			{Key: "avoid_crossing_perimeters", Value: "0"},
+			{Key: "bed_shape", Value: "0x0,250x0,250x210,0x210"},
+			{Key: "end_gcode", Value: `M104 S0 ; turn off temperature\nG28 X0`},
+			{Key: "notes", Value: ""},
+		},
+		Objects: Objects{{
+			ObjectID: 1,
+			Attrs:    []xml.Attr{{Name: xml.Name{Local: "instances_count"}, Value: "1"}},
+			Settings: Settings{{Key: "name", Value: "Cube"}, {Key: "extruder", Value: "2"}},
+			Parts: []PartSettings{{
+				Attrs: []xml.Attr{
+					{Name: xml.Name{Local: "firstid"}, Value: "0"},
+					{Name: xml.Name{Local: "lastid"}, Value: "11"},
+				},
+				Settings: Settings{{Key: "name", Value: "Cube"}, {Key: "volume_type", Value: "ModelPart"}},
+				Extra: []Element{{
+					XMLName: xml.Name{Local: "mesh"},
+					Attrs: []xml.Attr{
+						{Name: xml.Name{Local: "edges_fixed"}, Value: "0"},
+						{Name: xml.Name{Local: "degenerate_facets"}, Value: "0"},
+					},
+				}},
+			}},
+		}},
+	}
+}
+
+func TestReadPrusa(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *go3mf.Model
+		want    *PrusaConfig
+		wantErr bool
+	}{
+		{"empty", new(go3mf.Model), nil, false},
+		{"base", prusaModel(), wantPrusa(), false},
+		{"onlySettings", &go3mf.Model{Attachments: []go3mf.Attachment{
+			{Path: PathPrusaConfig, Stream: bytes.NewBufferString("; a = 1\n")},
+		}}, &PrusaConfig{Settings: Settings{{Key: "a", Value: "1"}}}, false},
+		{"invalidModel", &go3mf.Model{Attachments: []go3mf.Attachment{
+			{Path: PathPrusaModelConfig, Stream: bytes.NewBufferString(`<config><object id="a"/></config>`)},
+		}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadPrusa(tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadPrusa() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("ReadPrusa() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestWritePrusa(t *testing.T) {
+	m := prusaModel()
+	c, err := ReadPrusa(m)
+	if err != nil {
+		t.Fatalf("ReadPrusa() error = %v", err)
+	}
+	c.Settings.Set("notes", "painted")
+	c.Objects[0].Settings.Set("extruder", "1")
+	if err := WritePrusa(m, c); err != nil {
+		t.Fatalf("WritePrusa() error = %v", err)
+	}
+	newModel := roundtrip(t, m)
+	if len(newModel.Attachments) != 2 {
+		t.Fatalf("WritePrusa() attachments = %v", newModel.Attachments)
+	}
+	got, err := ReadPrusa(newModel)
+	if err != nil {
+		t.Fatalf("ReadPrusa() error = %v", err)
+	}
+	if diff := deep.Equal(got, c); diff != nil {
+		t.Errorf("WritePrusa() = %v", diff)
+	}
+}
+
+func TestWritePrusa_empty(t *testing.T) {
+	m := prusaModel()
+	if err := WritePrusa(m, new(PrusaConfig)); err != nil {
+		t.Fatalf("WritePrusa() error = %v", err)
+	}
+	if len(m.Attachments) != 0 {
+		t.Errorf("WritePrusa() attachments = %v", m.Attachments)
+	}
+}
+
+func Test_encodePrusaSettings(t *testing.T) {
+	header, settings := decodePrusaSettings([]byte(prusaConfig))
+	want := "; generated by PrusaSlicer 2.4.0+linux-x64 on 2021-12-01 at 10:00:00 UTC\n\n" +
+		"; avoid_crossing_perimeters = 0\n" +
+		"; bed_shape = 0x0,250x0,250x210,0x210\n" +
+		"; end_gcode = M104 S0 ; turn off temperature\\nG28 X0\n" +
+		"; notes = \n"
+	if got := string(encodePrusaSettings(header, settings)); got != want {
+		t.Errorf("encodePrusaSettings() = %s, want %s", got, want)
+	}
+}
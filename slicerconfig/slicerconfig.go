@@ -0,0 +1,183 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+// Package slicerconfig reads and writes the slicer settings that
+// PrusaSlicer, Bambu Studio and Cura embed in the 3MF package.
+//
+// These parts are not the target of any relationship, so the decoder
+// only extracts them as attachments when asked to:
+//
+//	d.SetAttachmentFilter(slicerconfig.IsConfigPath)
+package slicerconfig
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/MosaicManufacturing/go3mf"
+)
+
+// Paths of the slicer configuration parts.
+const (
+	PathPrusaConfig      = "/Metadata/Slic3r_PE.config"
+	PathPrusaModelConfig = "/Metadata/Slic3r_PE_model.config"
+	PathBambuProject     = "/Metadata/project_settings.config"
+	PathBambuModel       = "/Metadata/model_settings.config"
+	PathCuraDir          = "/Cura/"
+)
+
+// Content types used when creating new configuration parts.
+const (
+	ContentTypeText = "text/plain"
+	ContentTypeXML  = "text/xml"
+	ContentTypeJSON = "application/json"
+)
+
+var ErrUnsupportedValue = errors.New("unsupported setting value")
+
+// IsConfigPath returns true if path is a slicer configuration part.
+// It can be used as a go3mf.Decoder attachment filter.
+func IsConfigPath(path string) bool {
+	for _, p := range []string{PathPrusaConfig, PathPrusaModelConfig, PathBambuProject, PathBambuModel} {
+		if strings.EqualFold(path, p) {
+			return true
+		}
+	}
+	return isCuraPath(path)
+}
+
+// Setting is a key-value slicer setting.
+// List holds the values of the settings stored as arrays,
+// in which case Value is empty.
+type Setting struct {
+	Key   string
+	Value string
+	List  []string
+}
+
+// Settings is an ordered list of settings.
+type Settings []Setting
+
+// Find returns the setting with the target key.
+func (s Settings) Find(key string) (*Setting, bool) {
+	for i := range s {
+		if s[i].Key == key {
+			return &s[i], true
+		}
+	}
+	return nil, false
+}
+
+// Set sets the value of the setting with the target key,
+// appending it if it does not exist.
+func (s *Settings) Set(key, value string) {
+	if st, ok := s.Find(key); ok {
+		st.Value, st.List = value, nil
+		return
+	}
+	*s = append(*s, Setting{Key: key, Value: value})
+}
+
+// SetList sets the values of the array setting with the target key,
+// appending it if it does not exist.
+func (s *Settings) SetList(key string, values []string) {
+	if values == nil {
+		values = []string{}
+	}
+	if st, ok := s.Find(key); ok {
+		st.Value, st.List = "", values
+		return
+	}
+	*s = append(*s, Setting{Key: key, List: values})
+}
+
+// Element is an XML element that is preserved verbatim.
+type Element struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Inner   string     `xml:",innerxml"`
+}
+
+// PartSettings defines the settings of an object part,
+// a volume in PrusaSlicer and a part in Bambu Studio.
+type PartSettings struct {
+	Attrs    []xml.Attr
+	Settings Settings
+	Extra    []Element
+}
+
+// ObjectSettings defines the settings that override
+// the global ones for the object with ID ObjectID.
+type ObjectSettings struct {
+	ObjectID uint32
+	Attrs    []xml.Attr
+	Settings Settings
+	Parts    []PartSettings
+	Extra    []Element
+}
+
+// Objects is a list of per-object settings.
+type Objects []ObjectSettings
+
+// Find returns the settings of the object with the target ID.
+func (o Objects) Find(id uint32) (*ObjectSettings, bool) {
+	for i := range o {
+		if o[i].ObjectID == id {
+			return &o[i], true
+		}
+	}
+	return nil, false
+}
+
+// Object returns the model object the settings apply to.
+func (o *ObjectSettings) Object(m *go3mf.Model) (*go3mf.Object, bool) {
+	return m.Resources.FindObject(o.ObjectID)
+}
+
+// readAttachment returns the content of the attachment located at path.
+// The attachment stream is replaced so it can still be encoded.
+func readAttachment(m *go3mf.Model, path string) ([]byte, bool, error) {
+	a, ok := m.FindAttachment(path)
+	if !ok {
+		return nil, false, nil
+	}
+	if a.Stream == nil {
+		return nil, true, nil
+	}
+	data, err := ioutil.ReadAll(a.Stream)
+	if err != nil {
+		return nil, true, err
+	}
+	a.Stream = bytes.NewBuffer(data)
+	return data, true, nil
+}
+
+// writeAttachment replaces the content of the attachment located at path,
+// adding it with contentType if it does not exist.
+func writeAttachment(m *go3mf.Model, path, contentType string, data []byte) {
+	if a, ok := m.FindAttachment(path); ok {
+		a.Stream = bytes.NewBuffer(data)
+		if a.ContentType == "" {
+			a.ContentType = contentType
+		}
+		return
+	}
+	m.Attachments = append(m.Attachments, go3mf.Attachment{
+		Path:        path,
+		ContentType: contentType,
+		Stream:      bytes.NewBuffer(data),
+	})
+}
+
+// removeAttachment removes the attachment located at path.
+func removeAttachment(m *go3mf.Model, path string) {
+	for i := range m.Attachments {
+		if strings.EqualFold(m.Attachments[i].Path, path) {
+			m.Attachments = append(m.Attachments[:i], m.Attachments[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slicerconfig
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/go-test/deep"
+)
+
+func TestIsConfigPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{PathPrusaConfig, true},
+		{"/metadata/slic3r_pe.config", true},
+		{PathPrusaModelConfig, true},
+		{PathBambuProject, true},
+		{PathBambuModel, true},
+		{"/Cura/a.global.cfg", true},
+		{"/Cura/a.txt", false},
+		{"/Metadata/thumbnail.png", false},
+		{"/3D/3dmodel.model", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := IsConfigPath(tt.path); got != tt.want {
+				t.Errorf("IsConfigPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettings(t *testing.T) {
+	s := Settings{{Key: "a", Value: "1"}, {Key: "b", List: []string{"x"}}}
+	s.Set("a", "2")
+	s.Set("c", "3")
+	s.SetList("b", nil)
+	s.SetList("d", []string{"y", "z"})
+	want := Settings{
+		{Key: "a", Value: "2"},
+		{Key: "b", List: []string{}},
+		{Key: "c", Value: "3"},
+		{Key: "d", List: []string{"y", "z"}},
+	}
+	if diff := deep.Equal(s, want); diff != nil {
+		t.Errorf("Settings = %v", diff)
+	}
+	if _, ok := s.Find("e"); ok {
+		t.Error("Settings.Find() unexpected setting")
+	}
+}
+
+func TestObjects_Find(t *testing.T) {
+	objs := Objects{{ObjectID: 1}, {ObjectID: 3}}
+	if got, ok := objs.Find(3); !ok || got != &objs[1] {
+		t.Errorf("Objects.Find() = %v, %v", got, ok)
+	}
+	if _, ok := objs.Find(2); ok {
+		t.Error("Objects.Find() unexpected object")
+	}
+}
+
+func TestObjectSettings_Object(t *testing.T) {
+	obj := &go3mf.Object{ID: 2}
+	m := &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{obj}}}
+	if got, ok := (&ObjectSettings{ObjectID: 2}).Object(m); !ok || got != obj {
+		t.Errorf("ObjectSettings.Object() = %v, %v", got, ok)
+	}
+	if _, ok := (&ObjectSettings{ObjectID: 1}).Object(m); ok {
+		t.Error("ObjectSettings.Object() unexpected object")
+	}
+}
+
+func TestAttachments(t *testing.T) {
+	m := &go3mf.Model{Attachments: []go3mf.Attachment{
+		{Path: "/a.config", ContentType: "fake", Stream: bytes.NewBufferString("a")},
+	}}
+	for i := 0; i < 2; i++ {
+		data, ok, err := readAttachment(m, "/A.config")
+		if err != nil || !ok || string(data) != "a" {
+			t.Fatalf("readAttachment() = %s, %v, %v", data, ok, err)
+		}
+	}
+	if _, ok, _ := readAttachment(m, "/b.config"); ok {
+		t.Error("readAttachment() unexpected attachment")
+	}
+	writeAttachment(m, "/a.config", ContentTypeText, []byte("b"))
+	writeAttachment(m, "/b.config", ContentTypeText, []byte("c"))
+	if m.Attachments[0].ContentType != "fake" || m.Attachments[1].ContentType != ContentTypeText {
+		t.Errorf("writeAttachment() content types = %v", m.Attachments)
+	}
+	if data, _ := ioutil.ReadAll(m.Attachments[0].Stream); string(data) != "b" {
+		t.Errorf("writeAttachment() = %s", data)
+	}
+	removeAttachment(m, "/a.config")
+	if len(m.Attachments) != 1 || m.Attachments[0].Path != "/b.config" {
+		t.Errorf("removeAttachment() = %v", m.Attachments)
+	}
+}
+
+// roundtrip encodes the model as a 3MF package and decodes it
+// extracting the slicer configuration attachments.
+func roundtrip(t *testing.T, m *go3mf.Model) *go3mf.Model {
+	t.Helper()
+	buff := new(bytes.Buffer)
+	if err := go3mf.NewEncoder(buff).Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	d := go3mf.NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+	d.SetAttachmentFilter(IsConfigPath)
+	newModel := new(go3mf.Model)
+	if err := d.Decode(newModel); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	return newModel
+}
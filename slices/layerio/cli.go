@@ -0,0 +1,214 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package layerio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/slices"
+)
+
+var (
+	ErrCLIBinary  = errors.New("binary CLI files are not supported")
+	ErrCLICommand = errors.New("invalid CLI command")
+)
+
+// CLI polyline directions.
+const (
+	cliClockwise        = 0
+	cliCounterClockwise = 1
+	cliOpen             = 2
+)
+
+// CLIEncoder writes slice stacks in the ASCII variant of the Common Layer Interface.
+type CLIEncoder struct {
+	w io.Writer
+	// Units is the length of a CLI unit in millimeters. Defaults to 1.
+	Units float64
+	// LabelID and Label identify the part in the CLI file. Default to 1 and empty.
+	LabelID int
+	Label   string
+}
+
+// NewCLIEncoder creates a new CLI encoder.
+func NewCLIEncoder(w io.Writer) *CLIEncoder {
+	return &CLIEncoder{w: w, Units: 1, LabelID: 1}
+}
+
+// Encode writes the slices of st, resolving its references in m.
+//
+// Each slice is a layer at its top z and each polygon is a polyline
+// whose direction is counterclockwise for outer contours,
+// clockwise for holes and open for open contours.
+func (e *CLIEncoder) Encode(m *go3mf.Model, st *slices.SliceStack) error {
+	layers, err := layers(m, st)
+	if err != nil {
+		return err
+	}
+	units := e.Units
+	if units <= 0 {
+		units = 1
+	}
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, l := range layers {
+		for _, c := range l.contours {
+			for _, p := range c.points {
+				minX, minY = math.Min(minX, p[0]), math.Min(minY, p[1])
+				maxX, maxY = math.Max(maxX, p[0]), math.Max(maxY, p[1])
+			}
+		}
+	}
+	if minX > maxX {
+		minX, minY, maxX, maxY = 0, 0, 0, 0
+	}
+	var minZ, maxZ float64
+	if len(layers) > 0 {
+		minZ, maxZ = float64(st.BottomZ)*millimeters(m.Units), layers[len(layers)-1].z
+	}
+	w := bufio.NewWriter(e.w)
+	w.WriteString("$$HEADERSTART\n$$ASCII\n")
+	w.WriteString("$$UNITS/" + formatFloat(units) + "\n")
+	w.WriteString("$$VERSION/200\n")
+	w.WriteString("$$LABEL/" + strconv.Itoa(e.LabelID) + "," + e.Label + "\n")
+	w.WriteString("$$DIMENSION/" + strings.Join([]string{
+		formatFloat(minX), formatFloat(minY), formatFloat(minZ),
+		formatFloat(maxX), formatFloat(maxY), formatFloat(maxZ),
+	}, ",") + "\n")
+	w.WriteString("$$LAYERS/" + strconv.Itoa(len(layers)) + "\n")
+	w.WriteString("$$HEADEREND\n$$GEOMETRYSTART\n")
+	for _, l := range layers {
+		w.WriteString("$$LAYER/" + formatFloat(l.z/units) + "\n")
+		for _, c := range l.contours {
+			dir := cliOpen
+			points := c.points
+			if c.closed {
+				dir = cliCounterClockwise
+				if c.area() < 0 {
+					dir = cliClockwise
+				}
+				points = append(points[:len(points):len(points)], points[0])
+			}
+			params := []string{strconv.Itoa(e.LabelID), strconv.Itoa(dir), strconv.Itoa(len(points))}
+			for _, p := range points {
+				params = append(params, formatFloat(p[0]/units), formatFloat(p[1]/units))
+			}
+			w.WriteString("$$POLYLINE/" + strings.Join(params, ",") + "\n")
+		}
+	}
+	w.WriteString("$$GEOMETRYEND\n")
+	return w.Flush()
+}
+
+// CLIDecoder reads slice stacks from the ASCII variant of the Common Layer Interface.
+//
+// Hatches are ignored and polylines from all the parts are added to the same slices.
+type CLIDecoder struct {
+	r io.Reader
+}
+
+// NewCLIDecoder creates a new CLI decoder.
+func NewCLIDecoder(r io.Reader) *CLIDecoder {
+	return &CLIDecoder{r: r}
+}
+
+// Decode adds a slice stack with the CLI layers to the resources of m,
+// converting the coordinates to the model units.
+func (d *CLIDecoder) Decode(m *go3mf.Model) (*slices.SliceStack, error) {
+	var (
+		st    = new(slices.SliceStack)
+		units = 1.0
+		scale = millimeters(m.Units)
+		slice *slices.Slice
+		line  int
+	)
+	scanner := bufio.NewScanner(d.r)
+	scanner.Buffer(nil, math.MaxInt32)
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if i := strings.Index(text, "//"); i >= 0 {
+			text = text[:i]
+		}
+		for _, cmd := range strings.Split(text, "$$")[1:] {
+			cmd = strings.TrimSpace(cmd)
+			var params []string
+			name := cmd
+			if i := strings.IndexByte(cmd, '/'); i >= 0 {
+				name = cmd[:i]
+				params = strings.Split(cmd[i+1:], ",")
+			}
+			var err error
+			switch strings.ToUpper(name) {
+			case "BINARY":
+				return nil, ErrCLIBinary
+			case "UNITS":
+				if units, err = parseFloat(params); err == nil && units <= 0 {
+					err = ErrCLICommand
+				}
+			case "LAYER":
+				var z float64
+				if z, err = parseFloat(params); err == nil {
+					slice = &slices.Slice{TopZ: float32(z * units / scale)}
+					st.Slices = append(st.Slices, slice)
+				}
+			case "POLYLINE":
+				var c contour
+				if slice == nil {
+					err = ErrCLICommand
+				} else if c, err = parsePolyline(params, units); err == nil && len(c.points) > 0 {
+					addContour(slice, c, scale)
+				}
+			}
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w: %s", line, ErrCLICommand, cmd)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	st.ID = m.Resources.UnusedID()
+	m.Resources.Assets = append(m.Resources.Assets, st)
+	return st, nil
+}
+
+// parseFloat parses a command with a single real parameter.
+func parseFloat(params []string) (float64, error) {
+	if len(params) != 1 {
+		return 0, ErrCLICommand
+	}
+	return strconv.ParseFloat(strings.TrimSpace(params[0]), 64)
+}
+
+func parsePolyline(params []string, units float64) (contour, error) {
+	if len(params) < 3 {
+		return contour{}, ErrCLICommand
+	}
+	dir, err1 := strconv.Atoi(strings.TrimSpace(params[1]))
+	n, err2 := strconv.Atoi(strings.TrimSpace(params[2]))
+	if err1 != nil || err2 != nil || n < 0 || len(params) != 3+2*n {
+		return contour{}, ErrCLICommand
+	}
+	c := contour{closed: dir != cliOpen, points: make([][2]float64, n)}
+	for i := range c.points {
+		for j := range c.points[i] {
+			v, err := strconv.ParseFloat(strings.TrimSpace(params[3+2*i+j]), 64)
+			if err != nil {
+				return contour{}, ErrCLICommand
+			}
+			c.points[i][j] = v * units
+		}
+	}
+	if c.closed && n > 1 && c.points[0] == c.points[n-1] {
+		c.points = c.points[:n-1]
+	}
+	return c, nil
+}
@@ -0,0 +1,154 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package layerio
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/slices"
+	"github.com/go-test/deep"
+)
+
+const squareCLI = `$$HEADERSTART
+$$ASCII
+$$UNITS/0.5
+$$VERSION/200
+$$LABEL/3,part
+$$DIMENSION/0,0,0,10,10,0.2
+$$LAYERS/2
+$$HEADEREND
+$$GEOMETRYSTART
+$$LAYER/0.2
+$$POLYLINE/3,0,5,8,8,8,12,12,12,12,8,8,8
+$$POLYLINE/3,1,5,0,0,20,0,20,20,0,20,0,0
+$$POLYLINE/3,2,2,2,2,4,2
+$$LAYER/0.4
+$$GEOMETRYEND
+`
+
+func TestCLIEncoder_Encode(t *testing.T) {
+	var b bytes.Buffer
+	e := NewCLIEncoder(&b)
+	e.Units, e.LabelID, e.Label = 0.5, 3, "part"
+	st := &slices.SliceStack{Slices: []*slices.Slice{square(0.1), {TopZ: 0.2}}}
+	if err := e.Encode(new(go3mf.Model), st); err != nil {
+		t.Fatalf("CLIEncoder.Encode() error = %v", err)
+	}
+	if got := b.String(); got != squareCLI {
+		t.Errorf("CLIEncoder.Encode() = %s, want %s", got, squareCLI)
+	}
+	if err := e.Encode(new(go3mf.Model), &slices.SliceStack{Refs: []slices.SliceRef{{SliceStackID: 1}}}); err == nil {
+		t.Error("CLIEncoder.Encode() expected error")
+	}
+}
+
+func TestCLIDecoder_Decode(t *testing.T) {
+	m := &go3mf.Model{Units: go3mf.UnitCentimeter, Resources: go3mf.Resources{Objects: []*go3mf.Object{{ID: 1}}}}
+	got, err := NewCLIDecoder(strings.NewReader(squareCLI)).Decode(m)
+	if err != nil {
+		t.Fatalf("CLIDecoder.Decode() error = %v", err)
+	}
+	// Polygons are decoded in order, the hole being the first one.
+	want := square(0.01)
+	want.Polygons = []slices.Polygon{
+		{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}},
+		{StartV: 4, Segments: []slices.Segment{{V2: 5}, {V2: 6}, {V2: 7}, {V2: 4}}},
+		{StartV: 8, Segments: []slices.Segment{{V2: 9}}},
+	}
+	want.Vertices = []go3mf.Point2D{
+		{0.4, 0.4}, {0.4, 0.6}, {0.6, 0.6}, {0.6, 0.4},
+		{0, 0}, {1, 0}, {1, 1}, {0, 1},
+		{0.1, 0.1}, {0.2, 0.1},
+	}
+	wantStack := &slices.SliceStack{ID: 2, Slices: []*slices.Slice{want, {TopZ: 0.02}}}
+	if diff := deep.Equal(got, wantStack); diff != nil {
+		t.Errorf("CLIDecoder.Decode() = %v", diff)
+	}
+	if len(m.Resources.Assets) != 1 || m.Resources.Assets[0] != got {
+		t.Errorf("CLIDecoder.Decode() assets = %v", m.Resources.Assets)
+	}
+}
+
+func TestCLI_roundtrip(t *testing.T) {
+	st := &slices.SliceStack{Slices: []*slices.Slice{square(0.1), square(0.2)}}
+	var b bytes.Buffer
+	if err := NewCLIEncoder(&b).Encode(new(go3mf.Model), st); err != nil {
+		t.Fatalf("CLIEncoder.Encode() error = %v", err)
+	}
+	m := new(go3mf.Model)
+	got, err := NewCLIDecoder(&b).Decode(m)
+	if err != nil {
+		t.Fatalf("CLIDecoder.Decode() error = %v", err)
+	}
+	var want, gotSVG bytes.Buffer
+	NewSVGEncoder(&want).Encode(m, st)
+	NewSVGEncoder(&gotSVG).Encode(m, got)
+	if diff := deep.Equal(gotSVG.String(), want.String()); diff != nil {
+		t.Errorf("CLI roundtrip = %v", diff)
+	}
+}
+
+func TestCLIDecoder_Decode_syntax(t *testing.T) {
+	tests := []struct {
+		name string
+		cli  string
+		want int
+	}{
+		{"comments", "// header //\n$$HEADERSTART $$ASCII $$UNITS/1 $$HEADEREND\n$$LAYER/1 // first\n$$POLYLINE/1,1,0\n$$HATCHES/1,1,0,0,1,1\n", 1},
+		{"lowercase", "$$layer/1\n$$layer/2\n", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCLIDecoder(strings.NewReader(tt.cli)).Decode(new(go3mf.Model))
+			if err != nil {
+				t.Fatalf("CLIDecoder.Decode() error = %v", err)
+			}
+			if len(got.Slices) != tt.want {
+				t.Errorf("CLIDecoder.Decode() = %v", got.Slices)
+			}
+			for _, s := range got.Slices {
+				if len(s.Polygons) != 0 {
+					t.Errorf("CLIDecoder.Decode() = %v", s.Polygons)
+				}
+			}
+		})
+	}
+}
+
+func TestCLIDecoder_Decode_error(t *testing.T) {
+	tests := []struct {
+		name string
+		cli  string
+		want string
+	}{
+		{"binary", "$$HEADERSTART\n$$BINARY\n", ErrCLIBinary.Error()},
+		{"units", "$$UNITS/0\n", "line 1: invalid CLI command: UNITS/0"},
+		{"unitsParams", "$$UNITS/1,2\n", "line 1: invalid CLI command: UNITS/1,2"},
+		{"layer", "\n$$LAYER/a\n", "line 2: invalid CLI command: LAYER/a"},
+		{"noLayer", "$$POLYLINE/1,1,1,0,0\n", "line 1: invalid CLI command: POLYLINE/1,1,1,0,0"},
+		{"polylineParams", "$$LAYER/1\n$$POLYLINE/1,1\n", "line 2: invalid CLI command: POLYLINE/1,1"},
+		{"polylineCount", "$$LAYER/1\n$$POLYLINE/1,1,2,0,0\n", "line 2: invalid CLI command: POLYLINE/1,1,2,0,0"},
+		{"polylineDir", "$$LAYER/1\n$$POLYLINE/1,a,1,0,0\n", "line 2: invalid CLI command: POLYLINE/1,a,1,0,0"},
+		{"polylinePoint", "$$LAYER/1\n$$POLYLINE/1,1,1,0,a\n", "line 2: invalid CLI command: POLYLINE/1,1,1,0,a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(go3mf.Model)
+			_, err := NewCLIDecoder(strings.NewReader(tt.cli)).Decode(m)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("CLIDecoder.Decode() error = %v, want %v", err, tt.want)
+			}
+			if !errors.Is(err, ErrCLICommand) && !errors.Is(err, ErrCLIBinary) {
+				t.Errorf("CLIDecoder.Decode() error = %v", err)
+			}
+			if len(m.Resources.Assets) != 0 {
+				t.Error("CLIDecoder.Decode() modified the model")
+			}
+		})
+	}
+}
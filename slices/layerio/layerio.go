@@ -0,0 +1,129 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+// Package layerio converts slice stacks from and to the vector layer formats
+// used by laser, SLM and DLP toolchains.
+//
+// Coordinates are converted from the model units to millimeters when encoding
+// and from millimeters to the model units when decoding.
+package layerio
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/slices"
+)
+
+// millimeters returns the length of a model unit in millimeters.
+func millimeters(u go3mf.Units) float64 {
+	return map[go3mf.Units]float64{
+		go3mf.UnitMillimeter: 1,
+		go3mf.UnitMicrometer: 0.001,
+		go3mf.UnitCentimeter: 10,
+		go3mf.UnitInch:       25.4,
+		go3mf.UnitFoot:       304.8,
+		go3mf.UnitMeter:      1000,
+	}[u]
+}
+
+// layer is a slice resolved into scaled contours.
+type layer struct {
+	z        float64
+	contours []contour
+}
+
+// contour is a polygon resolved into its points.
+type contour struct {
+	points [][2]float64
+	closed bool
+}
+
+// area returns the signed area of the contour,
+// positive if it is counterclockwise.
+func (c contour) area() float64 {
+	var a float64
+	for i, p := range c.points {
+		q := c.points[(i+1)%len(c.points)]
+		a += p[0]*q[1] - q[0]*p[1]
+	}
+	return a / 2
+}
+
+// layers resolves the slices of st and scales them to millimeters.
+func layers(m *go3mf.Model, st *slices.SliceStack) ([]layer, error) {
+	ss, err := st.Resolve(m)
+	if err != nil {
+		return nil, err
+	}
+	scale := millimeters(m.Units)
+	var errs error
+	layers := make([]layer, len(ss))
+	for i, s := range ss {
+		layers[i].z = float64(s.TopZ) * scale
+		for j, p := range s.Polygons {
+			c, ok := newContour(s, p, scale)
+			if !ok {
+				errs = specerr.Append(errs, specerr.WrapIndex(specerr.WrapIndex(specerr.ErrIndexOutOfBounds, p, j), s, i))
+				continue
+			}
+			layers[i].contours = append(layers[i].contours, c)
+		}
+	}
+	if errs != nil {
+		return nil, specerr.Wrap(errs, st)
+	}
+	return layers, nil
+}
+
+func newContour(s *slices.Slice, p slices.Polygon, scale float64) (contour, bool) {
+	point := func(i uint32) ([2]float64, bool) {
+		if int(i) >= len(s.Vertices) {
+			return [2]float64{}, false
+		}
+		v := s.Vertices[i]
+		return [2]float64{float64(v.X()) * scale, float64(v.Y()) * scale}, true
+	}
+	c := contour{closed: len(p.Segments) > 0 && p.Segments[len(p.Segments)-1].V2 == p.StartV}
+	first, ok := point(p.StartV)
+	if !ok {
+		return c, false
+	}
+	c.points = append(c.points, first)
+	for i, seg := range p.Segments {
+		if c.closed && i == len(p.Segments)-1 {
+			break
+		}
+		v, ok := point(seg.V2)
+		if !ok {
+			return c, false
+		}
+		c.points = append(c.points, v)
+	}
+	return c, true
+}
+
+// addContour adds c, in millimeters, to the slice scaled to the model units.
+func addContour(s *slices.Slice, c contour, scale float64) {
+	start := uint32(len(s.Vertices))
+	for _, p := range c.points {
+		s.Vertices = append(s.Vertices, go3mf.Point2D{float32(p[0] / scale), float32(p[1] / scale)})
+	}
+	p := slices.Polygon{StartV: start}
+	for i := 1; i < len(c.points); i++ {
+		p.Segments = append(p.Segments, slices.Segment{V2: start + uint32(i)})
+	}
+	if c.closed {
+		p.Segments = append(p.Segments, slices.Segment{V2: start})
+	}
+	s.Polygons = append(s.Polygons, p)
+}
+
+func formatFloat(v float64) string {
+	if v == 0 || math.Abs(v) < 1e-9 {
+		return "0"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 32)
+}
@@ -0,0 +1,99 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package layerio
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/slices"
+	"github.com/go-test/deep"
+)
+
+// square returns a slice with a 10x10 square with a 2x2 hole and an open line.
+func square(z float32) *slices.Slice {
+	return &slices.Slice{
+		TopZ: z,
+		Vertices: []go3mf.Point2D{
+			{0, 0}, {10, 0}, {10, 10}, {0, 10},
+			{4, 4}, {4, 6}, {6, 6}, {6, 4},
+			{1, 1}, {2, 1},
+		},
+		Polygons: []slices.Polygon{
+			{StartV: 4, Segments: []slices.Segment{{V2: 5}, {V2: 6}, {V2: 7}, {V2: 4}}},
+			{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}},
+			{StartV: 8, Segments: []slices.Segment{{V2: 9}}},
+		},
+	}
+}
+
+func Test_millimeters(t *testing.T) {
+	tests := []struct {
+		u    go3mf.Units
+		want float64
+	}{
+		{go3mf.UnitMillimeter, 1},
+		{go3mf.UnitMicrometer, 0.001},
+		{go3mf.UnitCentimeter, 10},
+		{go3mf.UnitInch, 25.4},
+		{go3mf.UnitFoot, 304.8},
+		{go3mf.UnitMeter, 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.u.String(), func(t *testing.T) {
+			if got := millimeters(tt.u); got != tt.want {
+				t.Errorf("millimeters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_layers(t *testing.T) {
+	m := &go3mf.Model{Units: go3mf.UnitCentimeter, Childs: map[string]*go3mf.ChildModel{
+		"/a.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&slices.SliceStack{ID: 1, Slices: []*slices.Slice{square(1)}},
+		}}},
+	}}
+	got, err := layers(m, &slices.SliceStack{Refs: []slices.SliceRef{{SliceStackID: 1, Path: "/a.model"}}})
+	if err != nil {
+		t.Fatalf("layers() error = %v", err)
+	}
+	want := []layer{{z: 10, contours: []contour{
+		{closed: true, points: [][2]float64{{40, 40}, {40, 60}, {60, 60}, {60, 40}}},
+		{closed: true, points: [][2]float64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}},
+		{points: [][2]float64{{10, 10}, {20, 10}}},
+	}}}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("layers() = %v", diff)
+	}
+	if a := want[0].contours[0].area(); a != -400 {
+		t.Errorf("contour.area() = %v", a)
+	}
+	if a := want[0].contours[1].area(); a != 10000 {
+		t.Errorf("contour.area() = %v", a)
+	}
+}
+
+func Test_layers_error(t *testing.T) {
+	s := square(1)
+	s.Polygons[1].StartV = 20
+	s.Polygons[2].Segments[0].V2 = 20
+	_, err := layers(new(go3mf.Model), &slices.SliceStack{Slices: []*slices.Slice{s}})
+	var errs []string
+	for _, err := range err.(*specerr.List).Errors {
+		errs = append(errs, err.Error())
+	}
+	want := []string{
+		fmt.Sprintf("SliceStack@Slice#0@Polygon#1: %v", specerr.ErrIndexOutOfBounds),
+		fmt.Sprintf("SliceStack@Slice#0@Polygon#2: %v", specerr.ErrIndexOutOfBounds),
+	}
+	if diff := deep.Equal(errs, want); diff != nil {
+		t.Errorf("layers() = %v", diff)
+	}
+	if _, err := layers(new(go3mf.Model), &slices.SliceStack{Refs: []slices.SliceRef{{SliceStackID: 1}}}); err == nil {
+		t.Error("layers() expected error")
+	}
+}
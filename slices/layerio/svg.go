@@ -0,0 +1,86 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package layerio
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/slices"
+)
+
+// Slic3rNamespace is the namespace of the layer attributes of the SVG files.
+const Slic3rNamespace = "http://slic3r.org/namespaces/slic3r"
+
+// SVGEncoder writes slice stacks as multi-layer SVG files,
+// following the layout exported by Slic3r and understood by most DLP tools.
+//
+// Each slice is a group with its top z, in millimeters, and contains a white polygon
+// for each outer contour, a black polygon for each hole and a polyline for each open contour.
+// The y axis points down, as in any SVG file, so the drawing is flipped
+// and translated so its bounding box starts at the origin.
+type SVGEncoder struct {
+	w io.Writer
+}
+
+// NewSVGEncoder creates a new SVG encoder.
+func NewSVGEncoder(w io.Writer) *SVGEncoder {
+	return &SVGEncoder{w: w}
+}
+
+// Encode writes the slices of st, resolving its references in m.
+func (e *SVGEncoder) Encode(m *go3mf.Model, st *slices.SliceStack) error {
+	layers, err := layers(m, st)
+	if err != nil {
+		return err
+	}
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, l := range layers {
+		for _, c := range l.contours {
+			for _, p := range c.points {
+				minX, minY = math.Min(minX, p[0]), math.Min(minY, p[1])
+				maxX, maxY = math.Max(maxX, p[0]), math.Max(maxY, p[1])
+			}
+		}
+	}
+	if minX > maxX {
+		minX, minY, maxX, maxY = 0, 0, 0, 0
+	}
+	width, height := formatFloat(maxX-minX), formatFloat(maxY-minY)
+	w := bufio.NewWriter(e.w)
+	w.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	w.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" xmlns:slic3r="` + Slic3rNamespace + `"`)
+	w.WriteString(` width="` + width + `mm" height="` + height + `mm" viewBox="0 0 ` + width + " " + height + `">` + "\n")
+	for i, l := range layers {
+		w.WriteString(`  <g id="layer` + strconv.Itoa(i) + `" slic3r:z="` + formatFloat(l.z) + `">` + "\n")
+		// Holes are drawn after the outer contours, which would cover them otherwise.
+		var contours, holes, polylines []string
+		for _, c := range l.contours {
+			points := make([]string, len(c.points))
+			for j, p := range c.points {
+				points[j] = formatFloat(p[0]-minX) + "," + formatFloat(maxY-p[1])
+			}
+			switch {
+			case !c.closed:
+				polylines = append(polylines, `    <polyline points="`+strings.Join(points, " ")+`" style="fill: none; stroke: white"/>`+"\n")
+			case c.area() < 0:
+				holes = append(holes, `    <polygon slic3r:type="hole" points="`+strings.Join(points, " ")+`" style="fill: black"/>`+"\n")
+			default:
+				contours = append(contours, `    <polygon slic3r:type="contour" points="`+strings.Join(points, " ")+`" style="fill: white"/>`+"\n")
+			}
+		}
+		for _, e := range [][]string{contours, holes, polylines} {
+			for _, s := range e {
+				w.WriteString(s)
+			}
+		}
+		w.WriteString("  </g>\n")
+	}
+	w.WriteString("</svg>\n")
+	return w.Flush()
+}
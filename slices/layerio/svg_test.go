@@ -0,0 +1,54 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package layerio
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/slices"
+)
+
+func TestSVGEncoder_Encode(t *testing.T) {
+	tests := []struct {
+		name string
+		st   *slices.SliceStack
+		want string
+	}{
+		{"empty", new(slices.SliceStack), `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:slic3r="http://slic3r.org/namespaces/slic3r" width="0mm" height="0mm" viewBox="0 0 0 0">
+</svg>
+`},
+		{"base", &slices.SliceStack{Slices: []*slices.Slice{square(0.1), {TopZ: 0.2}}}, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:slic3r="http://slic3r.org/namespaces/slic3r" width="10mm" height="10mm" viewBox="0 0 10 10">
+  <g id="layer0" slic3r:z="0.1">
+    <polygon slic3r:type="contour" points="0,10 10,10 10,0 0,0" style="fill: white"/>
+    <polygon slic3r:type="hole" points="4,6 4,4 6,4 6,6" style="fill: black"/>
+    <polyline points="1,9 2,9" style="fill: none; stroke: white"/>
+  </g>
+  <g id="layer1" slic3r:z="0.2">
+  </g>
+</svg>
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := NewSVGEncoder(&b).Encode(new(go3mf.Model), tt.st); err != nil {
+				t.Fatalf("SVGEncoder.Encode() error = %v", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("SVGEncoder.Encode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSVGEncoder_Encode_error(t *testing.T) {
+	st := &slices.SliceStack{Refs: []slices.SliceRef{{SliceStackID: 1}}}
+	if err := NewSVGEncoder(new(bytes.Buffer)).Encode(new(go3mf.Model), st); err == nil {
+		t.Error("SVGEncoder.Encode() expected error")
+	}
+}
@@ -0,0 +1,273 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package polygon
+
+import (
+	"math"
+	"sort"
+)
+
+// FillRule defines which regions of a set of paths are inside.
+type FillRule uint8
+
+// Supported fill rules.
+const (
+	FillEvenOdd FillRule = iota
+	FillNonZero
+	FillPositive
+)
+
+func (f FillRule) String() string {
+	return map[FillRule]string{
+		FillEvenOdd:  "evenodd",
+		FillNonZero:  "nonzero",
+		FillPositive: "positive",
+	}[f]
+}
+
+func (f FillRule) inside(winding int) bool {
+	switch f {
+	case FillNonZero:
+		return winding != 0
+	case FillPositive:
+		return winding > 0
+	}
+	return winding%2 != 0
+}
+
+// Union returns the region inside a or b.
+// The result is a set of simple paths, counterclockwise for the outer
+// contours and clockwise for the holes.
+func Union(a, b []Path, rule FillRule) []Path {
+	return clip(a, b, rule, func(a, b bool) bool { return a || b })
+}
+
+// Difference returns the region inside a and outside b.
+func Difference(a, b []Path, rule FillRule) []Path {
+	return clip(a, b, rule, func(a, b bool) bool { return a && !b })
+}
+
+// Intersection returns the region inside both a and b.
+func Intersection(a, b []Path, rule FillRule) []Path {
+	return clip(a, b, rule, func(a, b bool) bool { return a && b })
+}
+
+// Xor returns the region inside either a or b, but not both.
+func Xor(a, b []Path, rule FillRule) []Path {
+	return clip(a, b, rule, func(a, b bool) bool { return a != b })
+}
+
+// segment is a directed segment.
+type segment struct {
+	a, b point
+}
+
+// clip computes a boolean operation by splitting all the segments at their
+// intersections and keeping the pieces that separate the inside of
+// the result from the outside, oriented with the inside on their left.
+func clip(a, b []Path, rule FillRule, op func(a, b bool) bool) []Path {
+	ra, rb := toPoints(a), toPoints(b)
+	sa, sb := segments(ra), segments(rb)
+	all := append(append([]segment(nil), sa...), sb...)
+	if len(all) == 0 {
+		return nil
+	}
+	tol := tolerance(all)
+	inside := func(p point) bool {
+		return op(rule.inside(winding(sa, p)), rule.inside(winding(sb, p)))
+	}
+	seen := make(map[segment]struct{})
+	var kept []segment
+	for _, s := range split(all, tol) {
+		key := s
+		if less(s.b, s.a) {
+			key = segment{s.b, s.a}
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		d := s.b.sub(s.a)
+		n := point{-d[1], d[0]}.scale(tol * 100 / d.length())
+		mid := s.a.add(d.scale(0.5))
+		left, right := inside(mid.add(n)), inside(mid.sub(n))
+		if left == right {
+			continue
+		}
+		if !left {
+			s = segment{s.b, s.a}
+		}
+		kept = append(kept, s)
+	}
+	return toPaths(link(kept))
+}
+
+func segments(rings [][]point) []segment {
+	var segs []segment
+	for _, r := range rings {
+		if len(r) < 2 {
+			continue
+		}
+		for i, p := range r {
+			q := r[(i+1)%len(r)]
+			if p != q {
+				segs = append(segs, segment{p, q})
+			}
+		}
+	}
+	return segs
+}
+
+// tolerance returns the geometric tolerance for the extent of the segments.
+func tolerance(segs []segment) float64 {
+	var extent float64
+	for _, s := range segs {
+		extent = math.Max(extent, math.Max(math.Abs(s.a[0]), math.Abs(s.a[1])))
+	}
+	return 1e-9 * math.Max(1, extent)
+}
+
+// winding returns the winding number of segs around p.
+func winding(segs []segment, p point) int {
+	var w int
+	for _, s := range segs {
+		side := s.b.sub(s.a).cross(p.sub(s.a))
+		if s.a[1] <= p[1] {
+			if s.b[1] > p[1] && side > 0 {
+				w++
+			}
+		} else if s.b[1] <= p[1] && side < 0 {
+			w--
+		}
+	}
+	return w
+}
+
+// split splits the segments at their mutual intersections.
+// Intersection points are computed once so the pieces share their ends exactly.
+func split(segs []segment, tol float64) []segment {
+	type cut struct {
+		t float64
+		p point
+	}
+	cuts := make([][]cut, len(segs))
+	// onSegment cuts segment i at p if it lies on its interior.
+	onSegment := func(i int, p point) {
+		s := segs[i]
+		d := s.b.sub(s.a)
+		l := d.length()
+		if math.Abs(d.cross(p.sub(s.a)))/l > tol {
+			return
+		}
+		if t := d.dot(p.sub(s.a)) / (l * l); t*l > tol && (1-t)*l > tol {
+			cuts[i] = append(cuts[i], cut{t, p})
+		}
+	}
+	for i := range segs {
+		for j := i + 1; j < len(segs); j++ {
+			s, o := segs[i], segs[j]
+			if math.Max(s.a[0], s.b[0])+tol < math.Min(o.a[0], o.b[0]) ||
+				math.Max(o.a[0], o.b[0])+tol < math.Min(s.a[0], s.b[0]) ||
+				math.Max(s.a[1], s.b[1])+tol < math.Min(o.a[1], o.b[1]) ||
+				math.Max(o.a[1], o.b[1])+tol < math.Min(s.a[1], s.b[1]) {
+				continue
+			}
+			onSegment(i, o.a)
+			onSegment(i, o.b)
+			onSegment(j, s.a)
+			onSegment(j, s.b)
+			r, q := s.b.sub(s.a), o.b.sub(o.a)
+			denom := r.cross(q)
+			if math.Abs(denom) <= tol*r.length()*q.length() {
+				continue
+			}
+			w := o.a.sub(s.a)
+			t, u := w.cross(q)/denom, w.cross(r)/denom
+			lr, lq := r.length(), q.length()
+			if t*lr > tol && (1-t)*lr > tol && u*lq > tol && (1-u)*lq > tol {
+				p := s.a.add(r.scale(t))
+				cuts[i] = append(cuts[i], cut{t, p})
+				cuts[j] = append(cuts[j], cut{u, p})
+			}
+		}
+	}
+	var pieces []segment
+	for i, s := range segs {
+		c := cuts[i]
+		sort.Slice(c, func(i, j int) bool { return c[i].t < c[j].t })
+		prev := s.a
+		for _, c := range c {
+			if c.p != prev {
+				pieces = append(pieces, segment{prev, c.p})
+				prev = c.p
+			}
+		}
+		if s.b != prev {
+			pieces = append(pieces, segment{prev, s.b})
+		}
+	}
+	return pieces
+}
+
+// link joins the segments into closed rings. When several segments leave
+// the same point, the one turning the most to the left is taken,
+// which separates the rings that touch at a vertex.
+func link(segs []segment) [][]point {
+	out := make(map[point][]int)
+	for i, s := range segs {
+		out[s.a] = append(out[s.a], i)
+	}
+	used := make([]bool, len(segs))
+	var rings [][]point
+	for i := range segs {
+		if used[i] {
+			continue
+		}
+		var ring []point
+		for cur := i; cur >= 0 && !used[cur]; {
+			used[cur] = true
+			s := segs[cur]
+			ring = append(ring, s.a)
+			dir := s.b.sub(s.a)
+			next, best := -1, math.Inf(-1)
+			for _, j := range out[s.b] {
+				if used[j] {
+					continue
+				}
+				turn := math.Atan2(dir.cross(segs[j].b.sub(segs[j].a)), dir.dot(segs[j].b.sub(segs[j].a)))
+				if turn > best {
+					next, best = j, turn
+				}
+			}
+			cur = next
+		}
+		if ring = removeCollinear(ring); len(ring) >= 3 {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// removeCollinear removes the points lying on the segment between their neighbours.
+func removeCollinear(ring []point) []point {
+	for changed := true; changed && len(ring) >= 3; {
+		changed = false
+		for i := 0; i < len(ring) && len(ring) >= 3; i++ {
+			prev, next := ring[(i+len(ring)-1)%len(ring)], ring[(i+1)%len(ring)]
+			d1, d2 := ring[i].sub(prev), next.sub(ring[i])
+			if d1.cross(d2) == 0 && d1.dot(d2) > 0 {
+				ring = append(ring[:i], ring[i+1:]...)
+				changed = true
+			}
+		}
+	}
+	return ring
+}
+
+func less(a, b point) bool {
+	if a[0] != b[0] {
+		return a[0] < b[0]
+	}
+	return a[1] < b[1]
+}
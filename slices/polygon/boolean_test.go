@@ -0,0 +1,106 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package polygon
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+// normalize rotates each path to start at its lowest vertex and sorts the paths,
+// so results can be compared regardless of where the rings start.
+func normalize(paths []Path) []Path {
+	out := make([]Path, len(paths))
+	for i, p := range paths {
+		first := 0
+		for j, v := range p {
+			if less(newPoint(v), newPoint(p[first])) {
+				first = j
+			}
+		}
+		out[i] = append(append(Path(nil), p[first:]...), p[:first]...)
+	}
+	sort.Slice(out, func(i, j int) bool { return less(newPoint(out[i][0]), newPoint(out[j][0])) })
+	return out
+}
+
+func TestFillRule_String(t *testing.T) {
+	for rule, want := range map[FillRule]string{FillEvenOdd: "evenodd", FillNonZero: "nonzero", FillPositive: "positive"} {
+		if got := rule.String(); got != want {
+			t.Errorf("FillRule.String() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestBoolean(t *testing.T) {
+	a, b := []Path{square(0, 0, 2)}, []Path{square(1, 1, 2)}
+	tests := []struct {
+		name string
+		op   func(a, b []Path, rule FillRule) []Path
+		a, b []Path
+		want []Path
+	}{
+		{"unionEmpty", Union, nil, nil, nil},
+		{"union", Union, a, b, []Path{{{0, 0}, {2, 0}, {2, 1}, {3, 1}, {3, 3}, {1, 3}, {1, 2}, {0, 2}}}},
+		{"unionDisjoint", Union, a, []Path{square(5, 0, 1)}, []Path{square(0, 0, 2), square(5, 0, 1)}},
+		{"unionContained", Union, []Path{square(0, 0, 4)}, []Path{square(1, 1, 1)}, []Path{square(0, 0, 4)}},
+		{"unionTouching", Union, a, []Path{square(2, 0, 2)}, []Path{{{0, 0}, {4, 0}, {4, 2}, {0, 2}}}},
+		{"unionCorner", Union, a, []Path{square(2, 2, 2)}, []Path{square(0, 0, 2), square(2, 2, 2)}},
+		{"difference", Difference, a, b, []Path{{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}}}},
+		{"differenceHole", Difference, []Path{square(0, 0, 4)}, []Path{square(1, 1, 2)}, []Path{square(0, 0, 4), square(1, 1, 2).Reverse()}},
+		{"intersection", Intersection, a, b, []Path{square(1, 1, 1)}},
+		{"intersectionDisjoint", Intersection, a, []Path{square(5, 0, 1)}, nil},
+		{"xor", Xor, a, b, []Path{
+			{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}},
+			{{1, 2}, {2, 2}, {2, 1}, {3, 1}, {3, 3}, {1, 3}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalize(tt.op(tt.a, tt.b, FillNonZero))
+			if diff := deep.Equal(got, normalize(tt.want)); diff != nil {
+				t.Errorf("clip() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestUnion_fillRule(t *testing.T) {
+	// Two nested counterclockwise squares.
+	paths := []Path{square(0, 0, 4), square(1, 1, 2)}
+	tests := []struct {
+		rule FillRule
+		area float64
+	}{
+		{FillEvenOdd, 12},
+		{FillNonZero, 16},
+		{FillPositive, 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule.String(), func(t *testing.T) {
+			if got := Area(Union(paths, nil, tt.rule)); got != tt.area {
+				t.Errorf("Union() area = %v, want %v", got, tt.area)
+			}
+		})
+	}
+	if got := Union([]Path{square(0, 0, 1).Reverse()}, nil, FillPositive); len(got) != 0 {
+		t.Errorf("Union() = %v, want empty", got)
+	}
+}
+
+func TestUnion_selfIntersecting(t *testing.T) {
+	bowtie := []Path{{{0, 0}, {2, 2}, {2, 0}, {0, 2}}}
+	got := Union(bowtie, nil, FillNonZero)
+	if len(got) != 2 {
+		t.Fatalf("Union() = %v, want two triangles", got)
+	}
+	for _, p := range got {
+		if !p.IsCCW() || math.Abs(p.Area()-1) > 1e-6 {
+			t.Errorf("Union() = %v, want counterclockwise triangles of area 1", p)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package polygon
+
+import "math"
+
+// DefaultMiterLimit is the miter limit used when OffsetOptions.MiterLimit is not defined.
+const DefaultMiterLimit = 2
+
+// JoinType defines how the offset segments are joined at convex corners.
+type JoinType uint8
+
+// Supported join types.
+const (
+	JoinMiter JoinType = iota
+	JoinRound
+	JoinSquare
+)
+
+func (j JoinType) String() string {
+	return map[JoinType]string{
+		JoinMiter:  "miter",
+		JoinRound:  "round",
+		JoinSquare: "square",
+	}[j]
+}
+
+// OffsetOptions defines how the paths are offset.
+type OffsetOptions struct {
+	Join JoinType
+	// MiterLimit is the maximum distance, in multiples of the offset,
+	// between a vertex and its mitered corner. Sharper corners are squared.
+	MiterLimit float64
+	// ArcTolerance is the maximum distance between a round join and its true arc.
+	// Defaults to a hundredth of the offset.
+	ArcTolerance float64
+}
+
+// Offset grows the region defined by paths by delta, or shrinks it if delta is negative.
+// The paths must be oriented with the filled region on their left.
+//
+// Insets of a contour are typically used for shells and the
+// region left inside the innermost shell for infill.
+func Offset(paths []Path, delta float64, opts OffsetOptions) []Path {
+	if delta == 0 {
+		return Union(paths, nil, FillPositive)
+	}
+	rings := toPoints(paths)
+	limit := opts.MiterLimit
+	if limit <= 1 {
+		limit = DefaultMiterLimit
+	}
+	arcTol := opts.ArcTolerance
+	if arcTol <= 0 {
+		arcTol = math.Abs(delta) / 100
+	}
+	step := 2 * math.Acos(math.Max(-1, 1-arcTol/math.Abs(delta)))
+	raw := make([]Path, 0, len(rings))
+	for _, r := range rings {
+		if len(r) < 3 {
+			continue
+		}
+		normals := make([]point, len(r))
+		for i, p := range r {
+			d := r[(i+1)%len(r)].sub(p)
+			normals[i] = point{d[1], -d[0]}.scale(1 / d.length())
+		}
+		var out []point
+		for i, p := range r {
+			n1, n2 := normals[(i+len(r)-1)%len(r)], normals[i]
+			out = append(out, join(p, n1, n2, delta, opts.Join, limit, step)...)
+		}
+		raw = append(raw, toPaths([][]point{out})...)
+	}
+	return Union(raw, nil, FillPositive)
+}
+
+// join returns the offset points of vertex p, whose adjacent segments
+// have the outward normals n1 and n2.
+func join(p, n1, n2 point, delta float64, jt JoinType, limit, step float64) []point {
+	a, b := p.add(n1.scale(delta)), p.add(n2.scale(delta))
+	cross, dot := n1.cross(n2), n1.dot(n2)
+	if dot > 1-1e-12 {
+		return []point{b}
+	}
+	// Concave corners produce overlapping segments that are
+	// removed when the result is cleaned up with FillPositive.
+	if cross*delta < 0 {
+		return []point{a, p, b}
+	}
+	switch jt {
+	case JoinRound:
+		angle := math.Atan2(cross, dot)
+		n := int(math.Ceil(math.Abs(angle) / step))
+		pts := make([]point, 0, n+1)
+		for i := 0; i <= n; i++ {
+			s, c := math.Sincos(angle * float64(i) / float64(n))
+			pts = append(pts, p.add(point{n1[0]*c - n1[1]*s, n1[0]*s + n1[1]*c}.scale(delta)))
+		}
+		return pts
+	case JoinMiter:
+		// The miter corner is at delta/cos(θ/2) from p.
+		if 2/(1+dot) <= limit*limit {
+			return []point{p.add(n1.add(n2).scale(delta / (1 + dot)))}
+		}
+	}
+	// Square the corner at delta from p.
+	mid := n1.add(n2)
+	if l := mid.length(); l > 1e-12 {
+		mid = mid.scale(1 / l)
+	} else {
+		// The path turns back, the corner is squared along the incoming segment.
+		mid = point{-n1[1], n1[0]}
+	}
+	tangent := point{-mid[1], mid[0]}
+	sq := p.add(mid.scale(delta))
+	t := math.Abs(delta) * math.Tan(math.Acos(math.Max(-1, math.Min(1, dot)))/4)
+	return []point{sq.sub(tangent.scale(t)), sq.add(tangent.scale(t))}
+}
@@ -0,0 +1,78 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package polygon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestJoinType_String(t *testing.T) {
+	for join, want := range map[JoinType]string{JoinMiter: "miter", JoinRound: "round", JoinSquare: "square"} {
+		if got := join.String(); got != want {
+			t.Errorf("JoinType.String() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestOffset(t *testing.T) {
+	lshape := []Path{{{0, 0}, {4, 0}, {4, 2}, {2, 2}, {2, 4}, {0, 4}}}
+	tests := []struct {
+		name  string
+		paths []Path
+		delta float64
+		opts  OffsetOptions
+		area  float64
+		n     int
+	}{
+		{"zero", []Path{square(0, 0, 2)}, 0, OffsetOptions{}, 4, 1},
+		{"miter", []Path{square(0, 0, 2)}, 1, OffsetOptions{}, 16, 1},
+		{"square", []Path{square(0, 0, 2)}, 1, OffsetOptions{Join: JoinSquare}, 16 - 4*(math.Sqrt2-1)*(math.Sqrt2-1), 1},
+		{"round", []Path{square(0, 0, 2)}, 1, OffsetOptions{Join: JoinRound}, 4 + 8 + math.Pi, 1},
+		{"inset", []Path{square(0, 0, 4)}, -1, OffsetOptions{}, 4, 1},
+		{"insetRound", []Path{square(0, 0, 4)}, -1, OffsetOptions{Join: JoinRound}, 4, 1},
+		{"vanish", []Path{square(0, 0, 2)}, -1.5, OffsetOptions{}, 0, 0},
+		{"hole", []Path{square(0, 0, 6), square(2, 2, 2).Reverse()}, -0.5, OffsetOptions{}, 25 - 9, 2},
+		{"closeHole", []Path{square(0, 0, 6), square(2, 2, 2).Reverse()}, 1.5, OffsetOptions{}, 81, 1},
+		{"concave", lshape, -0.5, OffsetOptions{}, 3 + 3 - 1, 1},
+		{"concaveGrow", lshape, 1, OffsetOptions{}, 6*4 + 4*2, 1},
+		{"merge", []Path{square(0, 0, 2), square(3, 0, 2)}, 0.5, OffsetOptions{}, 6 * 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Offset(tt.paths, tt.delta, tt.opts)
+			if len(got) != tt.n {
+				t.Errorf("Offset() = %v, want %d paths", got, tt.n)
+			}
+			// Round joins are approximated by chords.
+			if area := Area(got); math.Abs(area-tt.area) > 0.01*math.Max(1, tt.area) {
+				t.Errorf("Offset() area = %v, want %v", area, tt.area)
+			}
+		})
+	}
+}
+
+func TestOffset_miterLimit(t *testing.T) {
+	// The sharp corner at the origin exceeds the miter limit and is squared.
+	spike := []Path{{{0, 0}, {10, -1}, {10, 1}}}
+	got := Offset(spike, 0.1, OffsetOptions{MiterLimit: 2})
+	if len(got) != 1 {
+		t.Fatalf("Offset() = %v, want one path", got)
+	}
+	for _, v := range got[0] {
+		if v.X() < -0.2 {
+			t.Errorf("Offset() vertex %v exceeds the miter limit", v)
+		}
+	}
+}
+
+func TestOffset_orientation(t *testing.T) {
+	want := normalize([]Path{square(-1, -1, 4)})
+	got := normalize(Offset([]Path{square(0, 0, 2)}, 1, OffsetOptions{}))
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Offset() = %v", diff)
+	}
+}
@@ -0,0 +1,185 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+// Package polygon implements 2D geometry operations on the polygons of the slices,
+// such as offsetting, boolean operations and simplification.
+//
+// The operations work on closed paths: outer contours are counterclockwise
+// and holes clockwise, keeping the filled region on the left of each segment.
+// The segment properties are not preserved.
+package polygon
+
+import (
+	"math"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/slices"
+)
+
+// Path is a closed polygon, the last point being implicitly connected to the first.
+type Path []go3mf.Point2D
+
+// Area returns the signed area of p, positive if it is counterclockwise.
+func (p Path) Area() float64 {
+	var a float64
+	for i, v := range p {
+		w := p[(i+1)%len(p)]
+		a += float64(v.X())*float64(w.Y()) - float64(w.X())*float64(v.Y())
+	}
+	return a / 2
+}
+
+// IsCCW returns true if p is oriented counterclockwise.
+func (p Path) IsCCW() bool {
+	return p.Area() > 0
+}
+
+// Reverse returns a copy of p with the opposite orientation.
+func (p Path) Reverse() Path {
+	r := make(Path, len(p))
+	for i, v := range p {
+		r[len(p)-1-i] = v
+	}
+	return r
+}
+
+// Area returns the signed area of the region defined by paths.
+func Area(paths []Path) float64 {
+	var a float64
+	for _, p := range paths {
+		a += p.Area()
+	}
+	return a
+}
+
+// FromSlice returns the paths of the polygons of s.
+// Open polygons are closed implicitly.
+func FromSlice(s *slices.Slice) ([]Path, error) {
+	var (
+		paths []Path
+		errs  error
+	)
+	for i, p := range s.Polygons {
+		path := make(Path, 0, len(p.Segments)+1)
+		ok := int(p.StartV) < len(s.Vertices)
+		if ok {
+			path = append(path, s.Vertices[p.StartV])
+		}
+		for _, seg := range p.Segments {
+			if int(seg.V2) >= len(s.Vertices) {
+				ok = false
+				break
+			}
+			path = append(path, s.Vertices[seg.V2])
+		}
+		if !ok {
+			errs = specerr.Append(errs, specerr.WrapIndex(specerr.ErrIndexOutOfBounds, p, i))
+			continue
+		}
+		if len(path) > 1 && path[0] == path[len(path)-1] {
+			path = path[:len(path)-1]
+		}
+		paths = append(paths, path)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return paths, nil
+}
+
+// ToSlice returns a slice at topZ with a closed polygon for each path.
+func ToSlice(paths []Path, topZ float32) *slices.Slice {
+	s := &slices.Slice{TopZ: topZ}
+	for _, p := range paths {
+		if len(p) == 0 {
+			continue
+		}
+		start := uint32(len(s.Vertices))
+		s.Vertices = append(s.Vertices, p...)
+		poly := slices.Polygon{StartV: start, Segments: make([]slices.Segment, len(p))}
+		for i := 1; i < len(p); i++ {
+			poly.Segments[i-1].V2 = start + uint32(i)
+		}
+		poly.Segments[len(p)-1].V2 = start
+		s.Polygons = append(s.Polygons, poly)
+	}
+	return s
+}
+
+// point is a 2D point in double precision.
+type point [2]float64
+
+func newPoint(p go3mf.Point2D) point {
+	return point{float64(p.X()), float64(p.Y())}
+}
+
+func (p point) point2D() go3mf.Point2D {
+	return go3mf.Point2D{float32(p[0]), float32(p[1])}
+}
+
+func (p point) add(q point) point {
+	return point{p[0] + q[0], p[1] + q[1]}
+}
+
+func (p point) sub(q point) point {
+	return point{p[0] - q[0], p[1] - q[1]}
+}
+
+func (p point) scale(f float64) point {
+	return point{p[0] * f, p[1] * f}
+}
+
+func (p point) dot(q point) float64 {
+	return p[0]*q[0] + p[1]*q[1]
+}
+
+func (p point) cross(q point) float64 {
+	return p[0]*q[1] - p[1]*q[0]
+}
+
+func (p point) length() float64 {
+	return math.Hypot(p[0], p[1])
+}
+
+// toPoints converts the paths into double precision,
+// removing the consecutive duplicated points.
+func toPoints(paths []Path) [][]point {
+	rings := make([][]point, 0, len(paths))
+	for _, p := range paths {
+		ring := make([]point, 0, len(p))
+		for _, v := range p {
+			q := newPoint(v)
+			if len(ring) == 0 || ring[len(ring)-1] != q {
+				ring = append(ring, q)
+			}
+		}
+		for len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+			ring = ring[:len(ring)-1]
+		}
+		if len(ring) > 0 {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+func toPaths(rings [][]point) []Path {
+	paths := make([]Path, 0, len(rings))
+	for _, r := range rings {
+		p := make(Path, 0, len(r))
+		for _, v := range r {
+			q := v.point2D()
+			if len(p) == 0 || p[len(p)-1] != q {
+				p = append(p, q)
+			}
+		}
+		for len(p) > 1 && p[0] == p[len(p)-1] {
+			p = p[:len(p)-1]
+		}
+		if len(p) >= 3 {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,108 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package polygon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/slices"
+	"github.com/go-test/deep"
+)
+
+func square(x, y, size float32) Path {
+	return Path{{x, y}, {x + size, y}, {x + size, y + size}, {x, y + size}}
+}
+
+func TestPath_Area(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Path
+		want float64
+		ccw  bool
+	}{
+		{"empty", nil, 0, false},
+		{"ccw", square(0, 0, 2), 4, true},
+		{"cw", square(0, 0, 2).Reverse(), -4, false},
+		{"triangle", Path{{0, 0}, {4, 0}, {0, 3}}, 6, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Area(); got != tt.want {
+				t.Errorf("Path.Area() = %v, want %v", got, tt.want)
+			}
+			if got := tt.p.IsCCW(); got != tt.ccw {
+				t.Errorf("Path.IsCCW() = %v, want %v", got, tt.ccw)
+			}
+		})
+	}
+}
+
+func TestArea(t *testing.T) {
+	if got := Area([]Path{square(0, 0, 4), square(1, 1, 2).Reverse()}); got != 12 {
+		t.Errorf("Area() = %v, want 12", got)
+	}
+}
+
+func TestFromSlice(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       *slices.Slice
+		want    []Path
+		wantErr bool
+	}{
+		{"empty", new(slices.Slice), nil, false},
+		{"closed", &slices.Slice{
+			Vertices: []go3mf.Point2D{{0, 0}, {1, 0}, {1, 1}},
+			Polygons: []slices.Polygon{{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}, {V2: 0}}}},
+		}, []Path{{{0, 0}, {1, 0}, {1, 1}}}, false},
+		{"open", &slices.Slice{
+			Vertices: []go3mf.Point2D{{0, 0}, {1, 0}, {1, 1}},
+			Polygons: []slices.Polygon{{StartV: 2, Segments: []slices.Segment{{V2: 0}, {V2: 1}}}},
+		}, []Path{{{1, 1}, {0, 0}, {1, 0}}}, false},
+		{"outOfBounds", &slices.Slice{
+			Vertices: []go3mf.Point2D{{0, 0}, {1, 0}},
+			Polygons: []slices.Polygon{{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}}}},
+		}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromSlice(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromSlice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, specerr.ErrIndexOutOfBounds) {
+				t.Errorf("FromSlice() error = %v, want %v", err, specerr.ErrIndexOutOfBounds)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("FromSlice() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestToSlice(t *testing.T) {
+	paths := []Path{{{0, 0}, {1, 0}, {1, 1}}, nil, {{2, 2}, {3, 2}, {3, 3}}}
+	want := &slices.Slice{
+		TopZ:     1.5,
+		Vertices: []go3mf.Point2D{{0, 0}, {1, 0}, {1, 1}, {2, 2}, {3, 2}, {3, 3}},
+		Polygons: []slices.Polygon{
+			{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}, {V2: 0}}},
+			{StartV: 3, Segments: []slices.Segment{{V2: 4}, {V2: 5}, {V2: 3}}},
+		},
+	}
+	got := ToSlice(paths, 1.5)
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("ToSlice() = %v", diff)
+	}
+	back, err := FromSlice(got)
+	if err != nil {
+		t.Fatalf("FromSlice() error = %v", err)
+	}
+	if diff := deep.Equal(back, []Path{paths[0], paths[2]}); diff != nil {
+		t.Errorf("FromSlice() = %v", diff)
+	}
+}
@@ -0,0 +1,80 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package polygon
+
+import "math"
+
+// Simplify returns a copy of p without the vertices that deviate less than tolerance
+// from the simplified path, using the Douglas-Peucker algorithm.
+// It returns nil if less than three vertices remain.
+func (p Path) Simplify(tolerance float64) Path {
+	rings := toPoints([]Path{p})
+	if len(rings) == 0 || len(rings[0]) < 3 {
+		return nil
+	}
+	r := rings[0]
+	// The closed path is split into two chains at the
+	// vertex farthest from the first one.
+	far, best := 0, -1.0
+	for i, v := range r {
+		if d := v.sub(r[0]).length(); d > best {
+			far, best = i, d
+		}
+	}
+	keep := make([]bool, len(r)+1)
+	keep[0], keep[far], keep[len(r)] = true, true, true
+	chain := append(r[:len(r):len(r)], r[0])
+	douglasPeucker(chain, 0, far, tolerance, keep)
+	douglasPeucker(chain, far, len(r), tolerance, keep)
+	out := make(Path, 0, len(r))
+	for i, v := range r {
+		if keep[i] {
+			out = append(out, v.point2D())
+		}
+	}
+	if len(out) < 3 {
+		return nil
+	}
+	return out
+}
+
+// Simplify simplifies each path, dropping the ones that degenerate.
+func Simplify(paths []Path, tolerance float64) []Path {
+	out := make([]Path, 0, len(paths))
+	for _, p := range paths {
+		if s := p.Simplify(tolerance); s != nil {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// douglasPeucker marks in keep the vertices of chain between i and j that are needed
+// to stay within tolerance of the original chain.
+func douglasPeucker(chain []point, i, j int, tolerance float64, keep []bool) {
+	if j-i < 2 {
+		return
+	}
+	a, b := chain[i], chain[j]
+	d := b.sub(a)
+	l := d.length()
+	far, best := -1, tolerance
+	for k := i + 1; k < j; k++ {
+		var dist float64
+		if l == 0 {
+			dist = chain[k].sub(a).length()
+		} else {
+			dist = math.Abs(d.cross(chain[k].sub(a))) / l
+		}
+		if dist > best {
+			far, best = k, dist
+		}
+	}
+	if far < 0 {
+		return
+	}
+	keep[far] = true
+	douglasPeucker(chain, i, far, tolerance, keep)
+	douglasPeucker(chain, far, j, tolerance, keep)
+}
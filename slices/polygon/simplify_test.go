@@ -0,0 +1,42 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package polygon
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestPath_Simplify(t *testing.T) {
+	tests := []struct {
+		name      string
+		p         Path
+		tolerance float64
+		want      Path
+	}{
+		{"empty", nil, 1, nil},
+		{"line", Path{{0, 0}, {1, 0}}, 0.1, nil},
+		{"unchanged", square(0, 0, 2), 0.1, square(0, 0, 2)},
+		{"collinear", Path{{0, 0}, {1, 0}, {2, 0}, {2, 2}, {1, 2}, {0, 2}}, 0, square(0, 0, 2)},
+		{"noise", Path{{0, 0}, {1, 0.05}, {2, 0}, {2, 1}, {2.05, 1.5}, {2, 2}, {0, 2}}, 0.1, square(0, 0, 2)},
+		{"kept", Path{{0, 0}, {1, 0.5}, {2, 0}, {2, 2}, {0, 2}}, 0.1, Path{{0, 0}, {1, 0.5}, {2, 0}, {2, 2}, {0, 2}}},
+		{"collapse", Path{{0, 0}, {10, 0}, {10, 0.1}}, 1, nil},
+		{"duplicated", Path{{0, 0}, {0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}, 0.1, square(0, 0, 2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(tt.p.Simplify(tt.tolerance), tt.want); diff != nil {
+				t.Errorf("Path.Simplify() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	paths := []Path{square(0, 0, 2), {{0, 0}, {10, 0}, {10, 0.1}}}
+	if diff := deep.Equal(Simplify(paths, 1), []Path{square(0, 0, 2)}); diff != nil {
+		t.Errorf("Simplify() = %v", diff)
+	}
+}
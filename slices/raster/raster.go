@@ -0,0 +1,347 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+// Package raster renders slice stacks into layer bitmaps.
+package raster
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/slices"
+)
+
+// DefaultSamples is the number of samples per pixel side used when Options.Samples is not defined.
+const DefaultSamples = 4
+
+var ErrPixelSize = errors.New("pixel size MUST be positive")
+
+// FillRule defines which regions of the polygons are filled.
+type FillRule uint8
+
+// Supported fill rules.
+const (
+	FillEvenOdd FillRule = iota
+	FillNonZero
+)
+
+func (f FillRule) String() string {
+	return map[FillRule]string{
+		FillEvenOdd: "evenodd",
+		FillNonZero: "nonzero",
+	}[f]
+}
+
+// Options defines how the slices are rendered.
+type Options struct {
+	// PixelSize is the pixel pitch in model units.
+	PixelSize float32
+	// Origin is the position, in model units, of the bottom left corner of the images,
+	// and Width and Height their size in pixels.
+	// If Width or Height are zero the bounding box of all the slices is used.
+	Origin        go3mf.Point2D
+	Width, Height int
+	FillRule      FillRule
+	// Samples is the number of samples per pixel side used for anti-aliasing.
+	// One disables anti-aliasing.
+	Samples int
+	// Model is the color model of the images, either color.GrayModel,
+	// which is the default, or color.RGBAModel.
+	Model color.Model
+	// Color returns the color of a segment property.
+	// Each filled region takes the color of the segment on its left side,
+	// interpolated from the p1 to the p2 property along the segment.
+	// If nil, the regions are white. The background is always black.
+	Color func(pid, index uint32) color.Color
+}
+
+func (o Options) gray() bool {
+	return o.Model == nil || o.Model == color.GrayModel || o.Model == color.Gray16Model
+}
+
+// Rasterize renders the slices of st, resolving its references in m,
+// and calls fn with each image in order, stopping at the first error.
+// All the images have the same size and are aligned with each other.
+func Rasterize(m *go3mf.Model, st *slices.SliceStack, opts Options, fn func(i int, s *slices.Slice, img image.Image) error) error {
+	ss, err := st.Resolve(m)
+	if err != nil {
+		return err
+	}
+	if opts.PixelSize <= 0 {
+		return ErrPixelSize
+	}
+	if opts.Width <= 0 || opts.Height <= 0 {
+		opts.Origin, opts.Width, opts.Height = fit(ss, opts.PixelSize)
+	}
+	for i, s := range ss {
+		img, err := RasterizeSlice(s, opts)
+		if err != nil {
+			return specerr.Wrap(specerr.WrapIndex(err, s, i), st)
+		}
+		if err := fn(i, s, img); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RasterizeSlice renders s into a new image.
+// If opts.Width or opts.Height are zero the bounding box of s is used.
+func RasterizeSlice(s *slices.Slice, opts Options) (image.Image, error) {
+	if opts.PixelSize <= 0 {
+		return nil, ErrPixelSize
+	}
+	if opts.Width <= 0 || opts.Height <= 0 {
+		opts.Origin, opts.Width, opts.Height = fit([]*slices.Slice{s}, opts.PixelSize)
+	}
+	edges, err := newEdges(s, opts)
+	if err != nil {
+		return nil, err
+	}
+	r := rasterizer{opts: opts, edges: edges, samples: opts.Samples}
+	if r.samples <= 0 {
+		r.samples = DefaultSamples
+	}
+	return r.render(), nil
+}
+
+// fit returns the origin and size of the images that contain all the slices.
+func fit(ss []*slices.Slice, pixelSize float32) (go3mf.Point2D, int, int) {
+	minX, minY := float32(math.MaxFloat32), float32(math.MaxFloat32)
+	maxX, maxY := -minX, -minY
+	for _, s := range ss {
+		for _, v := range s.Vertices {
+			minX, minY = min32(minX, v.X()), min32(minY, v.Y())
+			maxX, maxY = max32(maxX, v.X()), max32(maxY, v.Y())
+		}
+	}
+	if minX > maxX {
+		return go3mf.Point2D{}, 1, 1
+	}
+	w := int(math.Ceil(float64((maxX - minX) / pixelSize)))
+	h := int(math.Ceil(float64((maxY - minY) / pixelSize)))
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return go3mf.Point2D{minX, minY}, w, h
+}
+
+// edge is a polygon segment in pixel coordinates, with y pointing down.
+type edge struct {
+	x1, y1, x2, y2 float64
+	pid, p1, p2    uint32
+}
+
+func newEdges(s *slices.Slice, opts Options) ([]edge, error) {
+	pixel := func(i uint32) (float64, float64, bool) {
+		if int(i) >= len(s.Vertices) {
+			return 0, 0, false
+		}
+		v := s.Vertices[i]
+		x := float64(v.X()-opts.Origin.X()) / float64(opts.PixelSize)
+		y := float64(opts.Height) - float64(v.Y()-opts.Origin.Y())/float64(opts.PixelSize)
+		return x, y, true
+	}
+	var (
+		edges []edge
+		errs  error
+	)
+	for i, p := range s.Polygons {
+		x, y, ok := pixel(p.StartV)
+		for _, seg := range p.Segments {
+			x2, y2, ok2 := pixel(seg.V2)
+			ok = ok && ok2
+			edges = append(edges, edge{x1: x, y1: y, x2: x2, y2: y2, pid: seg.PID, p1: seg.P1, p2: seg.P2})
+			x, y = x2, y2
+		}
+		if !ok {
+			errs = specerr.Append(errs, specerr.WrapIndex(specerr.ErrIndexOutOfBounds, p, i))
+			continue
+		}
+		// Open polygons are closed implicitly.
+		if n := len(p.Segments); n > 0 && p.Segments[n-1].V2 != p.StartV {
+			last := edges[len(edges)-1]
+			first := edges[len(edges)-n]
+			edges = append(edges, edge{x1: last.x2, y1: last.y2, x2: first.x1, y2: first.y1, pid: last.pid, p1: last.p2, p2: last.p2})
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return edges, nil
+}
+
+// crossing is the intersection of a scanline with an edge.
+type crossing struct {
+	x   float64
+	dir int
+	c   [3]uint32
+}
+
+type rasterizer struct {
+	opts    Options
+	edges   []edge
+	samples int
+	colors  map[[2]uint32][3]uint32
+}
+
+func (r *rasterizer) render() image.Image {
+	w, h, n := r.opts.Width, r.opts.Height, r.samples
+	gray := r.opts.gray()
+	var (
+		grayImg *image.Gray
+		rgbaImg *image.RGBA
+	)
+	if gray {
+		grayImg = image.NewGray(image.Rect(0, 0, w, h))
+	} else {
+		rgbaImg = image.NewRGBA(image.Rect(0, 0, w, h))
+	}
+	sort.Slice(r.edges, func(i, j int) bool {
+		return math.Min(r.edges[i].y1, r.edges[i].y2) < math.Min(r.edges[j].y1, r.edges[j].y2)
+	})
+	var (
+		active    []edge
+		next      int
+		crossings []crossing
+		acc       = make([][3]uint32, w)
+		total     = uint32(n * n)
+	)
+	for py := 0; py < h; py++ {
+		for i := range acc {
+			acc[i] = [3]uint32{}
+		}
+		for sy := 0; sy < n; sy++ {
+			y := float64(py) + (float64(sy)+0.5)/float64(n)
+			for next < len(r.edges) && math.Min(r.edges[next].y1, r.edges[next].y2) <= y {
+				active = append(active, r.edges[next])
+				next++
+			}
+			crossings = crossings[:0]
+			k := 0
+			for _, e := range active {
+				if math.Max(e.y1, e.y2) < y {
+					continue
+				}
+				active[k] = e
+				k++
+				if (e.y1 <= y) == (e.y2 <= y) {
+					continue
+				}
+				t := (y - e.y1) / (e.y2 - e.y1)
+				c := crossing{x: e.x1 + t*(e.x2-e.x1), dir: 1, c: r.color(e, t)}
+				if e.y2 < e.y1 {
+					c.dir = -1
+				}
+				crossings = append(crossings, c)
+			}
+			active = active[:k]
+			sort.Slice(crossings, func(i, j int) bool { return crossings[i].x < crossings[j].x })
+			r.fill(crossings, acc)
+		}
+		for px, a := range acc {
+			if gray {
+				grayImg.Pix[py*grayImg.Stride+px] = uint8((a[0] + total/2) / total)
+			} else {
+				i := py*rgbaImg.Stride + px*4
+				rgbaImg.Pix[i] = uint8((a[0] + total/2) / total)
+				rgbaImg.Pix[i+1] = uint8((a[1] + total/2) / total)
+				rgbaImg.Pix[i+2] = uint8((a[2] + total/2) / total)
+				rgbaImg.Pix[i+3] = 0xff
+			}
+		}
+	}
+	if gray {
+		return grayImg
+	}
+	return rgbaImg
+}
+
+// fill adds the samples of a scanline that are inside the polygons to acc.
+// Each span takes the color of the crossing on its left.
+func (r *rasterizer) fill(crossings []crossing, acc [][3]uint32) {
+	n := float64(r.samples)
+	limit := len(acc) * r.samples
+	var winding int
+	for i := 0; i < len(crossings)-1; i++ {
+		winding += crossings[i].dir
+		inside := winding != 0
+		if r.opts.FillRule == FillEvenOdd {
+			inside = (i+1)%2 == 1
+		}
+		if !inside {
+			continue
+		}
+		start := int(math.Ceil(crossings[i].x*n - 0.5))
+		end := int(math.Ceil(crossings[i+1].x*n - 0.5))
+		if start < 0 {
+			start = 0
+		}
+		if end > limit {
+			end = limit
+		}
+		c := crossings[i].c
+		for s := start; s < end; s++ {
+			a := &acc[s/r.samples]
+			a[0] += c[0]
+			a[1] += c[1]
+			a[2] += c[2]
+		}
+	}
+}
+
+// color returns the 8-bit color of the edge at t, which is
+// gray for gray images and RGB otherwise.
+func (r *rasterizer) color(e edge, t float64) [3]uint32 {
+	c1, c2 := r.property(e.pid, e.p1), r.property(e.pid, e.p2)
+	var c [3]uint32
+	for i := range c {
+		c[i] = uint32(math.Round(float64(c1[i]) + t*(float64(c2[i])-float64(c1[i]))))
+	}
+	return c
+}
+
+func (r *rasterizer) property(pid, index uint32) [3]uint32 {
+	if r.opts.Color == nil {
+		return [3]uint32{0xff, 0xff, 0xff}
+	}
+	key := [2]uint32{pid, index}
+	if c, ok := r.colors[key]; ok {
+		return c
+	}
+	if r.colors == nil {
+		r.colors = make(map[[2]uint32][3]uint32)
+	}
+	var c [3]uint32
+	if r.opts.gray() {
+		g := color.GrayModel.Convert(r.opts.Color(pid, index)).(color.Gray)
+		c = [3]uint32{uint32(g.Y), uint32(g.Y), uint32(g.Y)}
+	} else {
+		cr, cg, cb, _ := r.opts.Color(pid, index).RGBA()
+		c = [3]uint32{cr >> 8, cg >> 8, cb >> 8}
+	}
+	r.colors[key] = c
+	return c
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
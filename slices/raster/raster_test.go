@@ -0,0 +1,231 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package raster
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/slices"
+	"github.com/go-test/deep"
+)
+
+// addSquare adds a square polygon, counterclockwise unless cw.
+func addSquare(s *slices.Slice, min, max float32, cw bool, seg slices.Segment) {
+	start := uint32(len(s.Vertices))
+	s.Vertices = append(s.Vertices, go3mf.Point2D{min, min}, go3mf.Point2D{max, min}, go3mf.Point2D{max, max}, go3mf.Point2D{min, max})
+	order := []uint32{1, 2, 3, 0}
+	if cw {
+		order = []uint32{3, 2, 1, 0}
+	}
+	p := slices.Polygon{StartV: start}
+	for _, o := range order {
+		seg.V2 = start + o
+		p.Segments = append(p.Segments, seg)
+	}
+	s.Polygons = append(s.Polygons, p)
+}
+
+func grayRows(img image.Image) [][]uint8 {
+	g := img.(*image.Gray)
+	var rows [][]uint8
+	for y := 0; y < g.Rect.Dy(); y++ {
+		rows = append(rows, g.Pix[y*g.Stride:y*g.Stride+g.Rect.Dx()])
+	}
+	return rows
+}
+
+func errStrings(err error) []string {
+	if err == nil {
+		return nil
+	}
+	list, ok := err.(*specerr.List)
+	if !ok {
+		return []string{err.Error()}
+	}
+	var errs []string
+	for _, err := range list.Errors {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+func TestFillRule_String(t *testing.T) {
+	tests := []struct {
+		name string
+		f    FillRule
+	}{
+		{"evenodd", FillEvenOdd},
+		{"nonzero", FillNonZero},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.String(); got != tt.name {
+				t.Errorf("FillRule.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func TestRasterizeSlice_gray(t *testing.T) {
+	nested := func(cw bool) *slices.Slice {
+		s := new(slices.Slice)
+		addSquare(s, 0, 3, false, slices.Segment{})
+		addSquare(s, 1, 2, cw, slices.Segment{})
+		return s
+	}
+	square := new(slices.Slice)
+	addSquare(square, 0.5, 2.5, false, slices.Segment{})
+	open := new(slices.Slice)
+	addSquare(open, 0, 2, false, slices.Segment{})
+	open.Polygons[0].Segments = open.Polygons[0].Segments[:2]
+	tests := []struct {
+		name string
+		s    *slices.Slice
+		opts Options
+		want [][]uint8
+	}{
+		{"empty", new(slices.Slice), Options{PixelSize: 1}, [][]uint8{{0}}},
+		{"aliased", square, Options{PixelSize: 1, Samples: 1, Origin: go3mf.Point2D{0, 0}, Width: 3, Height: 3}, [][]uint8{
+			{255, 255, 0}, {255, 255, 0}, {0, 0, 0},
+		}},
+		{"antialiased", square, Options{PixelSize: 1, Origin: go3mf.Point2D{0, 0}, Width: 3, Height: 3}, [][]uint8{
+			{64, 128, 64}, {128, 255, 128}, {64, 128, 64},
+		}},
+		{"pixelSize", square, Options{PixelSize: 0.5, Samples: 1}, [][]uint8{
+			{255, 255, 255, 255}, {255, 255, 255, 255}, {255, 255, 255, 255}, {255, 255, 255, 255},
+		}},
+		{"evenodd", nested(false), Options{PixelSize: 1, Samples: 1}, [][]uint8{
+			{255, 255, 255}, {255, 0, 255}, {255, 255, 255},
+		}},
+		{"nonzero", nested(false), Options{PixelSize: 1, Samples: 1, FillRule: FillNonZero}, [][]uint8{
+			{255, 255, 255}, {255, 255, 255}, {255, 255, 255},
+		}},
+		{"nonzeroHole", nested(true), Options{PixelSize: 1, Samples: 1, FillRule: FillNonZero}, [][]uint8{
+			{255, 255, 255}, {255, 0, 255}, {255, 255, 255},
+		}},
+		{"open", open, Options{PixelSize: 1, Samples: 1}, [][]uint8{
+			{0, 255}, {255, 255},
+		}},
+		{"color", square, Options{PixelSize: 1, Samples: 1, Origin: go3mf.Point2D{0.5, 0.5}, Width: 2, Height: 2,
+			Color: func(pid, index uint32) color.Color { return color.Gray{Y: uint8(100)} }}, [][]uint8{
+			{100, 100}, {100, 100},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RasterizeSlice(tt.s, tt.opts)
+			if err != nil {
+				t.Fatalf("RasterizeSlice() error = %v", err)
+			}
+			if diff := deep.Equal(grayRows(got), tt.want); diff != nil {
+				t.Errorf("RasterizeSlice() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestRasterizeSlice_rgba(t *testing.T) {
+	colors := []color.Color{color.RGBA{R: 0xff, A: 0xff}, color.RGBA{B: 0xff, A: 0xff}}
+	s := new(slices.Slice)
+	addSquare(s, 0, 4, false, slices.Segment{})
+	// The left side goes down, from red at the top to blue at the bottom.
+	s.Polygons[0].Segments[3] = slices.Segment{V2: 0, PID: 1, P1: 0, P2: 1}
+	got, err := RasterizeSlice(s, Options{PixelSize: 1, Samples: 1, Model: color.RGBAModel, Color: func(pid, index uint32) color.Color {
+		if pid != 1 {
+			return color.White
+		}
+		return colors[index]
+	}})
+	if err != nil {
+		t.Fatalf("RasterizeSlice() error = %v", err)
+	}
+	img := got.(*image.RGBA)
+	want := []color.RGBA{
+		{R: 223, B: 32, A: 255}, {R: 159, B: 96, A: 255}, {R: 96, B: 159, A: 255}, {R: 32, B: 223, A: 255},
+	}
+	for y, c := range want {
+		for x := 0; x < 4; x++ {
+			if got := img.RGBAAt(x, y); got != c {
+				t.Errorf("RasterizeSlice() (%d, %d) = %v, want %v", x, y, got, c)
+			}
+		}
+	}
+}
+
+func TestRasterizeSlice_error(t *testing.T) {
+	s := new(slices.Slice)
+	addSquare(s, 0, 1, false, slices.Segment{})
+	addSquare(s, 0, 1, false, slices.Segment{})
+	s.Polygons[1].Segments[1].V2 = 10
+	if _, err := RasterizeSlice(s, Options{}); err != ErrPixelSize {
+		t.Errorf("RasterizeSlice() error = %v, want %v", err, ErrPixelSize)
+	}
+	_, err := RasterizeSlice(s, Options{PixelSize: 1})
+	if diff := deep.Equal(errStrings(err), []string{fmt.Sprintf("Polygon#1: %v", specerr.ErrIndexOutOfBounds)}); diff != nil {
+		t.Errorf("RasterizeSlice() error = %v", diff)
+	}
+}
+
+func TestRasterize(t *testing.T) {
+	s1, s2 := &slices.Slice{TopZ: 1}, &slices.Slice{TopZ: 2}
+	addSquare(s1, 0, 2, false, slices.Segment{})
+	addSquare(s2, 1, 3, false, slices.Segment{})
+	m := &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{&slices.SliceStack{ID: 1, Slices: []*slices.Slice{s1, s2}}}}}
+	st := &slices.SliceStack{Refs: []slices.SliceRef{{SliceStackID: 1, Path: "/3D/3dmodel.model"}}}
+	var got [][][]uint8
+	err := Rasterize(m, st, Options{PixelSize: 1, Samples: 1}, func(i int, s *slices.Slice, img image.Image) error {
+		if i != len(got) || s != []*slices.Slice{s1, s2}[i] {
+			t.Errorf("Rasterize() = %d, %v", i, s)
+		}
+		got = append(got, grayRows(img))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Rasterize() error = %v", err)
+	}
+	want := [][][]uint8{
+		{{0, 0, 0}, {255, 255, 0}, {255, 255, 0}},
+		{{0, 255, 255}, {0, 255, 255}, {0, 0, 0}},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Rasterize() = %v", diff)
+	}
+}
+
+func TestRasterize_error(t *testing.T) {
+	s := new(slices.Slice)
+	addSquare(s, 0, 1, false, slices.Segment{})
+	bad := new(slices.Slice)
+	bad.Polygons = []slices.Polygon{{StartV: 1}}
+	errFn := errors.New("fn")
+	fn := func(int, *slices.Slice, image.Image) error { return errFn }
+	tests := []struct {
+		name string
+		st   *slices.SliceStack
+		opts Options
+		fn   func(int, *slices.Slice, image.Image) error
+		want string
+	}{
+		{"resolve", &slices.SliceStack{Refs: []slices.SliceRef{{SliceStackID: 1}}}, Options{PixelSize: 1}, fn,
+			fmt.Sprintf("SliceStack@SliceRef#0: %v", specerr.ErrMissingResource)},
+		{"pixelSize", &slices.SliceStack{Slices: []*slices.Slice{s}}, Options{}, fn, ErrPixelSize.Error()},
+		{"slice", &slices.SliceStack{Slices: []*slices.Slice{s, bad}}, Options{PixelSize: 1}, func(int, *slices.Slice, image.Image) error { return nil },
+			fmt.Sprintf("SliceStack@Slice#1@Polygon#0: %v", specerr.ErrIndexOutOfBounds)},
+		{"fn", &slices.SliceStack{Slices: []*slices.Slice{s}}, Options{PixelSize: 1}, fn, errFn.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Rasterize(new(go3mf.Model), tt.st, tt.opts, tt.fn)
+			if diff := deep.Equal(errStrings(err), []string{tt.want}); diff != nil {
+				t.Errorf("Rasterize() error = %v", diff)
+			}
+		})
+	}
+}
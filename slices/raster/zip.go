@@ -0,0 +1,37 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package raster
+
+import (
+	"archive/zip"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/slices"
+)
+
+// LayerName returns the name of the i'th layer image in the zip archives.
+func LayerName(i int) string {
+	return fmt.Sprintf("layer%05d.png", i)
+}
+
+// WriteZip renders the slices of st, resolving its references in m,
+// and writes them to w as a zip archive of PNG images named by LayerName.
+func WriteZip(w io.Writer, m *go3mf.Model, st *slices.SliceStack, opts Options) error {
+	z := zip.NewWriter(w)
+	err := Rasterize(m, st, opts, func(i int, _ *slices.Slice, img image.Image) error {
+		f, err := z.CreateHeader(&zip.FileHeader{Name: LayerName(i), Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		return png.Encode(f, img)
+	})
+	if err != nil {
+		return err
+	}
+	return z.Close()
+}
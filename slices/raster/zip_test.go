@@ -0,0 +1,54 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package raster
+
+import (
+	"archive/zip"
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/slices"
+)
+
+func TestWriteZip(t *testing.T) {
+	s1, s2 := &slices.Slice{TopZ: 1}, &slices.Slice{TopZ: 2}
+	addSquare(s1, 0, 2, false, slices.Segment{})
+	addSquare(s2, 0, 1, false, slices.Segment{})
+	var b bytes.Buffer
+	if err := WriteZip(&b, new(go3mf.Model), &slices.SliceStack{Slices: []*slices.Slice{s1, s2}}, Options{PixelSize: 0.5}); err != nil {
+		t.Fatalf("WriteZip() error = %v", err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	if len(r.File) != 2 {
+		t.Fatalf("WriteZip() files = %v", r.File)
+	}
+	for i, f := range r.File {
+		if f.Name != LayerName(i) {
+			t.Errorf("WriteZip() name = %v, want %v", f.Name, LayerName(i))
+		}
+		rc, _ := f.Open()
+		img, err := png.Decode(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("png.Decode() error = %v", err)
+		}
+		if img.Bounds().Dx() != 4 || img.Bounds().Dy() != 4 {
+			t.Errorf("WriteZip() bounds = %v", img.Bounds())
+		}
+	}
+	if err := WriteZip(new(bytes.Buffer), new(go3mf.Model), &slices.SliceStack{Slices: []*slices.Slice{s1}}, Options{}); err != ErrPixelSize {
+		t.Errorf("WriteZip() error = %v", err)
+	}
+}
+
+func TestLayerName(t *testing.T) {
+	if got := LayerName(12); got != "layer00012.png" {
+		t.Errorf("LayerName() = %v", got)
+	}
+}
@@ -0,0 +1,40 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slices
+
+import (
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+)
+
+// Resolve returns the slices of the stack, following its references,
+// which are searched in m. Referenced stacks must not contain further references.
+func (s *SliceStack) Resolve(m *go3mf.Model) ([]*Slice, error) {
+	if len(s.Refs) == 0 {
+		return s.Slices, nil
+	}
+	var (
+		slices []*Slice
+		errs   error
+	)
+	for i, ref := range s.Refs {
+		r, ok := m.FindAsset(ref.Path, ref.SliceStackID)
+		if !ok {
+			errs = specerr.Append(errs, specerr.WrapIndex(specerr.ErrMissingResource, ref, i))
+			continue
+		}
+		st, ok := r.(*SliceStack)
+		if !ok {
+			errs = specerr.Append(errs, specerr.WrapIndex(ErrNonSliceStack, ref, i))
+		} else if len(st.Refs) != 0 {
+			errs = specerr.Append(errs, specerr.WrapIndex(ErrSliceRefRef, ref, i))
+		} else {
+			slices = append(slices, st.Slices...)
+		}
+	}
+	if errs != nil {
+		return nil, specerr.Wrap(errs, s)
+	}
+	return slices, nil
+}
@@ -0,0 +1,60 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slices
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/go-test/deep"
+)
+
+func TestSliceStack_Resolve(t *testing.T) {
+	s1, s2, s3 := &Slice{TopZ: 1}, &Slice{TopZ: 2}, &Slice{TopZ: 3}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{Assets: []go3mf.Asset{&SliceStack{ID: 1, Slices: []*Slice{s1}}}},
+		Childs: map[string]*go3mf.ChildModel{
+			"/a.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&SliceStack{ID: 1, Slices: []*Slice{s2, s3}},
+				&SliceStack{ID: 2, Refs: []SliceRef{{SliceStackID: 1, Path: "/a.model"}}},
+				&go3mf.BaseMaterials{ID: 3},
+			}}},
+		},
+	}
+	tests := []struct {
+		name    string
+		s       *SliceStack
+		want    []*Slice
+		wantErr []string
+	}{
+		{"slices", &SliceStack{Slices: []*Slice{s1}}, []*Slice{s1}, nil},
+		{"refs", &SliceStack{Refs: []SliceRef{{SliceStackID: 1}, {SliceStackID: 1, Path: "/a.model"}}}, []*Slice{s1, s2, s3}, nil},
+		{"errors", &SliceStack{Refs: []SliceRef{
+			{SliceStackID: 5}, {SliceStackID: 2, Path: "/a.model"}, {SliceStackID: 3, Path: "/a.model"},
+		}}, nil, []string{
+			fmt.Sprintf("SliceStack@SliceRef#0: %v", specerr.ErrMissingResource),
+			fmt.Sprintf("SliceStack@SliceRef#1: %v", ErrSliceRefRef),
+			fmt.Sprintf("SliceStack@SliceRef#2: %v", ErrNonSliceStack),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Resolve(m)
+			var errs []string
+			if err != nil {
+				for _, err := range err.(*specerr.List).Errors {
+					errs = append(errs, err.Error())
+				}
+			}
+			if diff := deep.Equal(errs, tt.wantErr); diff != nil {
+				t.Errorf("SliceStack.Resolve() error = %v", diff)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("SliceStack.Resolve() = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slices
+
+import (
+	"errors"
+	"math"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+)
+
+var (
+	ErrLayerHeight = errors.New("layer height MUST be positive")
+	ErrSliceNoMesh = errors.New("only mesh objects can be sliced")
+)
+
+// SliceOptions defines how a mesh is sliced.
+type SliceOptions struct {
+	// LayerHeight is the distance between two consecutive slices.
+	LayerHeight float32
+	// Transform is applied to the mesh vertices before slicing them.
+	// The zero value is treated as the identity. Only used by SliceMesh.
+	Transform go3mf.Matrix
+}
+
+// SliceMesh intersects the mesh, after applying opts.Transform, with horizontal planes
+// and returns a slice stack whose slice tops are multiples of opts.LayerHeight.
+//
+// Each slice contains the section of the mesh at the middle of its layer.
+// The polygons are closed and oriented counterclockwise for outer contours
+// and clockwise for holes, as long as the mesh is closed and correctly oriented.
+// Open contours, produced by holes in the mesh, are closed with an extra segment.
+// The ID of the returned stack is not set.
+func SliceMesh(mesh *go3mf.Mesh, opts SliceOptions) (*SliceStack, error) {
+	t := opts.Transform
+	if t == (go3mf.Matrix{}) {
+		t = go3mf.Identity()
+	}
+	return sliceMesh(mesh, t, opts.LayerHeight, 0)
+}
+
+// SliceObject slices the mesh of obj, which must be in the resources of path,
+// adds the slice stack to those resources and references it from obj.
+//
+// The slices are defined in the object coordinates, as required by the specification,
+// but the layers are aligned with the build plate taking into account the
+// z translation of the first build item that references obj.
+// It fails with ErrSliceInvalidTranform if obj is referenced with non-planar transforms.
+func SliceObject(m *go3mf.Model, path string, obj *go3mf.Object, opts SliceOptions) (*SliceStack, error) {
+	if obj.Mesh == nil {
+		return nil, ErrSliceNoMesh
+	}
+	res, ok := m.FindResources(path)
+	if !ok {
+		return nil, specerr.ErrMissingResource
+	}
+	if !validateBuildTransforms(m, path, obj.ID) {
+		return nil, ErrSliceInvalidTranform
+	}
+	var offset float32
+	for _, item := range m.Build.Items {
+		if r, _ := m.FindResources(item.ObjectPath()); item.ObjectID == obj.ID && r == res {
+			if item.HasTransform() {
+				offset = -item.Transform[14]
+			}
+			break
+		}
+	}
+	st, err := sliceMesh(obj.Mesh, go3mf.Identity(), opts.LayerHeight, offset)
+	if err != nil {
+		return nil, err
+	}
+	st.ID = res.UnusedID()
+	res.Assets = append(res.Assets, st)
+	if attr := GetObjectAttr(obj); attr != nil {
+		attr.SliceStackID = st.ID
+		attr.MeshResolution = ResolutionFull
+	} else {
+		obj.AnyAttr = append(obj.AnyAttr, &ObjectAttr{SliceStackID: st.ID})
+	}
+	var hasExt bool
+	for _, ext := range m.Extensions {
+		if ext.Namespace == Namespace {
+			hasExt = true
+			break
+		}
+	}
+	if !hasExt {
+		m.Extensions = append(m.Extensions, DefaultExtension)
+	}
+	return st, nil
+}
+
+// sliceMesh slices the transformed mesh with layers whose tops are
+// at offset plus a multiple of the layer height.
+func sliceMesh(mesh *go3mf.Mesh, t go3mf.Matrix, layerHeight, offset float32) (*SliceStack, error) {
+	if layerHeight <= 0 {
+		return nil, ErrLayerHeight
+	}
+	vertices := make([]go3mf.Point3D, len(mesh.Vertices))
+	minZ, maxZ := math.Inf(1), math.Inf(-1)
+	for i, v := range mesh.Vertices {
+		vertices[i] = t.Mul3D(v)
+		z := float64(vertices[i].Z())
+		minZ = math.Min(minZ, z)
+		maxZ = math.Max(maxZ, z)
+	}
+	st := new(SliceStack)
+	if len(mesh.Triangles) == 0 {
+		return st, nil
+	}
+	h := float64(layerHeight)
+	off := math.Mod(float64(offset), h)
+	if off < 0 {
+		off += h
+	}
+	first := math.Floor((minZ-off)/h) + 1
+	last := math.Max(first, math.Ceil((maxZ-off)/h))
+	st.BottomZ = float32((first-1)*h + off)
+	s := slicer{vertices: vertices, triangles: mesh.Triangles, mirror: determinant(t) < 0}
+	for k := first; k <= last; k++ {
+		bottom, top := (k-1)*h+off, k*h+off
+		z := (math.Max(bottom, minZ) + math.Min(top, maxZ)) / 2
+		slice := s.slice(z)
+		slice.TopZ = float32(top)
+		st.Slices = append(st.Slices, slice)
+	}
+	return st, nil
+}
+
+// edge identifies a mesh edge by its sorted vertex indices.
+type edge [2]uint32
+
+func newEdge(v1, v2 uint32) edge {
+	if v1 > v2 {
+		return edge{v2, v1}
+	}
+	return edge{v1, v2}
+}
+
+type slicer struct {
+	vertices  []go3mf.Point3D
+	triangles []go3mf.Triangle
+	mirror    bool
+}
+
+// slice intersects the mesh with the plane at z.
+//
+// Vertices on the plane are considered above it, so every crossed triangle
+// has exactly one edge going down and one going up. The section segment runs
+// from the former to the latter, which leaves the inside on its left
+// for counterclockwise triangles, and adjacent segments share the crossed edges.
+func (s *slicer) slice(z float64) *Slice {
+	var (
+		next   = make(map[edge]edge)
+		starts []edge
+	)
+	for _, tri := range s.triangles {
+		v := [3]uint32{tri.V1, tri.V2, tri.V3}
+		var down, up edge
+		var crossed bool
+		for i := range v {
+			a, b := v[i], v[(i+1)%3]
+			if int(a) >= len(s.vertices) || int(b) >= len(s.vertices) {
+				crossed = false
+				break
+			}
+			aboveA, aboveB := s.above(a, z), s.above(b, z)
+			if aboveA && !aboveB {
+				down, crossed = newEdge(a, b), true
+			} else if !aboveA && aboveB {
+				up = newEdge(a, b)
+			}
+		}
+		if !crossed {
+			continue
+		}
+		if s.mirror {
+			down, up = up, down
+		}
+		if _, ok := next[down]; !ok {
+			starts = append(starts, down)
+		}
+		next[down] = up
+	}
+	// Open contours must be walked from their first edge.
+	targets := make(map[edge]struct{}, len(next))
+	for _, e := range next {
+		targets[e] = struct{}{}
+	}
+	heads := make([]edge, 0, len(starts))
+	for _, e := range starts {
+		if _, ok := targets[e]; !ok {
+			heads = append(heads, e)
+		}
+	}
+	slice := new(Slice)
+	visited := make(map[edge]struct{}, len(next))
+	for _, start := range append(heads, starts...) {
+		if _, ok := visited[start]; ok {
+			continue
+		}
+		var points []go3mf.Point2D
+		for e, ok := start, true; ok; e, ok = next[e] {
+			if _, ok := visited[e]; ok {
+				break
+			}
+			visited[e] = struct{}{}
+			p := s.intersection(e, z)
+			if len(points) == 0 || points[len(points)-1] != p {
+				points = append(points, p)
+			}
+		}
+		if len(points) > 1 && points[0] == points[len(points)-1] {
+			points = points[:len(points)-1]
+		}
+		if len(points) < 3 {
+			continue
+		}
+		slice.addPolygon(points)
+	}
+	return slice
+}
+
+func (s *slicer) above(v uint32, z float64) bool {
+	return float64(s.vertices[v].Z()) >= z
+}
+
+func (s *slicer) intersection(e edge, z float64) go3mf.Point2D {
+	a, b := s.vertices[e[0]], s.vertices[e[1]]
+	za, zb := float64(a.Z()), float64(b.Z())
+	f := float32((z - za) / (zb - za))
+	return go3mf.Point2D{a.X() + (b.X()-a.X())*f, a.Y() + (b.Y()-a.Y())*f}
+}
+
+// addPolygon adds a closed polygon through points.
+func (s *Slice) addPolygon(points []go3mf.Point2D) {
+	start := uint32(len(s.Vertices))
+	s.Vertices = append(s.Vertices, points...)
+	p := Polygon{StartV: start, Segments: make([]Segment, len(points))}
+	for i := 1; i < len(points); i++ {
+		p.Segments[i-1].V2 = start + uint32(i)
+	}
+	p.Segments[len(points)-1].V2 = start
+	s.Polygons = append(s.Polygons, p)
+}
+
+func determinant(t go3mf.Matrix) float32 {
+	return t[0]*(t[5]*t[10]-t[6]*t[9]) - t[1]*(t[4]*t[10]-t[6]*t[8]) + t[2]*(t[4]*t[9]-t[5]*t[8])
+}
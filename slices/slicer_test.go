@@ -0,0 +1,207 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package slices
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/go-test/deep"
+)
+
+// addBox adds a box to mesh, with inward normals if inverted.
+func addBox(mesh *go3mf.Mesh, min, max go3mf.Point3D, inverted bool) {
+	start := uint32(len(mesh.Vertices))
+	for _, z := range []float32{min.Z(), max.Z()} {
+		mesh.Vertices = append(mesh.Vertices,
+			go3mf.Point3D{min.X(), min.Y(), z}, go3mf.Point3D{max.X(), min.Y(), z},
+			go3mf.Point3D{max.X(), max.Y(), z}, go3mf.Point3D{min.X(), max.Y(), z})
+	}
+	for _, f := range [][3]uint32{
+		{0, 2, 1}, {0, 3, 2}, {4, 5, 6}, {4, 6, 7}, {0, 1, 5}, {0, 5, 4},
+		{1, 2, 6}, {1, 6, 5}, {2, 3, 7}, {2, 7, 6}, {3, 0, 4}, {3, 4, 7},
+	} {
+		if inverted {
+			f[1], f[2] = f[2], f[1]
+		}
+		mesh.Triangles = append(mesh.Triangles, go3mf.Triangle{V1: start + f[0], V2: start + f[1], V3: start + f[2]})
+	}
+}
+
+func box(min, max go3mf.Point3D) *go3mf.Mesh {
+	mesh := new(go3mf.Mesh)
+	addBox(mesh, min, max, false)
+	return mesh
+}
+
+// areas returns the signed area of each polygon of the slice.
+func areas(s *Slice) []float64 {
+	var areas []float64
+	for _, p := range s.Polygons {
+		var a float64
+		prev := s.Vertices[p.StartV]
+		for _, seg := range p.Segments {
+			next := s.Vertices[seg.V2]
+			a += float64(prev.X()*next.Y()-next.X()*prev.Y()) / 2
+			prev = next
+		}
+		areas = append(areas, math.Round(a*1000)/1000)
+	}
+	return areas
+}
+
+func TestSliceMesh(t *testing.T) {
+	hollow := box(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{10, 10, 3})
+	addBox(hollow, go3mf.Point3D{2, 2, 1}, go3mf.Point3D{8, 8, 2}, true)
+	tests := []struct {
+		name   string
+		mesh   *go3mf.Mesh
+		opts   SliceOptions
+		bottom float32
+		tops   []float32
+		areas  [][]float64
+	}{
+		{"empty", new(go3mf.Mesh), SliceOptions{LayerHeight: 1}, 0, nil, nil},
+		{"box", box(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{10, 5, 2}), SliceOptions{LayerHeight: 1},
+			0, []float32{1, 2}, [][]float64{{50}, {50}}},
+		{"unaligned", box(go3mf.Point3D{0, 0, 0.5}, go3mf.Point3D{2, 2, 2.2}), SliceOptions{LayerHeight: 1},
+			0, []float32{1, 2, 3}, [][]float64{{4}, {4}, {4}}},
+		{"hollow", hollow, SliceOptions{LayerHeight: 1},
+			0, []float32{1, 2, 3}, [][]float64{{100}, {100, -36}, {100}}},
+		{"translated", box(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{1, 1, 1}), SliceOptions{
+			LayerHeight: 0.5, Transform: go3mf.Identity().Translate(0, 0, 5),
+		}, 5, []float32{5.5, 6}, [][]float64{{1}, {1}}},
+		{"mirrored", box(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{2, 1, 1}), SliceOptions{
+			LayerHeight: 1, Transform: go3mf.Matrix{-1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1},
+		}, 0, []float32{1}, [][]float64{{2}}},
+		{"rotated", box(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{1, 1, 3}), SliceOptions{
+			LayerHeight: 1, Transform: go3mf.Matrix{1, 0, 0, 0, 0, 0, 1, 0, 0, -1, 0, 0, 0, 0, 0, 1},
+		}, 0, []float32{1}, [][]float64{{3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SliceMesh(tt.mesh, tt.opts)
+			if err != nil {
+				t.Fatalf("SliceMesh() error = %v", err)
+			}
+			if got.BottomZ != tt.bottom {
+				t.Errorf("SliceMesh() bottom = %v, want %v", got.BottomZ, tt.bottom)
+			}
+			var (
+				tops []float32
+				a    [][]float64
+			)
+			for _, s := range got.Slices {
+				tops = append(tops, s.TopZ)
+				a = append(a, areas(s))
+			}
+			if diff := deep.Equal(tops, tt.tops); diff != nil {
+				t.Errorf("SliceMesh() tops = %v", diff)
+			}
+			if diff := deep.Equal(a, tt.areas); diff != nil {
+				t.Errorf("SliceMesh() areas = %v", diff)
+			}
+			if !isSliceStackClosed(got) {
+				t.Error("SliceMesh() open polygons")
+			}
+			if got.Slices != nil {
+				if err := got.validateSlices(); err != nil {
+					t.Errorf("SliceMesh() invalid slices = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestSliceMesh_open(t *testing.T) {
+	mesh := box(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{1, 1, 1})
+	mesh.Triangles = mesh.Triangles[:len(mesh.Triangles)-2]
+	got, err := SliceMesh(mesh, SliceOptions{LayerHeight: 1})
+	if err != nil {
+		t.Fatalf("SliceMesh() error = %v", err)
+	}
+	if diff := deep.Equal(areas(got.Slices[0]), []float64{1}); diff != nil {
+		t.Errorf("SliceMesh() = %v", diff)
+	}
+	if !isSliceStackClosed(got) {
+		t.Error("SliceMesh() open polygons")
+	}
+}
+
+func TestSliceObject(t *testing.T) {
+	obj := &go3mf.Object{ID: 1, Mesh: box(go3mf.Point3D{0, 0, 0}, go3mf.Point3D{1, 1, 2})}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{obj}},
+		Build: go3mf.Build{Items: []*go3mf.Item{
+			{ObjectID: 2},
+			{ObjectID: 1, Transform: go3mf.Identity().Translate(5, 5, 0.3)},
+			{ObjectID: 1, Transform: go3mf.Identity().Translate(0, 0, 0.6)},
+		}},
+	}
+	got, err := SliceObject(m, "", obj, SliceOptions{LayerHeight: 1})
+	if err != nil {
+		t.Fatalf("SliceObject() error = %v", err)
+	}
+	if got.ID != 2 || len(m.Resources.Assets) != 1 || m.Resources.Assets[0] != got {
+		t.Errorf("SliceObject() = %v", m.Resources.Assets)
+	}
+	if diff := deep.Equal(GetObjectAttr(obj), &ObjectAttr{SliceStackID: 2}); diff != nil {
+		t.Errorf("SliceObject() attr = %v", diff)
+	}
+	if diff := deep.Equal(m.Extensions, []go3mf.Extension{DefaultExtension}); diff != nil {
+		t.Errorf("SliceObject() extensions = %v", diff)
+	}
+	var tops []float64
+	for _, s := range got.Slices {
+		tops = append(tops, math.Round(float64(s.TopZ)*1000)/1000)
+	}
+	if diff := deep.Equal(tops, []float64{0.7, 1.7, 2.7}); diff != nil {
+		t.Errorf("SliceObject() tops = %v", diff)
+	}
+	m.Build.Items = m.Build.Items[1:]
+	if err := m.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	obj.AnyAttr[0].(*ObjectAttr).MeshResolution = ResolutionLow
+	got, err = SliceObject(m, "", obj, SliceOptions{LayerHeight: 1})
+	if err != nil {
+		t.Fatalf("SliceObject() error = %v", err)
+	}
+	if diff := deep.Equal(obj.AnyAttr, go3mf.AnyAttr{&ObjectAttr{SliceStackID: got.ID}}); diff != nil {
+		t.Errorf("SliceObject() attr = %v", diff)
+	}
+	if len(m.Extensions) != 1 {
+		t.Errorf("SliceObject() extensions = %v", m.Extensions)
+	}
+}
+
+func TestSliceObject_error(t *testing.T) {
+	rotated := go3mf.Matrix{1, 0, 0, 0, 0, 0, 1, 0, 0, -1, 0, 0, 0, 0, 0, 1}
+	tests := []struct {
+		name string
+		path string
+		obj  *go3mf.Object
+		opts SliceOptions
+		want error
+	}{
+		{"noMesh", "", &go3mf.Object{ID: 1}, SliceOptions{LayerHeight: 1}, ErrSliceNoMesh},
+		{"path", "/other.model", &go3mf.Object{ID: 1, Mesh: new(go3mf.Mesh)}, SliceOptions{LayerHeight: 1}, specerr.ErrMissingResource},
+		{"transform", "", &go3mf.Object{ID: 2, Mesh: new(go3mf.Mesh)}, SliceOptions{LayerHeight: 1}, ErrSliceInvalidTranform},
+		{"layerHeight", "", &go3mf.Object{ID: 1, Mesh: new(go3mf.Mesh)}, SliceOptions{}, ErrLayerHeight},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &go3mf.Model{Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 2, Transform: rotated}}}}
+			if _, err := SliceObject(m, tt.path, tt.obj, tt.opts); err != tt.want {
+				t.Errorf("SliceObject() error = %v, want %v", err, tt.want)
+			}
+			if len(m.Resources.Assets) != 0 || len(m.Extensions) != 0 {
+				t.Errorf("SliceObject() modified the model")
+			}
+		})
+	}
+}
@@ -107,6 +107,47 @@ func (s *SliceStack) Identify() uint32 {
 	return s.ID
 }
 
+// References returns the IDs of the resources referenced by
+// the slice segments and by the slice refs without a path.
+func (s *SliceStack) References() []uint32 {
+	var refs []uint32
+	for _, r := range s.Refs {
+		if r.Path == "" && r.SliceStackID != 0 {
+			refs = append(refs, r.SliceStackID)
+		}
+	}
+	for _, sl := range s.Slices {
+		for _, p := range sl.Polygons {
+			for _, seg := range p.Segments {
+				if seg.PID != 0 {
+					refs = append(refs, seg.PID)
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// Remap replaces the ID of s, and the IDs it references, with the ones returned by fn.
+// The slice refs are passed with their path, which is also replaced.
+func (s *SliceStack) Remap(fn func(path string, id uint32) (string, uint32)) {
+	if s.ID != 0 {
+		_, s.ID = fn("", s.ID)
+	}
+	for i, r := range s.Refs {
+		s.Refs[i].Path, s.Refs[i].SliceStackID = fn(r.Path, r.SliceStackID)
+	}
+	for _, sl := range s.Slices {
+		for _, p := range sl.Polygons {
+			for i, seg := range p.Segments {
+				if seg.PID != 0 {
+					_, p.Segments[i].PID = fn("", seg.PID)
+				}
+			}
+		}
+	}
+}
+
 func GetObjectAttr(obj *go3mf.Object) *ObjectAttr {
 	for _, a := range obj.AnyAttr {
 		if a, ok := a.(*ObjectAttr); ok {
@@ -116,6 +157,21 @@ func GetObjectAttr(obj *go3mf.Object) *ObjectAttr {
 	return nil
 }
 
+// References returns the IDs of the resources referenced by o.
+func (o *ObjectAttr) References() []uint32 {
+	if o.SliceStackID == 0 {
+		return nil
+	}
+	return []uint32{o.SliceStackID}
+}
+
+// Remap replaces the IDs referenced by o with the ones returned by fn.
+func (o *ObjectAttr) Remap(fn func(path string, id uint32) (string, uint32)) {
+	if o.SliceStackID != 0 {
+		_, o.SliceStackID = fn("", o.SliceStackID)
+	}
+}
+
 // ObjectAttr defines the attributes added to Object.
 type ObjectAttr struct {
 	SliceStackID   uint32
@@ -72,3 +72,40 @@ func Test_newMeshResolution(t *testing.T) {
 		})
 	}
 }
+
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		r    interface{ References() []uint32 }
+		want []uint32
+	}{
+		{"attrEmpty", new(ObjectAttr), nil},
+		{"attr", &ObjectAttr{SliceStackID: 3}, []uint32{3}},
+		{"refs", &SliceStack{Refs: []SliceRef{{SliceStackID: 1}, {SliceStackID: 2, Path: "/other.model"}}}, []uint32{1}},
+		{"segments", &SliceStack{Slices: []*Slice{{Polygons: []Polygon{{Segments: []Segment{{PID: 4}, {}}}}}}}, []uint32{4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.References(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("References() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSliceStack_Remap(t *testing.T) {
+	s := &SliceStack{
+		ID:     1,
+		Refs:   []SliceRef{{SliceStackID: 2, Path: "/other.model"}},
+		Slices: []*Slice{{Polygons: []Polygon{{Segments: []Segment{{PID: 4}, {}}}}}},
+	}
+	s.Remap(func(path string, id uint32) (string, uint32) { return "", id + 10 })
+	want := &SliceStack{
+		ID:     11,
+		Refs:   []SliceRef{{SliceStackID: 12}},
+		Slices: []*Slice{{Polygons: []Polygon{{Segments: []Segment{{PID: 14}, {}}}}}},
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("SliceStack.Remap() = %v, want %v", s, want)
+	}
+}
@@ -19,6 +19,12 @@ type PropertyGroup interface {
 	Len() int
 }
 
+// A Shape is an object extension element, such as a boolean shape,
+// that defines the object geometry instead of a mesh or components.
+type Shape interface {
+	IsShape() bool
+}
+
 // If a Spec implemented ValidateSpec, then model.Validate will call
 // Validate and aggregate the resulting erros.
 //
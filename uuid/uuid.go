@@ -5,11 +5,13 @@ package uuid
 
 import (
 	"crypto/rand"
+	"crypto/sha1"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 )
 
 var rander = rand.Reader
@@ -45,18 +47,66 @@ func New() string {
 	return string(buf[:])
 }
 
+// NewSHA1 returns a name-based (Version 5) UUID derived from the SHA-1 hash
+// of the namespace UUID space and data, as described in IETF RFC 4122.
+// The same space and data always generate the same UUID.
+//
+// It returns an error if space is not a valid UUID.
+func NewSHA1(space string, data []byte) (string, error) {
+	ns, err := parseUUID(space)
+	if err != nil {
+		return "", err
+	}
+	h := sha1.New()
+	h.Write(ns[:])
+	h.Write(data)
+	var uuid [16]byte
+	copy(uuid[:], h.Sum(nil))
+	uuid[6] = (uuid[6] & 0x0f) | 0x50 // Version 5
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
+	var buf [36]byte
+	encodeHex(buf[:], uuid)
+	return string(buf[:]), nil
+}
+
+// NewV7 returns a time-ordered (Version 7) UUID, whose first 48 bits
+// are the current Unix time in milliseconds and the rest are random.
+// UUIDs generated in different milliseconds sort lexically by creation time.
+func NewV7() string {
+	var uuid [16]byte
+	_, err := io.ReadFull(rander, uuid[6:])
+	if err != nil {
+		panic(err)
+	}
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	for i := 0; i < 6; i++ {
+		uuid[i] = byte(ms >> (40 - 8*i))
+	}
+	uuid[6] = (uuid[6] & 0x0f) | 0x70 // Version 7
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
+	var buf [36]byte
+	encodeHex(buf[:], uuid)
+	return string(buf[:])
+}
+
 // Validate decodes s into a UUID or returns an error. Both the standard UUID
 // forms of xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx and
 // urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx are decoded as well as the
 // Microsoft encoding {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx} and the raw hex
 // encoding: xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx.
 func Validate(s string) error {
-	if err := validateUUID(s); err != nil {
+	if _, err := parseUUID(s); err != nil {
 		return err
 	}
 	return nil
 }
 
+// Parse decodes s into the 16 bytes of a UUID, accepting the same forms as Validate,
+// so the different forms of the same UUID are decoded into the same value.
+func Parse(s string) ([16]byte, error) {
+	return parseUUID(s)
+}
+
 // xvalues returns the value of a byte as a hexadecimal digit or 255.
 var xvalues = [256]byte{
 	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
@@ -84,14 +134,13 @@ func xtob(x1, x2 byte) (byte, bool) {
 	return (b1 << 4) | b2, b1 != 255 && b2 != 255
 }
 
-// validateUUID decodes s and return an erorr if it is not valid. Both the standard UUID
+// parseUUID decodes s and return an erorr if it is not valid. Both the standard UUID
 // forms of xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx and
 // urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx are decoded as well as the
 // Microsoft encoding {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx} and the raw hex
 // encoding: xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx.
 // Implementation taken form https://github.com/google/uuid.
-func validateUUID(s string) error {
-	var uuid [16]byte
+func parseUUID(s string) (uuid [16]byte, err error) {
 	switch len(s) {
 	// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
 	case 36:
@@ -99,7 +148,7 @@ func validateUUID(s string) error {
 	// urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
 	case 36 + 9:
 		if strings.ToLower(s[:9]) != "urn:uuid:" {
-			return fmt.Errorf("production: invalid urn prefix: %q", s[:9])
+			return uuid, fmt.Errorf("production: invalid urn prefix: %q", s[:9])
 		}
 		s = s[9:]
 
@@ -113,17 +162,17 @@ func validateUUID(s string) error {
 		for i := range uuid {
 			uuid[i], ok = xtob(s[i*2], s[i*2+1])
 			if !ok {
-				return errors.New("production: invalid UUID format")
+				return uuid, errors.New("production: invalid UUID format")
 			}
 		}
-		return nil
+		return uuid, nil
 	default:
-		return fmt.Errorf("production: invalid UUID length: %d", len(s))
+		return uuid, fmt.Errorf("production: invalid UUID length: %d", len(s))
 	}
 	// s is now at least 36 bytes long
 	// it must be of the form  xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
 	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
-		return errors.New("production: invalid UUID format")
+		return uuid, errors.New("production: invalid UUID format")
 	}
 	for i, x := range [16]int{
 		0, 2, 4, 6,
@@ -133,11 +182,11 @@ func validateUUID(s string) error {
 		24, 26, 28, 30, 32, 34} {
 		v, ok := xtob(s[x], s[x+1])
 		if !ok {
-			return errors.New("production: invalid UUID format")
+			return uuid, errors.New("production: invalid UUID format")
 		}
 		uuid[i] = v
 	}
-	return nil
+	return uuid, nil
 }
 
 func encodeHex(dst []byte, uuid [16]byte) {
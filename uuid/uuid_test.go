@@ -98,6 +98,26 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestParse(t *testing.T) {
+	want, err := Parse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	for _, s := range []string{
+		"F47AC10B-58CC-0372-8567-0E02B2C3D479",
+		"urn:uuid:f47ac10b-58cc-0372-8567-0e02b2c3d479",
+		"{f47ac10b-58cc-0372-8567-0e02b2c3d479}",
+		"f47ac10b58cc037285670e02b2c3d479",
+	} {
+		if got, err := Parse(s); err != nil || got != want {
+			t.Errorf("Parse(%q) = %x, %v, want %x", s, got, err, want)
+		}
+	}
+	if _, err := Parse("a-b-c-d"); err == nil {
+		t.Error("Parse() expected error")
+	}
+}
+
 func TestSetRand(t *testing.T) {
 	type args struct {
 		r io.Reader
@@ -115,3 +135,45 @@ func TestSetRand(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSHA1(t *testing.T) {
+	tests := []struct {
+		name    string
+		space   string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{"dns", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "python.org", "886313e1-3b8a-5372-9b90-0c9aee199e5d", false},
+		{"urn", "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", "python.org", "886313e1-3b8a-5372-9b90-0c9aee199e5d", false},
+		{"invalidSpace", "a-b-c-d", "python.org", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSHA1(tt.space, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSHA1() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NewSHA1() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewV7(t *testing.T) {
+	prev := NewV7()
+	for x := 1; x < 32; x++ {
+		s := NewV7()
+		if err := Validate(s); err != nil {
+			t.Errorf("NewV7() returned %q which does not decode", s)
+		}
+		if s[14] != '7' {
+			t.Errorf("NewV7() returned %q with version %c", s, s[14])
+		}
+		if s[:8] < prev[:8] {
+			t.Errorf("NewV7() returned %q before %q", s, prev)
+		}
+		prev = s
+	}
+}
@@ -35,6 +35,9 @@ func (m *Model) Validate() error {
 	sortedChilds := m.sortedChilds()
 	for _, path := range sortedChilds {
 		c := m.Childs[path]
+		if err := c.Load(); err != nil {
+			return err
+		}
 		if path == rootPath {
 			errs = errors.Append(errs, errors.ErrOPCDuplicatedModelName)
 		} else {
@@ -197,6 +200,16 @@ func (res *Resources) validate(m *Model, path string) error {
 	return errs
 }
 
+// hasShape reports whether an extension element defines the object geometry.
+func (r *Object) hasShape() bool {
+	for _, a := range r.Any {
+		if s, ok := a.(spec.Shape); ok && s.IsShape() {
+			return true
+		}
+	}
+	return false
+}
+
 // Validate validates that the object is compliant with 3MF specs,
 // except for the mesh coherency.
 func (r *Object) Validate(m *Model, path string) error {
@@ -208,21 +221,21 @@ func (r *Object) Validate(m *Model, path string) error {
 	if r.PIndex != 0 && r.PID == 0 {
 		errs = errors.Append(errs, errors.NewMissingFieldError(attrPID))
 	}
-	if (r.Mesh != nil && r.Components != nil) || (r.Mesh == nil && r.Components == nil) {
+	if (r.Mesh != nil && r.Components != nil) || (r.Mesh == nil && r.Components == nil && !r.hasShape()) {
 		errs = errors.Append(errs, errors.ErrInvalidObject)
 	}
-	if r.Mesh != nil {
-		if r.PID != 0 {
-			if a, ok := res.FindAsset(r.PID); ok {
-				if a, ok := a.(spec.PropertyGroup); ok {
-					if int(r.PIndex) >= a.Len() {
-						errs = errors.Append(errs, errors.ErrIndexOutOfBounds)
-					}
+	if r.Components == nil && r.PID != 0 {
+		if a, ok := res.FindAsset(r.PID); ok {
+			if a, ok := a.(spec.PropertyGroup); ok {
+				if int(r.PIndex) >= a.Len() {
+					errs = errors.Append(errs, errors.ErrIndexOutOfBounds)
 				}
-			} else {
-				errs = errors.Append(errs, errors.ErrMissingResource)
 			}
+		} else {
+			errs = errors.Append(errs, errors.ErrMissingResource)
 		}
+	}
+	if r.Mesh != nil {
 		err := r.validateMesh(m, path)
 		if err != nil {
 			errs = errors.Append(errs, errors.Wrap(err, r.Mesh))
@@ -281,6 +294,44 @@ func (r *Object) validateMesh(m *Model, path string) error {
 			}
 		}
 	}
+	errs = errors.Append(errs, r.Mesh.validateTriangleSets())
+	return errs
+}
+
+func (m *Mesh) validateTriangleSets() error {
+	var errs error
+	triangleCount := uint32(len(m.Triangles))
+	ids := make(map[string]struct{}, len(m.TriangleSets))
+	for i := range m.TriangleSets {
+		set := &m.TriangleSets[i]
+		var setErrs error
+		if set.Name == "" {
+			setErrs = errors.Append(setErrs, errors.NewMissingFieldError(attrName))
+		}
+		if set.Identifier == "" {
+			setErrs = errors.Append(setErrs, errors.NewMissingFieldError(attrIdentifier))
+		} else if _, ok := ids[set.Identifier]; ok {
+			setErrs = errors.Append(setErrs, errors.ErrDuplicatedTriangleSetID)
+		} else {
+			ids[set.Identifier] = struct{}{}
+		}
+		for _, ref := range set.Refs {
+			if ref >= triangleCount {
+				setErrs = errors.Append(setErrs, errors.ErrIndexOutOfBounds)
+				break
+			}
+		}
+		for j, r := range set.RefRanges {
+			if r.Start > r.End {
+				setErrs = errors.Append(setErrs, errors.WrapIndex(errors.ErrTriangleSetRange, r, j))
+			} else if r.End >= triangleCount {
+				setErrs = errors.Append(setErrs, errors.WrapIndex(errors.ErrIndexOutOfBounds, r, j))
+			}
+		}
+		if setErrs != nil {
+			errs = errors.Append(errs, errors.WrapIndex(setErrs, set, i))
+		}
+	}
 	return errs
 }
 
@@ -370,6 +421,11 @@ func (m *Model) ValidateCoherency() error {
 		wg   sync.WaitGroup
 		mu   sync.Mutex
 	)
+	for _, c := range m.Childs {
+		if err := c.Load(); err != nil {
+			return err
+		}
+	}
 	wg.Add(len(m.Resources.Objects))
 	for i := range m.Resources.Objects {
 		go func(i int) {
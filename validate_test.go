@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/spec"
 	"github.com/go-test/deep"
 )
 
@@ -127,6 +128,28 @@ func TestValidate(t *testing.T) {
 			fmt.Sprintf("Resources@Object#5@Mesh@Triangle#1: %v", errors.ErrIndexOutOfBounds),
 			fmt.Sprintf("Resources@Object#5@Mesh@Triangle#3: %v", errors.ErrMissingResource),
 		}},
+		{"shapes", &Model{Resources: Resources{Objects: []*Object{
+			{ID: 1, Any: Any{spec.UnknownTokens{xml.StartElement{Name: fooName}, xml.EndElement{Name: fooName}}}},
+			{ID: 2, Any: Any{&fakeShape{}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#0: %v", errors.ErrInvalidObject),
+		}},
+		{"triangleSets", &Model{Resources: Resources{Objects: []*Object{
+			{ID: 1, Mesh: &Mesh{Vertices: []Point3D{{}, {}, {}, {}}, Triangles: []Triangle{
+				{V1: 0, V2: 1, V3: 2}, {V1: 0, V2: 3, V3: 1}, {V1: 0, V2: 2, V3: 3}, {V1: 1, V2: 3, V3: 2},
+			}, TriangleSets: []TriangleSet{
+				{Name: "a", Identifier: "a", Refs: []uint32{0, 3}, RefRanges: []TriangleRefRange{{Start: 1, End: 2}}},
+				{Identifier: "a", Refs: []uint32{4}},
+				{Name: "c", RefRanges: []TriangleRefRange{{Start: 2, End: 1}, {Start: 1, End: 4}}},
+			}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#1: %v", &errors.MissingFieldError{Name: attrName}),
+			fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#1: %v", errors.ErrDuplicatedTriangleSetID),
+			fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#1: %v", errors.ErrIndexOutOfBounds),
+			fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#2: %v", &errors.MissingFieldError{Name: attrIdentifier}),
+			fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#2@TriangleRefRange#0: %v", errors.ErrTriangleSetRange),
+			fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#2@TriangleRefRange#1: %v", errors.ErrIndexOutOfBounds),
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -238,3 +261,9 @@ func TestModel_ValidateCoherency(t *testing.T) {
 		})
 	}
 }
+
+type fakeShape struct{}
+
+func (f *fakeShape) IsShape() bool { return true }
+
+func (f *fakeShape) Marshal3MF(_ spec.Encoder) error { return nil }
@@ -0,0 +1,625 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package volumetric
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/MosaicManufacturing/go3mf"
+	specerr "github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/MosaicManufacturing/go3mf/spec"
+)
+
+func (Spec) DecodeAttribute(parent interface{}, a spec.Attr) error {
+	if mesh, ok := parent.(*go3mf.Mesh); ok && a.Name.Local == attrVolumeID {
+		val, err := strconv.ParseUint(string(a.Value), 10, 32)
+		if ext := GetMeshAttr(mesh); ext != nil {
+			ext.VolumeID = uint32(val)
+		} else {
+			mesh.AnyAttr = append(mesh.AnyAttr, &MeshAttr{VolumeID: uint32(val)})
+		}
+		if err != nil {
+			return specerr.NewParseAttrError(a.Name.Local, true)
+		}
+	}
+	return nil
+}
+
+func (Spec) CreateElementDecoder(parent interface{}, name string) (child spec.ElementDecoder) {
+	switch parent := parent.(type) {
+	case *go3mf.Resources:
+		switch name {
+		case attrImage3D:
+			child = &image3DDecoder{resources: parent}
+		case attrFunctionFromImage3D:
+			child = &functionFromImage3DDecoder{resources: parent}
+		case attrVolumeData:
+			child = &volumeDataDecoder{resources: parent}
+		}
+	case *go3mf.Object:
+		if name == attrLevelSet {
+			child = &levelSetDecoder{obj: parent}
+		}
+	}
+	return
+}
+
+func (ImplicitSpec) DecodeAttribute(interface{}, spec.Attr) error {
+	return nil
+}
+
+func (ImplicitSpec) CreateElementDecoder(parent interface{}, name string) spec.ElementDecoder {
+	if resources, ok := parent.(*go3mf.Resources); ok && name == attrImplicitFunction {
+		return &implicitFunctionDecoder{resources: resources}
+	}
+	return nil
+}
+
+type image3DDecoder struct {
+	baseDecoder
+	resources *go3mf.Resources
+	resource  Image3D
+}
+
+func (d *image3DDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *image3DDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *image3DDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrImageStack {
+		child = &imageStackDecoder{stack: &d.resource.Stack}
+	}
+	return
+}
+
+func (d *image3DDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
+			id, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.ID = uint32(id)
+		case attrName:
+			d.resource.Name = string(a.Value)
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type imageStackDecoder struct {
+	baseDecoder
+	stack *ImageStack
+}
+
+func (d *imageStackDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrRowCount, attrColumnCount, attrSheetCount:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			switch a.Name.Local {
+			case attrRowCount:
+				d.stack.RowCount = uint32(val)
+			case attrColumnCount:
+				d.stack.ColumnCount = uint32(val)
+			}
+		}
+	}
+	if errs != nil {
+		return specerr.Wrap(errs, d.stack)
+	}
+	return nil
+}
+
+func (d *imageStackDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrImageSheet {
+		child = &imageSheetDecoder{stack: d.stack}
+	}
+	return
+}
+
+type imageSheetDecoder struct {
+	baseDecoder
+	stack *ImageStack
+}
+
+func (d *imageSheetDecoder) Start(attrs []spec.Attr) error {
+	var path string
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrPath {
+			path = string(a.Value)
+		}
+	}
+	d.stack.Sheets = append(d.stack.Sheets, path)
+	return nil
+}
+
+type functionFromImage3DDecoder struct {
+	baseDecoder
+	resources *go3mf.Resources
+	resource  FunctionFromImage3D
+}
+
+func (d *functionFromImage3DDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *functionFromImage3DDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.resource.ValueScale = 1
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		var ok bool
+		switch a.Name.Local {
+		case attrID, attrImage3DID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			if a.Name.Local == attrID {
+				d.resource.ID = uint32(val)
+			} else {
+				d.resource.Image3DID = uint32(val)
+			}
+		case attrDisplayName:
+			d.resource.DisplayName = string(a.Value)
+		case attrValueOffset, attrValueScale:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			if a.Name.Local == attrValueOffset {
+				d.resource.ValueOffset = float32(val)
+			} else {
+				d.resource.ValueScale = float32(val)
+			}
+		case attrFilter:
+			if d.resource.Filter, ok = newFilter(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrTileStyleU:
+			if d.resource.TileStyleU, ok = newTileStyle(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrTileStyleV:
+			if d.resource.TileStyleV, ok = newTileStyle(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrTileStyleW:
+			if d.resource.TileStyleW, ok = newTileStyle(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type volumeDataDecoder struct {
+	baseDecoder
+	resources *go3mf.Resources
+	resource  VolumeData
+}
+
+func (d *volumeDataDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *volumeDataDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *volumeDataDecoder) Start(attrs []spec.Attr) error {
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrID {
+			id, err := strconv.ParseUint(string(a.Value), 10, 32)
+			d.resource.ID = uint32(id)
+			if err != nil {
+				return specerr.WrapIndex(specerr.NewParseAttrError(a.Name.Local, true), &d.resource, len(d.resources.Assets))
+			}
+		}
+	}
+	return nil
+}
+
+func (d *volumeDataDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space != Namespace {
+		return
+	}
+	switch name.Local {
+	case attrComposite:
+		d.resource.Composite = new(Composite)
+		child = &compositeDecoder{composite: d.resource.Composite}
+	case attrColor:
+		d.resource.Color = new(FunctionRef)
+		child = &functionRefDecoder{ref: d.resource.Color}
+	case attrProperty:
+		d.resource.Properties = append(d.resource.Properties, Property{})
+		child = &propertyDecoder{property: &d.resource.Properties[len(d.resource.Properties)-1]}
+	}
+	return
+}
+
+type compositeDecoder struct {
+	baseDecoder
+	composite *Composite
+}
+
+func (d *compositeDecoder) Start(attrs []spec.Attr) error {
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrBaseMaterialID {
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			d.composite.BaseMaterialID = uint32(val)
+			if err != nil {
+				return specerr.Wrap(specerr.NewParseAttrError(a.Name.Local, true), d.composite)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *compositeDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrMaterialMapping {
+		d.composite.Mappings = append(d.composite.Mappings, FunctionRef{})
+		child = &functionRefDecoder{ref: &d.composite.Mappings[len(d.composite.Mappings)-1]}
+	}
+	return
+}
+
+type functionRefDecoder struct {
+	baseDecoder
+	ref *FunctionRef
+}
+
+func (d *functionRefDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	for _, a := range attrs {
+		if a.Name.Space == "" {
+			_, err := decodeFunctionRefAttr(d.ref, a)
+			errs = specerr.Append(errs, err)
+		}
+	}
+	if errs != nil {
+		return specerr.Wrap(errs, d.ref)
+	}
+	return nil
+}
+
+type propertyDecoder struct {
+	baseDecoder
+	property *Property
+}
+
+func (d *propertyDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		if ok, err := decodeFunctionRefAttr(&d.property.FunctionRef, a); ok {
+			errs = specerr.Append(errs, err)
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			d.property.Name = string(a.Value)
+		case attrRequired:
+			val, err := strconv.ParseBool(string(a.Value))
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.property.Required = val
+		}
+	}
+	if errs != nil {
+		return specerr.Wrap(errs, d.property)
+	}
+	return nil
+}
+
+type levelSetDecoder struct {
+	baseDecoder
+	obj *go3mf.Object
+}
+
+func (d *levelSetDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	ls := new(LevelSet)
+	d.obj.Any = append(d.obj.Any, ls)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		if ok, err := decodeFunctionRefAttr(&ls.FunctionRef, a); ok {
+			errs = specerr.Append(errs, err)
+			continue
+		}
+		switch a.Name.Local {
+		case attrMeshID, attrVolumeID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, a.Name.Local == attrMeshID))
+			}
+			if a.Name.Local == attrMeshID {
+				ls.MeshID = uint32(val)
+			} else {
+				ls.VolumeID = uint32(val)
+			}
+		case attrMeshBBoxOnly:
+			val, err := strconv.ParseBool(string(a.Value))
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			ls.MeshBBoxOnly = val
+		}
+	}
+	if errs != nil {
+		return specerr.Wrap(errs, ls)
+	}
+	return nil
+}
+
+// decodeFunctionRefAttr decodes a into f if it is a function reference attribute.
+func decodeFunctionRefAttr(f *FunctionRef, a spec.Attr) (bool, error) {
+	switch a.Name.Local {
+	case attrFunctionID:
+		val, err := strconv.ParseUint(string(a.Value), 10, 32)
+		f.FunctionID = uint32(val)
+		if err != nil {
+			return true, specerr.NewParseAttrError(a.Name.Local, true)
+		}
+	case attrChannel:
+		f.Channel = string(a.Value)
+	case attrTransform:
+		var ok bool
+		if f.Transform, ok = spec.ParseMatrix(string(a.Value)); !ok {
+			return true, specerr.NewParseAttrError(a.Name.Local, false)
+		}
+	case attrMinFeatureSize, attrFallbackValue:
+		val, err := strconv.ParseFloat(string(a.Value), 32)
+		if a.Name.Local == attrMinFeatureSize {
+			f.MinFeatureSize = float32(val)
+		} else {
+			f.FallbackValue = float32(val)
+		}
+		if err != nil {
+			return true, specerr.NewParseAttrError(a.Name.Local, false)
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+type implicitFunctionDecoder struct {
+	baseDecoder
+	resources *go3mf.Resources
+	resource  ImplicitFunction
+}
+
+func (d *implicitFunctionDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *implicitFunctionDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *implicitFunctionDecoder) Start(attrs []spec.Attr) error {
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
+			id, err := strconv.ParseUint(string(a.Value), 10, 32)
+			d.resource.ID = uint32(id)
+			if err != nil {
+				return specerr.WrapIndex(specerr.NewParseAttrError(a.Name.Local, true), &d.resource, len(d.resources.Assets))
+			}
+		case attrDisplayName:
+			d.resource.DisplayName = string(a.Value)
+		}
+	}
+	return nil
+}
+
+func (d *implicitFunctionDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == ImplicitNamespace {
+		switch name.Local {
+		case attrIn:
+			return &portsDecoder{ports: &d.resource.Inputs}
+		case attrOut:
+			return &portsDecoder{ports: &d.resource.Outputs}
+		}
+		if t, ok := newNodeType(name.Local); ok {
+			return &nodeDecoder{function: &d.resource, nodeType: t}
+		}
+	}
+	return &anyDecoder{UnknownTokensDecoder: spec.UnknownTokensDecoder{Name: name}, any: &d.resource.Any}
+}
+
+type nodeDecoder struct {
+	baseDecoder
+	function *ImplicitFunction
+	nodeType NodeType
+	node     Node
+}
+
+func (d *nodeDecoder) End() {
+	d.function.Nodes = append(d.function.Nodes, d.node)
+}
+
+func (d *nodeDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, d.node, len(d.function.Nodes))
+}
+
+func (d *nodeDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.node.Type = d.nodeType
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrIdentifier:
+			d.node.Identifier = string(a.Value)
+		case attrDisplayName:
+			d.node.DisplayName = string(a.Value)
+		case attrTag:
+			d.node.Tag = string(a.Value)
+		case attrValue:
+			if d.nodeType == NodeConstResourceID {
+				val, err := strconv.ParseUint(string(a.Value), 10, 32)
+				if err != nil {
+					errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+				}
+				d.node.ResourceID = uint32(val)
+			} else {
+				val, err := strconv.ParseFloat(string(a.Value), 32)
+				if err != nil {
+					errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+				}
+				d.node.Value = float32(val)
+			}
+		case attrX, attrY, attrZ:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.node.Vector[a.Name.Local[0]-'x'] = float32(val)
+		case attrMatrix:
+			var ok bool
+			if d.node.Matrix, ok = parseMatrix4(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, d.node, len(d.function.Nodes))
+	}
+	return nil
+}
+
+func (d *nodeDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == ImplicitNamespace {
+		switch name.Local {
+		case attrIn:
+			child = &portsDecoder{ports: &d.node.Inputs}
+		case attrOut:
+			child = &portsDecoder{ports: &d.node.Outputs}
+		}
+	}
+	return
+}
+
+type portsDecoder struct {
+	baseDecoder
+	ports *[]Port
+}
+
+func (d *portsDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space != ImplicitNamespace {
+		return
+	}
+	if t, isRef, ok := newPortType(name.Local); ok {
+		child = &portDecoder{ports: d.ports, portType: t, isRef: isRef}
+	}
+	return
+}
+
+type portDecoder struct {
+	baseDecoder
+	ports    *[]Port
+	portType PortType
+	isRef    bool
+}
+
+func (d *portDecoder) Start(attrs []spec.Attr) error {
+	p := Port{Type: d.portType}
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrIdentifier:
+			p.Identifier = string(a.Value)
+		case attrDisplayName:
+			p.DisplayName = string(a.Value)
+		case attrRef:
+			if d.isRef {
+				p.Ref = string(a.Value)
+			}
+		}
+	}
+	*d.ports = append(*d.ports, p)
+	return nil
+}
+
+type anyDecoder struct {
+	spec.UnknownTokensDecoder
+	any *go3mf.Any
+}
+
+func (d *anyDecoder) End() {
+	d.UnknownTokensDecoder.End()
+	*d.any = append(*d.any, d.Tokens())
+}
+
+// parseMatrix4 parses the 16 values of a 4x4 matrix in row-major order.
+func parseMatrix4(s string) (m go3mf.Matrix, ok bool) {
+	values := strings.Fields(s)
+	if len(values) != len(m) {
+		return m, false
+	}
+	for i, v := range values {
+		val, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return go3mf.Matrix{}, false
+		}
+		m[i] = float32(val)
+	}
+	return m, true
+}
+
+func newTileStyle(s string) (t materials.TileStyle, ok bool) {
+	t, ok = map[string]materials.TileStyle{
+		"wrap":   materials.TileWrap,
+		"mirror": materials.TileMirror,
+		"clamp":  materials.TileClamp,
+	}[s]
+	return
+}
+
+type baseDecoder struct {
+}
+
+func (d *baseDecoder) Start([]spec.Attr) error { return nil }
+func (d *baseDecoder) End()                    {}
@@ -0,0 +1,197 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package volumetric
+
+import (
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/MosaicManufacturing/go3mf/spec"
+	"github.com/go-test/deep"
+)
+
+func TestDecode(t *testing.T) {
+	img := &Image3D{ID: 1, Name: "density", Stack: ImageStack{RowCount: 2, ColumnCount: 3, Sheets: []string{
+		"/3D/Volumetric/sheet0.png", "/3D/Volumetric/sheet1.png",
+	}}}
+	fromImg := &FunctionFromImage3D{ID: 2, DisplayName: "density", Image3DID: 1, ValueOffset: -0.5, ValueScale: 2, Filter: FilterNearest, TileStyleU: materials.TileClamp, TileStyleV: materials.TileMirror}
+	fn := &ImplicitFunction{ID: 3, DisplayName: "sphere",
+		Inputs: []Port{{Identifier: "pos", Type: PortVector}},
+		Nodes: []Node{
+			{Type: NodeLength, Identifier: "len", Inputs: []Port{{Identifier: "A", Type: PortVector, Ref: "inputs.pos"}}, Outputs: []Port{{Identifier: "result", Type: PortScalar}}},
+			{Type: NodeConstant, Identifier: "radius", DisplayName: "radius", Tag: "params", Value: 10, Outputs: []Port{{Identifier: "value", Type: PortScalar}}},
+			{Type: NodeConstVec, Identifier: "vec", Vector: go3mf.Point3D{1, 2, 3}, Outputs: []Port{{Identifier: "vector", Type: PortVector}}},
+			{Type: NodeConstMat, Identifier: "mat", Matrix: go3mf.Identity(), Outputs: []Port{{Identifier: "matrix", Type: PortMatrix}}},
+			{Type: NodeConstResourceID, Identifier: "res", ResourceID: 2, Outputs: []Port{{Identifier: "value", Type: PortResourceID}}},
+			{Type: NodeSubtraction, Identifier: "sub",
+				Inputs:  []Port{{Identifier: "A", Type: PortScalar, Ref: "len.result"}, {Identifier: "B", Type: PortScalar, Ref: "radius.value"}},
+				Outputs: []Port{{Identifier: "result", Type: PortScalar}},
+			},
+		},
+		Outputs: []Port{{Identifier: "shape", Type: PortScalar, Ref: "sub.result"}},
+		Any: go3mf.Any{spec.UnknownTokens{
+			xml.StartElement{Name: xml.Name{Space: ImplicitNamespace, Local: "gyroid"}, Attr: []xml.Attr{{Name: xml.Name{Local: "identifier"}, Value: "g"}}},
+			xml.StartElement{Name: xml.Name{Space: ImplicitNamespace, Local: "in"}},
+			xml.EndElement{Name: xml.Name{Space: ImplicitNamespace, Local: "in"}},
+			xml.EndElement{Name: xml.Name{Space: ImplicitNamespace, Local: "gyroid"}},
+		}},
+	}
+	vol := &VolumeData{ID: 4,
+		Composite: &Composite{BaseMaterialID: 5, Mappings: []FunctionRef{
+			{FunctionID: 2, Channel: ChannelRed}, {FunctionID: 2, Channel: ChannelGreen, MinFeatureSize: 0.5},
+		}},
+		Color:      &FunctionRef{FunctionID: 2, Channel: ChannelBlue, Transform: go3mf.Identity().Translate(1, 2, 3), FallbackValue: 1},
+		Properties: []Property{{FunctionRef: FunctionRef{FunctionID: 3, Channel: "shape"}, Name: "temperature", Required: true}},
+	}
+	meshObj := &go3mf.Object{ID: 6, Mesh: &go3mf.Mesh{AnyAttr: go3mf.AnyAttr{&MeshAttr{VolumeID: 4}}}}
+	lsObj := &go3mf.Object{ID: 7, Any: go3mf.Any{&LevelSet{FunctionRef: FunctionRef{FunctionID: 3, Channel: "shape"}, MeshID: 6, MeshBBoxOnly: true, VolumeID: 4}}}
+	want := &go3mf.Model{
+		Path:       "/3D/3dmodel.model",
+		Extensions: []go3mf.Extension{DefaultExtension, ImplicitExtension},
+		Resources: go3mf.Resources{
+			Assets:  []go3mf.Asset{img, fromImg, fn, vol},
+			Objects: []*go3mf.Object{meshObj, lsObj},
+		},
+	}
+	got := &go3mf.Model{
+		Path: "/3D/3dmodel.model",
+	}
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:v="http://schemas.3mf.io/3dmanufacturing/volumetric/2022/01" xmlns:i="http://schemas.3mf.io/3dmanufacturing/implicit/2023/12">
+		<resources>
+			<v:image3d id="1" name="density">
+				<v:imagestack rowcount="2" columncount="3" sheetcount="2">
+					<v:imagesheet path="/3D/Volumetric/sheet0.png"/>
+					<v:imagesheet path="/3D/Volumetric/sheet1.png"/>
+				</v:imagestack>
+			</v:image3d>
+			<v:functionfromimage3d id="2" displayname="density" image3did="1" valueoffset="-0.5" valuescale="2" filter="nearest" tilestyleu="clamp" tilestylev="mirror"/>
+			<i:implicitfunction id="3" displayname="sphere">
+				<i:in>
+					<i:vector identifier="pos"/>
+				</i:in>
+				<i:length identifier="len">
+					<i:in><i:vectorref identifier="A" ref="inputs.pos"/></i:in>
+					<i:out><i:scalar identifier="result"/></i:out>
+				</i:length>
+				<i:constant identifier="radius" displayname="radius" tag="params" value="10">
+					<i:out><i:scalar identifier="value"/></i:out>
+				</i:constant>
+				<i:constvec identifier="vec" x="1" y="2" z="3">
+					<i:out><i:vector identifier="vector"/></i:out>
+				</i:constvec>
+				<i:constmat identifier="mat" matrix="1 0 0 0 0 1 0 0 0 0 1 0 0 0 0 1">
+					<i:out><i:matrix identifier="matrix"/></i:out>
+				</i:constmat>
+				<i:constresourceid identifier="res" value="2">
+					<i:out><i:resourceid identifier="value"/></i:out>
+				</i:constresourceid>
+				<i:gyroid identifier="g"><i:in></i:in></i:gyroid>
+				<i:subtraction identifier="sub">
+					<i:in>
+						<i:scalarref identifier="A" ref="len.result"/>
+						<i:scalarref identifier="B" ref="radius.value"/>
+					</i:in>
+					<i:out><i:scalar identifier="result"/></i:out>
+				</i:subtraction>
+				<i:out>
+					<i:scalarref identifier="shape" ref="sub.result"/>
+				</i:out>
+			</i:implicitfunction>
+			<v:volumedata id="4">
+				<v:composite basematerialid="5">
+					<v:materialmapping functionid="2" channel="red"/>
+					<v:materialmapping functionid="2" channel="green" minfeaturesize="0.5"/>
+				</v:composite>
+				<v:color functionid="2" channel="blue" transform="1 0 0 0 1 0 0 0 1 1 2 3" fallbackvalue="1"/>
+				<v:property functionid="3" channel="shape" name="temperature" required="true"/>
+			</v:volumedata>
+			<object id="6" type="model">
+				<mesh v:volumeid="4"/>
+			</object>
+			<object id="7" type="model">
+				<v:levelset functionid="3" channel="shape" meshid="6" meshbboxonly="true" volumeid="4"/>
+			</object>
+		</resources>
+		<build>
+		</build>
+		</model>
+		`
+
+	t.Run("base", func(t *testing.T) {
+		if err := go3mf.UnmarshalModel([]byte(rootFile), got); err != nil {
+			t.Errorf("DecodeRawModel() unexpected error = %v", err)
+			return
+		}
+		if diff := deep.Equal(got, want); diff != nil {
+			t.Errorf("DecodeRawModel() = %v", diff)
+			return
+		}
+	})
+}
+
+func TestDecode_warns(t *testing.T) {
+	want := []string{
+		fmt.Sprintf("Resources@Image3D#0: %v", errors.NewParseAttrError("id", true)),
+		fmt.Sprintf("Resources@Image3D#0@ImageStack: %v", errors.NewParseAttrError("rowcount", true)),
+		fmt.Sprintf("Resources@FunctionFromImage3D#1: %v", errors.NewParseAttrError("image3did", true)),
+		fmt.Sprintf("Resources@FunctionFromImage3D#1: %v", errors.NewParseAttrError("valuescale", false)),
+		fmt.Sprintf("Resources@FunctionFromImage3D#1: %v", errors.NewParseAttrError("filter", false)),
+		fmt.Sprintf("Resources@FunctionFromImage3D#1: %v", errors.NewParseAttrError("tilestylew", false)),
+		fmt.Sprintf("Resources@ImplicitFunction#2@Node#0: %v", errors.NewParseAttrError("value", true)),
+		fmt.Sprintf("Resources@ImplicitFunction#2@Node#1: %v", errors.NewParseAttrError("matrix", true)),
+		fmt.Sprintf("Resources@VolumeData#3@Composite: %v", errors.NewParseAttrError("basematerialid", true)),
+		fmt.Sprintf("Resources@VolumeData#3@FunctionRef: %v", errors.NewParseAttrError("functionid", true)),
+		fmt.Sprintf("Resources@VolumeData#3@Property: %v", errors.NewParseAttrError("required", false)),
+		fmt.Sprintf("Resources@Object#0@LevelSet: %v", errors.NewParseAttrError("transform", false)),
+		fmt.Sprintf("Resources@Object#0@LevelSet: %v", errors.NewParseAttrError("meshid", true)),
+	}
+	got := new(go3mf.Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:v="http://schemas.3mf.io/3dmanufacturing/volumetric/2022/01" xmlns:i="http://schemas.3mf.io/3dmanufacturing/implicit/2023/12">
+		<resources>
+			<v:image3d id="a">
+				<v:imagestack rowcount="a" columncount="3" sheetcount="1">
+					<v:imagesheet path="/3D/Volumetric/sheet0.png"/>
+				</v:imagestack>
+			</v:image3d>
+			<v:functionfromimage3d id="2" image3did="a" valuescale="b" filter="c" tilestylew="d"/>
+			<i:implicitfunction id="3">
+				<i:constant identifier="c" value="a"/>
+				<i:constmat identifier="m" matrix="1 0 0"/>
+			</i:implicitfunction>
+			<v:volumedata id="4">
+				<v:composite basematerialid="a"/>
+				<v:color functionid="b" channel="red"/>
+				<v:property functionid="2" channel="red" name="p" required="c"/>
+			</v:volumedata>
+			<object id="7" type="model">
+				<v:levelset functionid="3" channel="shape" transform="a" meshid="b"/>
+			</object>
+		</resources>
+		<build>
+		</build>
+		</model>
+		`
+
+	t.Run("base", func(t *testing.T) {
+		err := go3mf.UnmarshalModel([]byte(rootFile), got)
+		if err == nil {
+			t.Fatal("error expected")
+		}
+		var errs []string
+		for _, err := range err.(*errors.List).Errors {
+			errs = append(errs, err.Error())
+		}
+		if diff := deep.Equal(errs, want); diff != nil {
+			t.Errorf("UnmarshalModel_warn() = %v", diff)
+			return
+		}
+	})
+}
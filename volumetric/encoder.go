@@ -0,0 +1,240 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package volumetric
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/MosaicManufacturing/go3mf/spec"
+)
+
+// Marshal3MFAttr encodes the resource attributes.
+func (m *MeshAttr) Marshal3MFAttr(_ spec.Encoder) ([]xml.Attr, error) {
+	return []xml.Attr{
+		{Name: xml.Name{Space: Namespace, Local: attrVolumeID}, Value: strconv.FormatUint(uint64(m.VolumeID), 10)},
+	}, nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *Image3D) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrImage3D}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	if r.Name != "" {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrName}, Value: r.Name})
+	}
+	x.EncodeToken(xs)
+	xst := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrImageStack}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrRowCount}, Value: strconv.FormatUint(uint64(r.Stack.RowCount), 10)},
+		{Name: xml.Name{Local: attrColumnCount}, Value: strconv.FormatUint(uint64(r.Stack.ColumnCount), 10)},
+		{Name: xml.Name{Local: attrSheetCount}, Value: strconv.Itoa(len(r.Stack.Sheets))},
+	}}
+	x.EncodeToken(xst)
+	x.SetAutoClose(true)
+	for _, path := range r.Stack.Sheets {
+		x.AddRelationship(spec.Relationship{Path: path, Type: materials.RelTypeTexture3D})
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrImageSheet}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrPath}, Value: path},
+		}})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xst.End())
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *FunctionFromImage3D) Marshal3MF(x spec.Encoder) error {
+	prec := x.FloatPresicion()
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrFunctionFromImage3D}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrImage3DID}, Value: strconv.FormatUint(uint64(r.Image3DID), 10)},
+	}}
+	if r.DisplayName != "" {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrDisplayName}, Value: r.DisplayName})
+	}
+	if r.ValueOffset != 0 {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrValueOffset}, Value: strconv.FormatFloat(float64(r.ValueOffset), 'f', prec, 32)})
+	}
+	if r.ValueScale != 1 {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrValueScale}, Value: strconv.FormatFloat(float64(r.ValueScale), 'f', prec, 32)})
+	}
+	if r.Filter != FilterLinear {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrFilter}, Value: r.Filter.String()})
+	}
+	if r.TileStyleU != materials.TileWrap {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTileStyleU}, Value: r.TileStyleU.String()})
+	}
+	if r.TileStyleV != materials.TileWrap {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTileStyleV}, Value: r.TileStyleV.String()})
+	}
+	if r.TileStyleW != materials.TileWrap {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTileStyleW}, Value: r.TileStyleW.String()})
+	}
+	x.SetAutoClose(true)
+	x.EncodeToken(xs)
+	x.SetAutoClose(false)
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *VolumeData) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrVolumeData}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	if r.Composite != nil {
+		xc := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrComposite}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrBaseMaterialID}, Value: strconv.FormatUint(uint64(r.Composite.BaseMaterialID), 10)},
+		}}
+		x.EncodeToken(xc)
+		x.SetAutoClose(true)
+		for i := range r.Composite.Mappings {
+			x.EncodeToken(xml.StartElement{
+				Name: xml.Name{Space: Namespace, Local: attrMaterialMapping},
+				Attr: r.Composite.Mappings[i].attrs(x),
+			})
+		}
+		x.SetAutoClose(false)
+		x.EncodeToken(xc.End())
+	}
+	x.SetAutoClose(true)
+	if r.Color != nil {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrColor}, Attr: r.Color.attrs(x)})
+	}
+	for _, p := range r.Properties {
+		xp := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrProperty}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: p.Name},
+		}}
+		xp.Attr = append(xp.Attr, p.FunctionRef.attrs(x)...)
+		if p.Required {
+			xp.Attr = append(xp.Attr, xml.Attr{Name: xml.Name{Local: attrRequired}, Value: "true"})
+		}
+		x.EncodeToken(xp)
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *LevelSet) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrLevelSet}, Attr: r.FunctionRef.attrs(x)}
+	xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrMeshID}, Value: strconv.FormatUint(uint64(r.MeshID), 10)})
+	if r.MeshBBoxOnly {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrMeshBBoxOnly}, Value: "true"})
+	}
+	if r.VolumeID != 0 {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrVolumeID}, Value: strconv.FormatUint(uint64(r.VolumeID), 10)})
+	}
+	x.SetAutoClose(true)
+	x.EncodeToken(xs)
+	x.SetAutoClose(false)
+	return nil
+}
+
+func (f *FunctionRef) attrs(x spec.Encoder) []xml.Attr {
+	prec := x.FloatPresicion()
+	attrs := []xml.Attr{
+		{Name: xml.Name{Local: attrFunctionID}, Value: strconv.FormatUint(uint64(f.FunctionID), 10)},
+		{Name: xml.Name{Local: attrChannel}, Value: f.Channel},
+	}
+	if f.HasTransform() {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attrTransform}, Value: f.Transform.String()})
+	}
+	if f.MinFeatureSize != 0 {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attrMinFeatureSize}, Value: strconv.FormatFloat(float64(f.MinFeatureSize), 'f', prec, 32)})
+	}
+	if f.FallbackValue != 0 {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attrFallbackValue}, Value: strconv.FormatFloat(float64(f.FallbackValue), 'f', prec, 32)})
+	}
+	return attrs
+}
+
+// Marshal3MF encodes the resource.
+func (r *ImplicitFunction) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: ImplicitNamespace, Local: attrImplicitFunction}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	if r.DisplayName != "" {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrDisplayName}, Value: r.DisplayName})
+	}
+	x.EncodeToken(xs)
+	writePorts(x, attrIn, r.Inputs)
+	for i := range r.Nodes {
+		r.Nodes[i].marshal(x)
+	}
+	for _, a := range r.Any {
+		if err := a.Marshal3MF(x); err != nil {
+			return err
+		}
+	}
+	writePorts(x, attrOut, r.Outputs)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+func (n *Node) marshal(x spec.Encoder) {
+	prec := x.FloatPresicion()
+	xs := xml.StartElement{Name: xml.Name{Space: ImplicitNamespace, Local: n.Type.String()}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrIdentifier}, Value: n.Identifier},
+	}}
+	if n.DisplayName != "" {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrDisplayName}, Value: n.DisplayName})
+	}
+	if n.Tag != "" {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTag}, Value: n.Tag})
+	}
+	switch n.Type {
+	case NodeConstant:
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrValue}, Value: strconv.FormatFloat(float64(n.Value), 'f', prec, 32)})
+	case NodeConstVec:
+		xs.Attr = append(xs.Attr,
+			xml.Attr{Name: xml.Name{Local: attrX}, Value: strconv.FormatFloat(float64(n.Vector.X()), 'f', prec, 32)},
+			xml.Attr{Name: xml.Name{Local: attrY}, Value: strconv.FormatFloat(float64(n.Vector.Y()), 'f', prec, 32)},
+			xml.Attr{Name: xml.Name{Local: attrZ}, Value: strconv.FormatFloat(float64(n.Vector.Z()), 'f', prec, 32)},
+		)
+	case NodeConstMat:
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrMatrix}, Value: formatMatrix4(n.Matrix, prec)})
+	case NodeConstResourceID:
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrValue}, Value: strconv.FormatUint(uint64(n.ResourceID), 10)})
+	}
+	x.EncodeToken(xs)
+	writePorts(x, attrIn, n.Inputs)
+	writePorts(x, attrOut, n.Outputs)
+	x.EncodeToken(xs.End())
+}
+
+func writePorts(x spec.Encoder, name string, ports []Port) {
+	xs := xml.StartElement{Name: xml.Name{Space: ImplicitNamespace, Local: name}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, p := range ports {
+		xp := xml.StartElement{Name: xml.Name{Space: ImplicitNamespace, Local: p.Type.String()}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrIdentifier}, Value: p.Identifier},
+		}}
+		if p.DisplayName != "" {
+			xp.Attr = append(xp.Attr, xml.Attr{Name: xml.Name{Local: attrDisplayName}, Value: p.DisplayName})
+		}
+		if p.Ref != "" {
+			xp.Name.Local = p.Type.refName()
+			xp.Attr = append(xp.Attr, xml.Attr{Name: xml.Name{Local: attrRef}, Value: p.Ref})
+		}
+		x.EncodeToken(xp)
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+}
+
+func formatMatrix4(m go3mf.Matrix, prec int) string {
+	values := make([]string, len(m))
+	for i, v := range m {
+		values[i] = strconv.FormatFloat(float64(v), 'f', prec, 32)
+	}
+	return strings.Join(values, " ")
+}
@@ -0,0 +1,82 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package volumetric
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/MosaicManufacturing/go3mf/spec"
+	"github.com/go-test/deep"
+)
+
+func TestMarshalModel(t *testing.T) {
+	img := &Image3D{ID: 1, Name: "density", Stack: ImageStack{RowCount: 4, ColumnCount: 2, Sheets: []string{
+		"/3D/Volumetric/sheet0.png", "/3D/Volumetric/sheet1.png", "/3D/Volumetric/sheet2.png",
+	}}}
+	fromImg := &FunctionFromImage3D{ID: 2, Image3DID: 1, ValueScale: 1, TileStyleW: materials.TileClamp}
+	other := &FunctionFromImage3D{ID: 3, DisplayName: "other", Image3DID: 1, ValueOffset: 0.25, ValueScale: 0.5, Filter: FilterNearest, TileStyleU: materials.TileMirror, TileStyleV: materials.TileClamp}
+	fn := &ImplicitFunction{ID: 4,
+		Inputs: []Port{{Identifier: "pos", DisplayName: "position", Type: PortVector}},
+		Nodes: []Node{
+			{Type: NodeConstMat, Identifier: "mat", Matrix: go3mf.Identity().Translate(1, 2, 3), Outputs: []Port{{Identifier: "matrix", Type: PortMatrix}}},
+			{Type: NodeMatVecMultiplication, Identifier: "mul",
+				Inputs:  []Port{{Identifier: "A", Type: PortMatrix, Ref: "mat.matrix"}, {Identifier: "B", Type: PortVector, Ref: "inputs.pos"}},
+				Outputs: []Port{{Identifier: "result", Type: PortVector}},
+			},
+			{Type: NodeConstVec, Identifier: "vec", DisplayName: "offset", Tag: "group", Vector: go3mf.Point3D{1, 0.5, 0}, Outputs: []Port{{Identifier: "vector", Type: PortVector}}},
+			{Type: NodeConstant, Identifier: "c", Value: 2.5, Outputs: []Port{{Identifier: "value", Type: PortScalar}}},
+			{Type: NodeConstResourceID, Identifier: "res", ResourceID: 2, Outputs: []Port{{Identifier: "value", Type: PortResourceID}}},
+			{Type: NodeLength, Identifier: "len", Inputs: []Port{{Identifier: "A", Type: PortVector, Ref: "mul.result"}}, Outputs: []Port{{Identifier: "result", Type: PortScalar}}},
+		},
+		Outputs: []Port{{Identifier: "shape", Type: PortScalar, Ref: "len.result"}},
+		Any: go3mf.Any{spec.UnknownTokens{
+			xml.StartElement{Name: xml.Name{Space: ImplicitNamespace, Local: "gyroid"}, Attr: []xml.Attr{{Name: xml.Name{Local: "identifier"}, Value: "g"}}},
+			xml.EndElement{Name: xml.Name{Space: ImplicitNamespace, Local: "gyroid"}},
+		}},
+	}
+	vol := &VolumeData{ID: 5,
+		Composite: &Composite{BaseMaterialID: 6, Mappings: []FunctionRef{
+			{FunctionID: 2, Channel: ChannelRed, Transform: go3mf.Identity().Translate(0, 0, 1)},
+			{FunctionID: 3, Channel: ChannelAlpha, MinFeatureSize: 0.25, FallbackValue: 0.5},
+		}},
+		Color:      &FunctionRef{FunctionID: 3, Channel: ChannelGreen},
+		Properties: []Property{{FunctionRef: FunctionRef{FunctionID: 4, Channel: "shape"}, Name: "density", Required: true}, {FunctionRef: FunctionRef{FunctionID: 2, Channel: ChannelBlue}, Name: "other"}},
+	}
+	emptyVol := &VolumeData{ID: 7}
+	meshObj := &go3mf.Object{ID: 8, Mesh: &go3mf.Mesh{
+		Vertices:  []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+		Triangles: []go3mf.Triangle{{V1: 0, V2: 1, V3: 2}},
+		AnyAttr:   go3mf.AnyAttr{&MeshAttr{VolumeID: 5}},
+	}}
+	lsObj := &go3mf.Object{ID: 9, Any: go3mf.Any{&LevelSet{FunctionRef: FunctionRef{FunctionID: 4, Channel: "shape", Transform: go3mf.Identity().Translate(1, 1, 1)}, MeshID: 8}}}
+	lsObj2 := &go3mf.Object{ID: 10, Any: go3mf.Any{&LevelSet{FunctionRef: FunctionRef{FunctionID: 4, Channel: "shape"}, MeshID: 8, MeshBBoxOnly: true, VolumeID: 7}}}
+	m := &go3mf.Model{
+		Path:       "/3D/3dmodel.model",
+		Extensions: []go3mf.Extension{DefaultExtension, ImplicitExtension},
+		Resources: go3mf.Resources{
+			Assets:  []go3mf.Asset{img, fromImg, other, fn, vol, emptyVol},
+			Objects: []*go3mf.Object{meshObj, lsObj, lsObj2},
+		},
+	}
+
+	t.Run("base", func(t *testing.T) {
+		b, err := go3mf.MarshalModel(m)
+		if err != nil {
+			t.Errorf("volumetric.MarshalModel() error = %v", err)
+			return
+		}
+		newModel := new(go3mf.Model)
+		newModel.Path = m.Path
+		if err := go3mf.UnmarshalModel(b, newModel); err != nil {
+			t.Errorf("volumetric.MarshalModel() error decoding = %v, s = %s", err, string(b))
+			return
+		}
+		if diff := deep.Equal(m, newModel); diff != nil {
+			t.Errorf("volumetric.MarshalModel() = %v, s = %s", diff, string(b))
+		}
+	})
+}
@@ -0,0 +1,278 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package volumetric
+
+import (
+	"strings"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/internal/reference"
+)
+
+// PortType defines the type of the values flowing through a port.
+type PortType uint8
+
+// Supported port types.
+const (
+	PortScalar PortType = iota
+	PortVector
+	PortMatrix
+	PortResourceID
+)
+
+func newPortType(s string) (p PortType, isRef bool, ok bool) {
+	if strings.HasSuffix(s, "ref") {
+		s, isRef = strings.TrimSuffix(s, "ref"), true
+		if s == "resource" {
+			s = "resourceid"
+		}
+	}
+	p, ok = map[string]PortType{
+		"scalar":     PortScalar,
+		"vector":     PortVector,
+		"matrix":     PortMatrix,
+		"resourceid": PortResourceID,
+	}[s]
+	return
+}
+
+func (p PortType) String() string {
+	return map[PortType]string{
+		PortScalar:     "scalar",
+		PortVector:     "vector",
+		PortMatrix:     "matrix",
+		PortResourceID: "resourceid",
+	}[p]
+}
+
+func (p PortType) refName() string {
+	if p == PortResourceID {
+		return "resourceref"
+	}
+	return p.String() + "ref"
+}
+
+// Port is an input or an output of a function or a node.
+// Ref, if not empty, references the port that provides the value,
+// either "inputs.<identifier>" or "<node>.<identifier>".
+type Port struct {
+	Identifier  string
+	DisplayName string
+	Type        PortType
+	Ref         string
+}
+
+// NodeType defines the operation of a node.
+type NodeType uint8
+
+// Supported node types.
+const (
+	NodeAddition NodeType = iota
+	NodeSubtraction
+	NodeMultiplication
+	NodeDivision
+	NodeConstant
+	NodeConstVec
+	NodeConstMat
+	NodeConstResourceID
+	NodeComposeVector
+	NodeVectorFromScalar
+	NodeDecomposeVector
+	NodeComposeMatrix
+	NodeMatrixFromColumns
+	NodeMatrixFromRows
+	NodeDot
+	NodeCross
+	NodeMatVecMultiplication
+	NodeTranspose
+	NodeInverse
+	NodeSin
+	NodeCos
+	NodeTan
+	NodeArcSin
+	NodeArcCos
+	NodeArcTan
+	NodeArcTan2
+	NodeSinh
+	NodeCosh
+	NodeTanh
+	NodeMin
+	NodeMax
+	NodeAbs
+	NodeFmod
+	NodeMod
+	NodePow
+	NodeSqrt
+	NodeExp
+	NodeLog
+	NodeLog2
+	NodeLog10
+	NodeSelect
+	NodeClamp
+	NodeRound
+	NodeCeil
+	NodeFloor
+	NodeSign
+	NodeFract
+	NodeLength
+	NodeFunctionCall
+	NodeMesh
+	NodeUnsignedMesh
+)
+
+var nodeTypeNames = map[NodeType]string{
+	NodeAddition:             "addition",
+	NodeSubtraction:          "subtraction",
+	NodeMultiplication:       "multiplication",
+	NodeDivision:             "division",
+	NodeConstant:             "constant",
+	NodeConstVec:             "constvec",
+	NodeConstMat:             "constmat",
+	NodeConstResourceID:      "constresourceid",
+	NodeComposeVector:        "composevector",
+	NodeVectorFromScalar:     "vectorfromscalar",
+	NodeDecomposeVector:      "decomposevector",
+	NodeComposeMatrix:        "composematrix",
+	NodeMatrixFromColumns:    "matrixfromcolumns",
+	NodeMatrixFromRows:       "matrixfromrows",
+	NodeDot:                  "dot",
+	NodeCross:                "cross",
+	NodeMatVecMultiplication: "matvecmultiplication",
+	NodeTranspose:            "transpose",
+	NodeInverse:              "inverse",
+	NodeSin:                  "sin",
+	NodeCos:                  "cos",
+	NodeTan:                  "tan",
+	NodeArcSin:               "arcsin",
+	NodeArcCos:               "arccos",
+	NodeArcTan:               "arctan",
+	NodeArcTan2:              "arctan2",
+	NodeSinh:                 "sinh",
+	NodeCosh:                 "cosh",
+	NodeTanh:                 "tanh",
+	NodeMin:                  "min",
+	NodeMax:                  "max",
+	NodeAbs:                  "abs",
+	NodeFmod:                 "fmod",
+	NodeMod:                  "mod",
+	NodePow:                  "pow",
+	NodeSqrt:                 "sqrt",
+	NodeExp:                  "exp",
+	NodeLog:                  "log",
+	NodeLog2:                 "log2",
+	NodeLog10:                "log10",
+	NodeSelect:               "select",
+	NodeClamp:                "clamp",
+	NodeRound:                "round",
+	NodeCeil:                 "ceil",
+	NodeFloor:                "floor",
+	NodeSign:                 "sign",
+	NodeFract:                "fract",
+	NodeLength:               "length",
+	NodeFunctionCall:         "functioncall",
+	NodeMesh:                 "mesh",
+	NodeUnsignedMesh:         "unsignedmesh",
+}
+
+var nodeTypes = func() map[string]NodeType {
+	m := make(map[string]NodeType, len(nodeTypeNames))
+	for t, s := range nodeTypeNames {
+		m[s] = t
+	}
+	return m
+}()
+
+func newNodeType(s string) (t NodeType, ok bool) {
+	t, ok = nodeTypes[s]
+	return
+}
+
+func (t NodeType) String() string {
+	return nodeTypeNames[t]
+}
+
+// Node is an operation of an implicit function.
+// Value, Vector, Matrix and ResourceID hold the value
+// of the constant, constvec, constmat and constresourceid nodes.
+type Node struct {
+	Type        NodeType
+	Identifier  string
+	DisplayName string
+	Tag         string
+	Inputs      []Port
+	Outputs     []Port
+	Value       float32
+	Vector      go3mf.Point3D
+	Matrix      go3mf.Matrix
+	ResourceID  uint32
+}
+
+// ImplicitFunction defines a function as a graph of nodes.
+// Any contains the nodes not supported by this package, which
+// are preserved when encoding but ignored when validating references.
+type ImplicitFunction struct {
+	ID          uint32
+	DisplayName string
+	Inputs      []Port
+	Outputs     []Port
+	Nodes       []Node
+	Any         go3mf.Any
+}
+
+// Identify returns the unique ID of the resource.
+func (r *ImplicitFunction) Identify() uint32 {
+	return r.ID
+}
+
+// References returns the IDs of the resources referenced by the nodes of r.
+func (r *ImplicitFunction) References() []uint32 {
+	var refs []uint32
+	for _, n := range r.Nodes {
+		refs = append(refs, reference.NonZero(n.ResourceID)...)
+	}
+	return refs
+}
+
+// Remap replaces the ID of r, and the IDs referenced by its nodes, with the ones returned by fn.
+func (r *ImplicitFunction) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+	for i := range r.Nodes {
+		r.Nodes[i].ResourceID = reference.Remap(fn, r.Nodes[i].ResourceID)
+	}
+}
+
+// FindNode returns the node with the target identifier.
+func (r *ImplicitFunction) FindNode(identifier string) (*Node, bool) {
+	for i := range r.Nodes {
+		if r.Nodes[i].Identifier == identifier {
+			return &r.Nodes[i], true
+		}
+	}
+	return nil, false
+}
+
+// HasOutput returns true if the function defines the output channel.
+func (r *ImplicitFunction) HasOutput(channel string) bool {
+	for _, o := range r.Outputs {
+		if o.Identifier == channel {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	attrImplicitFunction = "implicitfunction"
+	attrIn               = "in"
+	attrOut              = "out"
+	attrIdentifier       = "identifier"
+	attrTag              = "tag"
+	attrRef              = "ref"
+	attrValue            = "value"
+	attrX                = "x"
+	attrY                = "y"
+	attrZ                = "z"
+	attrMatrix           = "matrix"
+	inputsRef            = "inputs"
+)
@@ -0,0 +1,92 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package volumetric
+
+import (
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf/spec"
+)
+
+var _ spec.Marshaler = new(ImplicitFunction)
+
+func Test_newPortType(t *testing.T) {
+	tests := []struct {
+		s     string
+		want  PortType
+		isRef bool
+		ok    bool
+	}{
+		{"scalar", PortScalar, false, true},
+		{"vectorref", PortVector, true, true},
+		{"matrix", PortMatrix, false, true},
+		{"resourceid", PortResourceID, false, true},
+		{"resourceref", PortResourceID, true, true},
+		{"other", 0, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, isRef, ok := newPortType(tt.s)
+			if got != tt.want || isRef != tt.isRef || ok != tt.ok {
+				t.Errorf("newPortType() = %v, %v, %v, want %v, %v, %v", got, isRef, ok, tt.want, tt.isRef, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPortType_refName(t *testing.T) {
+	tests := []struct {
+		p    PortType
+		want string
+	}{
+		{PortScalar, "scalarref"},
+		{PortVector, "vectorref"},
+		{PortMatrix, "matrixref"},
+		{PortResourceID, "resourceref"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.p.refName(); got != tt.want {
+				t.Errorf("PortType.refName() = %v, want %v", got, tt.want)
+			}
+			if got, isRef, _ := newPortType(tt.want); got != tt.p || !isRef {
+				t.Errorf("newPortType() = %v, want %v", got, tt.p)
+			}
+		})
+	}
+}
+
+func TestNodeType_String(t *testing.T) {
+	for typ, name := range nodeTypeNames {
+		if got, ok := newNodeType(name); !ok || got != typ {
+			t.Errorf("newNodeType(%s) = %v, want %v", name, got, typ)
+		}
+		if got := typ.String(); got != name {
+			t.Errorf("NodeType.String() = %v, want %v", got, name)
+		}
+	}
+	if _, ok := newNodeType("unknown"); ok {
+		t.Error("newNodeType() expected to fail")
+	}
+}
+
+func TestImplicitFunction_FindNode(t *testing.T) {
+	fn := &ImplicitFunction{Nodes: []Node{{Identifier: "a"}, {Identifier: "b"}}}
+	if got, ok := fn.FindNode("b"); !ok || got != &fn.Nodes[1] {
+		t.Errorf("ImplicitFunction.FindNode() = %v, want %v", got, &fn.Nodes[1])
+	}
+	if _, ok := fn.FindNode("c"); ok {
+		t.Error("ImplicitFunction.FindNode() expected to fail")
+	}
+}
+
+func TestImplicitFunction_HasOutput(t *testing.T) {
+	fn := &ImplicitFunction{Outputs: []Port{{Identifier: "shape"}}}
+	if !fn.HasOutput("shape") {
+		t.Error("ImplicitFunction.HasOutput() = false, want true")
+	}
+	if fn.HasOutput("color") {
+		t.Error("ImplicitFunction.HasOutput() = true, want false")
+	}
+}
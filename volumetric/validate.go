@@ -0,0 +1,258 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package volumetric
+
+import (
+	"strings"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/materials"
+)
+
+func (Spec) Validate(m interface{}, path string, obj interface{}) error {
+	switch obj := obj.(type) {
+	case *Image3D:
+		return validateImage3D(m.(*go3mf.Model), obj)
+	case *FunctionFromImage3D:
+		return validateFunctionFromImage3D(m.(*go3mf.Model), path, obj)
+	case *VolumeData:
+		return validateVolumeData(m.(*go3mf.Model), path, obj)
+	case *go3mf.Object:
+		return validateObject(m.(*go3mf.Model), path, obj)
+	}
+	return nil
+}
+
+func (ImplicitSpec) Validate(m interface{}, path string, obj interface{}) error {
+	if obj, ok := obj.(*ImplicitFunction); ok {
+		return validateImplicitFunction(m.(*go3mf.Model), path, obj)
+	}
+	return nil
+}
+
+func validateImage3D(m *go3mf.Model, r *Image3D) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Stack.RowCount == 0 || r.Stack.ColumnCount == 0 || len(r.Stack.Sheets) == 0 {
+		errs = errors.Append(errs, ErrImageStackSize)
+	}
+	for i, p := range r.Stack.Sheets {
+		if p == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrPath), p, i))
+		} else if _, ok := m.FindAttachment(p); !ok {
+			errs = errors.Append(errs, errors.WrapIndex(materials.ErrMissingTexturePart, p, i))
+		}
+	}
+	return
+}
+
+func validateFunctionFromImage3D(m *go3mf.Model, path string, r *FunctionFromImage3D) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Image3DID == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrImage3DID))
+	} else if a, ok := m.FindAsset(path, r.Image3DID); !ok {
+		errs = errors.Append(errs, ErrImage3DRef)
+	} else if _, ok := a.(*Image3D); !ok {
+		errs = errors.Append(errs, ErrImage3DRef)
+	}
+	return
+}
+
+func validateVolumeData(m *go3mf.Model, path string, r *VolumeData) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Composite != nil {
+		var cErrs error
+		if r.Composite.BaseMaterialID == 0 {
+			cErrs = errors.Append(cErrs, errors.NewMissingFieldError(attrBaseMaterialID))
+		} else if a, ok := m.FindAsset(path, r.Composite.BaseMaterialID); !ok {
+			cErrs = errors.Append(cErrs, ErrBaseMaterialRef)
+		} else if _, ok := a.(*go3mf.BaseMaterials); !ok {
+			cErrs = errors.Append(cErrs, ErrBaseMaterialRef)
+		}
+		for i := range r.Composite.Mappings {
+			if err := validateFunctionRef(m, path, &r.Composite.Mappings[i]); err != nil {
+				cErrs = errors.Append(cErrs, errors.WrapIndex(err, &r.Composite.Mappings[i], i))
+			}
+		}
+		if cErrs != nil {
+			errs = errors.Append(errs, errors.Wrap(cErrs, r.Composite))
+		}
+	}
+	if r.Color != nil {
+		if err := validateFunctionRef(m, path, r.Color); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, r.Color))
+		}
+	}
+	for i := range r.Properties {
+		p := &r.Properties[i]
+		var pErrs error
+		if p.Name == "" {
+			pErrs = errors.Append(pErrs, ErrMissingPropertyKey)
+		}
+		pErrs = errors.Append(pErrs, validateFunctionRef(m, path, &p.FunctionRef))
+		if pErrs != nil {
+			errs = errors.Append(errs, errors.WrapIndex(pErrs, p, i))
+		}
+	}
+	return
+}
+
+func validateFunctionRef(m *go3mf.Model, path string, f *FunctionRef) (errs error) {
+	if f.FunctionID == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrFunctionID))
+	} else if a, ok := m.FindAsset(path, f.FunctionID); !ok {
+		errs = errors.Append(errs, ErrFunctionRef)
+	} else {
+		switch a := a.(type) {
+		case *FunctionFromImage3D:
+			switch f.Channel {
+			case ChannelRed, ChannelGreen, ChannelBlue, ChannelAlpha:
+			default:
+				errs = errors.Append(errs, ErrChannel)
+			}
+		case *ImplicitFunction:
+			if !a.HasOutput(f.Channel) {
+				errs = errors.Append(errs, ErrChannel)
+			}
+		default:
+			errs = errors.Append(errs, ErrFunctionRef)
+		}
+	}
+	if f.Channel == "" {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrChannel))
+	}
+	if f.MinFeatureSize < 0 {
+		errs = errors.Append(errs, ErrMinFeatureSize)
+	}
+	return
+}
+
+func validateObject(m *go3mf.Model, path string, obj *go3mf.Object) (errs error) {
+	if obj.Mesh != nil {
+		if attr := GetMeshAttr(obj.Mesh); attr != nil && !isVolumeData(m, path, attr.VolumeID) {
+			errs = errors.Append(errs, errors.Wrap(ErrVolumeDataRef, obj.Mesh))
+		}
+	}
+	ls := GetLevelSet(obj)
+	if ls == nil {
+		return
+	}
+	var lErrs error
+	if obj.Mesh != nil || obj.Components != nil {
+		lErrs = errors.Append(lErrs, ErrLevelSetContent)
+	}
+	lErrs = errors.Append(lErrs, validateFunctionRef(m, path, &ls.FunctionRef))
+	if ls.MeshID == 0 {
+		lErrs = errors.Append(lErrs, errors.NewMissingFieldError(attrMeshID))
+	} else if o, ok := m.FindObject(path, ls.MeshID); !ok || o.Mesh == nil {
+		lErrs = errors.Append(lErrs, ErrLevelSetMesh)
+	}
+	if ls.VolumeID != 0 && !isVolumeData(m, path, ls.VolumeID) {
+		lErrs = errors.Append(lErrs, ErrVolumeDataRef)
+	}
+	if lErrs != nil {
+		errs = errors.Append(errs, errors.Wrap(lErrs, ls))
+	}
+	return
+}
+
+func isVolumeData(m *go3mf.Model, path string, id uint32) bool {
+	if a, ok := m.FindAsset(path, id); ok {
+		_, ok = a.(*VolumeData)
+		return ok
+	}
+	return false
+}
+
+func validateImplicitFunction(m *go3mf.Model, path string, r *ImplicitFunction) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	errs = errors.Append(errs, validatePorts(r.Inputs, nil, false))
+	ids := make(map[string]struct{}, len(r.Nodes))
+	for i := range r.Nodes {
+		n := &r.Nodes[i]
+		var nErrs error
+		if n.Identifier == "" {
+			nErrs = errors.Append(nErrs, errors.NewMissingFieldError(attrIdentifier))
+		} else if _, ok := ids[n.Identifier]; ok {
+			nErrs = errors.Append(nErrs, ErrDuplicatedPort)
+		}
+		ids[n.Identifier] = struct{}{}
+		if n.Type == NodeConstResourceID {
+			if _, ok := m.FindAsset(path, n.ResourceID); !ok {
+				if _, ok := m.FindObject(path, n.ResourceID); !ok {
+					nErrs = errors.Append(nErrs, errors.ErrMissingResource)
+				}
+			}
+		}
+		nErrs = errors.Append(nErrs, validatePorts(n.Inputs, r, true))
+		nErrs = errors.Append(nErrs, validatePorts(n.Outputs, nil, false))
+		if nErrs != nil {
+			errs = errors.Append(errs, errors.WrapIndex(nErrs, n, i))
+		}
+	}
+	errs = errors.Append(errs, validatePorts(r.Outputs, r, true))
+	return
+}
+
+// validatePorts checks that the port identifiers are unique and,
+// when fn is not nil, that every port references a port of the same type.
+func validatePorts(ports []Port, fn *ImplicitFunction, needsRef bool) (errs error) {
+	ids := make(map[string]struct{}, len(ports))
+	for i, p := range ports {
+		var pErrs error
+		if p.Identifier == "" {
+			pErrs = errors.Append(pErrs, errors.NewMissingFieldError(attrIdentifier))
+		} else if _, ok := ids[p.Identifier]; ok {
+			pErrs = errors.Append(pErrs, ErrDuplicatedPort)
+		}
+		ids[p.Identifier] = struct{}{}
+		if needsRef {
+			if p.Ref == "" {
+				pErrs = errors.Append(pErrs, errors.NewMissingFieldError(attrRef))
+			} else {
+				pErrs = errors.Append(pErrs, validateRef(fn, p))
+			}
+		}
+		if pErrs != nil {
+			errs = errors.Append(errs, errors.WrapIndex(pErrs, p, i))
+		}
+	}
+	return
+}
+
+func validateRef(fn *ImplicitFunction, p Port) error {
+	i := strings.IndexByte(p.Ref, '.')
+	if i <= 0 || i == len(p.Ref)-1 {
+		return ErrUnresolvedRef
+	}
+	owner, id := p.Ref[:i], p.Ref[i+1:]
+	var ports []Port
+	if owner == inputsRef {
+		ports = fn.Inputs
+	} else if n, ok := fn.FindNode(owner); ok {
+		ports = n.Outputs
+	} else if len(fn.Any) > 0 {
+		// The node may be one of the unsupported ones.
+		return nil
+	} else {
+		return ErrUnresolvedRef
+	}
+	for _, src := range ports {
+		if src.Identifier == id {
+			if src.Type != p.Type {
+				return ErrPortType
+			}
+			return nil
+		}
+	}
+	return ErrUnresolvedRef
+}
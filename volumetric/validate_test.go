@@ -0,0 +1,169 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package volumetric
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/errors"
+	"github.com/MosaicManufacturing/go3mf/materials"
+	"github.com/go-test/deep"
+)
+
+func validMesh(attr *MeshAttr) *go3mf.Mesh {
+	return &go3mf.Mesh{
+		Vertices:  []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+		Triangles: []go3mf.Triangle{{V1: 0, V2: 1, V3: 2}, {V1: 0, V2: 3, V3: 1}, {V1: 0, V2: 2, V3: 3}, {V1: 1, V2: 3, V3: 2}},
+		AnyAttr:   go3mf.AnyAttr{attr},
+	}
+}
+
+func sphereFunction(id uint32) *ImplicitFunction {
+	return &ImplicitFunction{ID: id,
+		Inputs: []Port{{Identifier: "pos", Type: PortVector}},
+		Nodes: []Node{
+			{Type: NodeLength, Identifier: "len", Inputs: []Port{{Identifier: "A", Type: PortVector, Ref: "inputs.pos"}}, Outputs: []Port{{Identifier: "result", Type: PortScalar}}},
+		},
+		Outputs: []Port{{Identifier: "shape", Type: PortScalar, Ref: "len.result"}},
+	}
+}
+
+func validResources() []go3mf.Asset {
+	return []go3mf.Asset{
+		&Image3D{ID: 1, Stack: ImageStack{RowCount: 1, ColumnCount: 1, Sheets: []string{"/3D/Volumetric/sheet0.png"}}},
+		&FunctionFromImage3D{ID: 2, Image3DID: 1, ValueScale: 1},
+		sphereFunction(3),
+		&go3mf.BaseMaterials{ID: 4, Materials: []go3mf.Base{{Name: "a", Color: color.RGBA{A: 255}}}},
+		&VolumeData{ID: 5,
+			Composite:  &Composite{BaseMaterialID: 4, Mappings: []FunctionRef{{FunctionID: 2, Channel: ChannelRed}}},
+			Color:      &FunctionRef{FunctionID: 2, Channel: ChannelGreen},
+			Properties: []Property{{FunctionRef: FunctionRef{FunctionID: 3, Channel: "shape"}, Name: "p"}},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	attachments := []go3mf.Attachment{{Path: "/3D/Volumetric/sheet0.png", ContentType: "image/png", Stream: new(bytes.Buffer)}}
+	tests := []struct {
+		name  string
+		model *go3mf.Model
+		want  []string
+	}{
+		{"valid", &go3mf.Model{Attachments: attachments, Resources: go3mf.Resources{
+			Assets: validResources(),
+			Objects: []*go3mf.Object{
+				{ID: 6, Mesh: validMesh(&MeshAttr{VolumeID: 5})},
+				{ID: 7, Any: go3mf.Any{&LevelSet{FunctionRef: FunctionRef{FunctionID: 3, Channel: "shape"}, MeshID: 6, VolumeID: 5}}},
+			},
+		}}, nil},
+		{"image3d", &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&Image3D{},
+			&Image3D{ID: 2, Stack: ImageStack{RowCount: 1, ColumnCount: 1, Sheets: []string{"", "/3D/Volumetric/other.png"}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Image3D#0: %v", errors.ErrMissingID),
+			fmt.Sprintf("Resources@Image3D#0: %v", ErrImageStackSize),
+			fmt.Sprintf("Resources@Image3D#1@string#0: %v", &errors.MissingFieldError{Name: attrPath}),
+			fmt.Sprintf("Resources@Image3D#1@string#1: %v", materials.ErrMissingTexturePart),
+		}},
+		{"functionfromimage3d", &go3mf.Model{Attachments: attachments, Resources: go3mf.Resources{Assets: append(validResources(),
+			&FunctionFromImage3D{},
+			&FunctionFromImage3D{ID: 11, Image3DID: 2},
+			&FunctionFromImage3D{ID: 12, Image3DID: 100},
+		)}}, []string{
+			fmt.Sprintf("Resources@FunctionFromImage3D#5: %v", errors.ErrMissingID),
+			fmt.Sprintf("Resources@FunctionFromImage3D#5: %v", &errors.MissingFieldError{Name: attrImage3DID}),
+			fmt.Sprintf("Resources@FunctionFromImage3D#6: %v", ErrImage3DRef),
+			fmt.Sprintf("Resources@FunctionFromImage3D#7: %v", ErrImage3DRef),
+		}},
+		{"volumedata", &go3mf.Model{Attachments: attachments, Resources: go3mf.Resources{Assets: append(validResources(),
+			&VolumeData{
+				Composite: &Composite{Mappings: []FunctionRef{{FunctionID: 1, Channel: ChannelRed}, {FunctionID: 2, Channel: "other"}}},
+				Color:     &FunctionRef{FunctionID: 3, Channel: "other", MinFeatureSize: -1},
+				Properties: []Property{
+					{FunctionRef: FunctionRef{FunctionID: 100, Channel: "shape"}},
+					{FunctionRef: FunctionRef{}, Name: "p"},
+				},
+			},
+			&VolumeData{ID: 11, Composite: &Composite{BaseMaterialID: 2}},
+		)}}, []string{
+			fmt.Sprintf("Resources@VolumeData#5: %v", errors.ErrMissingID),
+			fmt.Sprintf("Resources@VolumeData#5@Composite: %v", &errors.MissingFieldError{Name: attrBaseMaterialID}),
+			fmt.Sprintf("Resources@VolumeData#5@Composite@FunctionRef#0: %v", ErrFunctionRef),
+			fmt.Sprintf("Resources@VolumeData#5@Composite@FunctionRef#1: %v", ErrChannel),
+			fmt.Sprintf("Resources@VolumeData#5@FunctionRef: %v", ErrChannel),
+			fmt.Sprintf("Resources@VolumeData#5@FunctionRef: %v", ErrMinFeatureSize),
+			fmt.Sprintf("Resources@VolumeData#5@Property#0: %v", ErrMissingPropertyKey),
+			fmt.Sprintf("Resources@VolumeData#5@Property#0: %v", ErrFunctionRef),
+			fmt.Sprintf("Resources@VolumeData#5@Property#1: %v", &errors.MissingFieldError{Name: attrFunctionID}),
+			fmt.Sprintf("Resources@VolumeData#5@Property#1: %v", &errors.MissingFieldError{Name: attrChannel}),
+			fmt.Sprintf("Resources@VolumeData#6@Composite: %v", ErrBaseMaterialRef),
+		}},
+		{"object", &go3mf.Model{Attachments: attachments, Resources: go3mf.Resources{
+			Assets: validResources(),
+			Objects: []*go3mf.Object{
+				{ID: 6, Mesh: validMesh(&MeshAttr{VolumeID: 2})},
+				{ID: 7, Components: &go3mf.Components{}, Any: go3mf.Any{&LevelSet{FunctionRef: FunctionRef{FunctionID: 3, Channel: "shape"}, MeshID: 6}}},
+				{ID: 8, Any: go3mf.Any{&LevelSet{FunctionRef: FunctionRef{FunctionID: 3, Channel: "shape"}, MeshID: 7, VolumeID: 3}}},
+				{ID: 9, Any: go3mf.Any{&LevelSet{FunctionRef: FunctionRef{FunctionID: 3, Channel: "shape"}}}},
+			},
+		}}, []string{
+			fmt.Sprintf("Resources@Object#0@Mesh: %v", ErrVolumeDataRef),
+			fmt.Sprintf("Resources@Object#1@LevelSet: %v", ErrLevelSetContent),
+			fmt.Sprintf("Resources@Object#2@LevelSet: %v", ErrLevelSetMesh),
+			fmt.Sprintf("Resources@Object#2@LevelSet: %v", ErrVolumeDataRef),
+			fmt.Sprintf("Resources@Object#3@LevelSet: %v", &errors.MissingFieldError{Name: attrMeshID}),
+		}},
+		{"implicitfunction", &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&ImplicitFunction{
+				Inputs: []Port{{Identifier: "pos", Type: PortVector}, {Identifier: "pos", Type: PortScalar}},
+				Nodes: []Node{
+					{Type: NodeLength, Identifier: "a", Inputs: []Port{{Identifier: "A", Type: PortScalar, Ref: "inputs.pos"}}, Outputs: []Port{{Identifier: "result", Type: PortScalar}}},
+					{Type: NodeAbs, Identifier: "a", Inputs: []Port{{Identifier: "A", Type: PortScalar, Ref: "b.result"}, {Identifier: "B", Type: PortScalar}}},
+					{Type: NodeConstResourceID, Outputs: []Port{{Type: PortResourceID}}, ResourceID: 100},
+				},
+				Outputs: []Port{{Identifier: "shape", Type: PortScalar, Ref: "a.other"}, {Identifier: "other", Type: PortScalar, Ref: "invalid"}},
+			},
+			&ImplicitFunction{ID: 2, Outputs: []Port{{Identifier: "shape", Type: PortScalar}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@ImplicitFunction#0: %v", errors.ErrMissingID),
+			fmt.Sprintf("Resources@ImplicitFunction#0@Port#1: %v", ErrDuplicatedPort),
+			fmt.Sprintf("Resources@ImplicitFunction#0@Node#0@Port#0: %v", ErrPortType),
+			fmt.Sprintf("Resources@ImplicitFunction#0@Node#1: %v", ErrDuplicatedPort),
+			fmt.Sprintf("Resources@ImplicitFunction#0@Node#1@Port#0: %v", ErrUnresolvedRef),
+			fmt.Sprintf("Resources@ImplicitFunction#0@Node#1@Port#1: %v", &errors.MissingFieldError{Name: attrRef}),
+			fmt.Sprintf("Resources@ImplicitFunction#0@Node#2: %v", &errors.MissingFieldError{Name: attrIdentifier}),
+			fmt.Sprintf("Resources@ImplicitFunction#0@Node#2: %v", errors.ErrMissingResource),
+			fmt.Sprintf("Resources@ImplicitFunction#0@Node#2@Port#0: %v", &errors.MissingFieldError{Name: attrIdentifier}),
+			fmt.Sprintf("Resources@ImplicitFunction#0@Port#0: %v", ErrUnresolvedRef),
+			fmt.Sprintf("Resources@ImplicitFunction#0@Port#1: %v", ErrUnresolvedRef),
+			fmt.Sprintf("Resources@ImplicitFunction#1@Port#0: %v", &errors.MissingFieldError{Name: attrRef}),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.model.Extensions = []go3mf.Extension{DefaultExtension, ImplicitExtension}
+			err := tt.model.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate() err = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("error expected")
+			}
+			var errs []string
+			for _, err := range err.(*errors.List).Errors {
+				errs = append(errs, err.Error())
+			}
+			if diff := deep.Equal(errs, tt.want); diff != nil {
+				t.Errorf("Validate() = %v", diff)
+			}
+		})
+	}
+}
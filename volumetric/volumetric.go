@@ -0,0 +1,322 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package volumetric
+
+import (
+	"errors"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/internal/reference"
+	"github.com/MosaicManufacturing/go3mf/materials"
+)
+
+const (
+	// Namespace is the canonical name of the volumetric extension.
+	Namespace = "http://schemas.3mf.io/3dmanufacturing/volumetric/2022/01"
+	// ImplicitNamespace is the canonical name of the implicit extension,
+	// which defines the functions used by the volumetric extension.
+	ImplicitNamespace = "http://schemas.3mf.io/3dmanufacturing/implicit/2023/12"
+)
+
+var DefaultExtension = go3mf.Extension{
+	Namespace:  Namespace,
+	LocalName:  "v",
+	IsRequired: false,
+}
+
+var ImplicitExtension = go3mf.Extension{
+	Namespace:  ImplicitNamespace,
+	LocalName:  "i",
+	IsRequired: false,
+}
+
+var (
+	ErrImageStackSize     = errors.New("rowcount, columncount and sheetcount MUST be greater than 0")
+	ErrImage3DRef         = errors.New("image3did MUST reference an image3d resource")
+	ErrFunctionRef        = errors.New("functionid MUST reference a function resource")
+	ErrChannel            = errors.New("channel MUST be an output of the referenced function")
+	ErrBaseMaterialRef    = errors.New("basematerialid MUST reference a basematerials resource")
+	ErrVolumeDataRef      = errors.New("volumeid MUST reference a volumedata resource")
+	ErrLevelSetMesh       = errors.New("meshid MUST reference a mesh object")
+	ErrLevelSetContent    = errors.New("MUST NOT contain a mesh or components")
+	ErrDuplicatedPort     = errors.New("identifiers MUST be unique")
+	ErrUnresolvedRef      = errors.New("ref MUST reference an input of the function or an output of a node")
+	ErrPortType           = errors.New("ref MUST reference a port of the same type")
+	ErrMinFeatureSize     = errors.New("minfeaturesize MUST NOT be negative")
+	ErrMissingPropertyKey = errors.New("property name MUST NOT be empty")
+)
+
+func init() {
+	go3mf.Register(Namespace, Spec{})
+	go3mf.Register(ImplicitNamespace, ImplicitSpec{})
+}
+
+// Spec implements the volumetric extension.
+type Spec struct{}
+
+// ImplicitSpec implements the implicit extension.
+type ImplicitSpec struct{}
+
+// Filter defines the sampling filter of an image3d.
+type Filter uint8
+
+// Supported filters.
+const (
+	FilterLinear Filter = iota
+	FilterNearest
+)
+
+func newFilter(s string) (f Filter, ok bool) {
+	f, ok = map[string]Filter{
+		"linear":  FilterLinear,
+		"nearest": FilterNearest,
+	}[s]
+	return
+}
+
+func (f Filter) String() string {
+	return map[Filter]string{
+		FilterLinear:  "linear",
+		FilterNearest: "nearest",
+	}[f]
+}
+
+// Image3D defines a voxel image built from a stack of PNG sheets.
+type Image3D struct {
+	ID    uint32
+	Name  string
+	Stack ImageStack
+}
+
+// Identify returns the unique ID of the resource.
+func (r *Image3D) Identify() uint32 {
+	return r.ID
+}
+
+// Remap replaces the ID of r with the one returned by fn.
+func (r *Image3D) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+}
+
+// ImageStack defines the size of each sheet and the paths of the
+// sheet attachments, from bottom to top.
+type ImageStack struct {
+	RowCount    uint32
+	ColumnCount uint32
+	Sheets      []string
+}
+
+// Channels returned by a FunctionFromImage3D.
+const (
+	ChannelRed   = "red"
+	ChannelGreen = "green"
+	ChannelBlue  = "blue"
+	ChannelAlpha = "alpha"
+)
+
+// FunctionFromImage3D defines a function that samples an Image3D
+// at the normalized input position, returning
+// ValueOffset + ValueScale * value for each color channel.
+type FunctionFromImage3D struct {
+	ID          uint32
+	DisplayName string
+	Image3DID   uint32
+	ValueOffset float32
+	ValueScale  float32
+	Filter      Filter
+	TileStyleU  materials.TileStyle
+	TileStyleV  materials.TileStyle
+	TileStyleW  materials.TileStyle
+}
+
+// Identify returns the unique ID of the resource.
+func (r *FunctionFromImage3D) Identify() uint32 {
+	return r.ID
+}
+
+// References returns the IDs of the resources referenced by r.
+func (r *FunctionFromImage3D) References() []uint32 {
+	return reference.NonZero(r.Image3DID)
+}
+
+// Remap replaces the ID of r, and the IDs it references, with the ones returned by fn.
+func (r *FunctionFromImage3D) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+	r.Image3DID = reference.Remap(fn, r.Image3DID)
+}
+
+// FunctionRef references an output channel of a function
+// evaluated in the coordinate system defined by Transform.
+type FunctionRef struct {
+	FunctionID     uint32
+	Channel        string
+	Transform      go3mf.Matrix
+	MinFeatureSize float32
+	FallbackValue  float32
+}
+
+// HasTransform returns true if the transform is different than the identity.
+func (f *FunctionRef) HasTransform() bool {
+	return f.Transform != go3mf.Matrix{} && f.Transform != go3mf.Identity()
+}
+
+// VolumeData defines the properties that vary inside
+// the volume of the objects that reference it.
+type VolumeData struct {
+	ID         uint32
+	Composite  *Composite
+	Color      *FunctionRef
+	Properties []Property
+}
+
+// Identify returns the unique ID of the resource.
+func (r *VolumeData) Identify() uint32 {
+	return r.ID
+}
+
+// References returns the IDs of the resources referenced by r.
+func (r *VolumeData) References() []uint32 {
+	var refs []uint32
+	if r.Composite != nil {
+		refs = reference.NonZero(r.Composite.BaseMaterialID)
+		for _, m := range r.Composite.Mappings {
+			refs = append(refs, reference.NonZero(m.FunctionID)...)
+		}
+	}
+	if r.Color != nil {
+		refs = append(refs, reference.NonZero(r.Color.FunctionID)...)
+	}
+	for _, p := range r.Properties {
+		refs = append(refs, reference.NonZero(p.FunctionID)...)
+	}
+	return refs
+}
+
+// Remap replaces the ID of r, and the IDs it references, with the ones returned by fn.
+func (r *VolumeData) Remap(fn func(path string, id uint32) (string, uint32)) {
+	r.ID = reference.Remap(fn, r.ID)
+	if r.Composite != nil {
+		r.Composite.BaseMaterialID = reference.Remap(fn, r.Composite.BaseMaterialID)
+		for i := range r.Composite.Mappings {
+			r.Composite.Mappings[i].FunctionID = reference.Remap(fn, r.Composite.Mappings[i].FunctionID)
+		}
+	}
+	if r.Color != nil {
+		r.Color.FunctionID = reference.Remap(fn, r.Color.FunctionID)
+	}
+	for i := range r.Properties {
+		r.Properties[i].FunctionID = reference.Remap(fn, r.Properties[i].FunctionID)
+	}
+}
+
+// Composite mixes the materials of a base materials group,
+// each weighted by one of the mappings.
+type Composite struct {
+	BaseMaterialID uint32
+	Mappings       []FunctionRef
+}
+
+// Property defines a custom volumetric property.
+type Property struct {
+	FunctionRef
+	Name     string
+	Required bool
+}
+
+// LevelSet defines the shape of an object as the region where the
+// function channel is negative, clipped to the bounding box of the mesh
+// referenced by MeshID, or to the mesh itself when MeshBBoxOnly is false.
+type LevelSet struct {
+	FunctionRef
+	MeshID       uint32
+	MeshBBoxOnly bool
+	VolumeID     uint32
+}
+
+func GetLevelSet(obj *go3mf.Object) *LevelSet {
+	for _, a := range obj.Any {
+		if a, ok := a.(*LevelSet); ok {
+			return a
+		}
+	}
+	return nil
+}
+
+// References returns the IDs of the resources referenced by l.
+func (l *LevelSet) References() []uint32 {
+	return reference.NonZero(l.FunctionID, l.MeshID, l.VolumeID)
+}
+
+// IsShape returns true, as LevelSet defines the geometry of its object.
+func (l *LevelSet) IsShape() bool {
+	return true
+}
+
+// Remap replaces the IDs referenced by l with the ones returned by fn.
+func (l *LevelSet) Remap(fn func(path string, id uint32) (string, uint32)) {
+	l.FunctionID = reference.Remap(fn, l.FunctionID)
+	l.MeshID = reference.Remap(fn, l.MeshID)
+	l.VolumeID = reference.Remap(fn, l.VolumeID)
+}
+
+// MeshAttr defines the volumetric attributes added to a mesh.
+type MeshAttr struct {
+	VolumeID uint32
+}
+
+func GetMeshAttr(mesh *go3mf.Mesh) *MeshAttr {
+	for _, a := range mesh.AnyAttr {
+		if a, ok := a.(*MeshAttr); ok {
+			return a
+		}
+	}
+	return nil
+}
+
+// References returns the IDs of the resources referenced by m.
+func (m *MeshAttr) References() []uint32 {
+	return reference.NonZero(m.VolumeID)
+}
+
+// Remap replaces the IDs referenced by m with the ones returned by fn.
+func (m *MeshAttr) Remap(fn func(path string, id uint32) (string, uint32)) {
+	m.VolumeID = reference.Remap(fn, m.VolumeID)
+}
+
+const (
+	attrImage3D             = "image3d"
+	attrImageStack          = "imagestack"
+	attrImageSheet          = "imagesheet"
+	attrFunctionFromImage3D = "functionfromimage3d"
+	attrVolumeData          = "volumedata"
+	attrComposite           = "composite"
+	attrMaterialMapping     = "materialmapping"
+	attrColor               = "color"
+	attrProperty            = "property"
+	attrLevelSet            = "levelset"
+	attrID                  = "id"
+	attrName                = "name"
+	attrDisplayName         = "displayname"
+	attrRowCount            = "rowcount"
+	attrColumnCount         = "columncount"
+	attrSheetCount          = "sheetcount"
+	attrPath                = "path"
+	attrImage3DID           = "image3did"
+	attrValueOffset         = "valueoffset"
+	attrValueScale          = "valuescale"
+	attrFilter              = "filter"
+	attrTileStyleU          = "tilestyleu"
+	attrTileStyleV          = "tilestylev"
+	attrTileStyleW          = "tilestylew"
+	attrFunctionID          = "functionid"
+	attrChannel             = "channel"
+	attrTransform           = "transform"
+	attrMinFeatureSize      = "minfeaturesize"
+	attrFallbackValue       = "fallbackvalue"
+	attrBaseMaterialID      = "basematerialid"
+	attrRequired            = "required"
+	attrMeshID              = "meshid"
+	attrMeshBBoxOnly        = "meshbboxonly"
+	attrVolumeID            = "volumeid"
+)
@@ -0,0 +1,123 @@
+// © Copyright 2021 HP Development Company, L.P.
+// SPDX-License Identifier: BSD-2-Clause
+
+package volumetric
+
+import (
+	"testing"
+
+	"github.com/MosaicManufacturing/go3mf"
+	"github.com/MosaicManufacturing/go3mf/spec"
+	"github.com/go-test/deep"
+)
+
+var _ spec.Marshaler = new(Image3D)
+var _ spec.Marshaler = new(FunctionFromImage3D)
+var _ spec.Marshaler = new(VolumeData)
+var _ spec.Marshaler = new(LevelSet)
+var _ spec.MarshalerAttr = new(MeshAttr)
+
+func TestFilter_String(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Filter
+	}{
+		{"linear", FilterLinear},
+		{"nearest", FilterNearest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.String(); got != tt.name {
+				t.Errorf("Filter.String() = %v, want %v", got, tt.name)
+			}
+			if got, ok := newFilter(tt.name); !ok || got != tt.f {
+				t.Errorf("newFilter() = %v, want %v", got, tt.f)
+			}
+		})
+	}
+}
+
+func TestFunctionRef_HasTransform(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform go3mf.Matrix
+		want      bool
+	}{
+		{"empty", go3mf.Matrix{}, false},
+		{"identity", go3mf.Identity(), false},
+		{"translation", go3mf.Identity().Translate(1, 0, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &FunctionRef{Transform: tt.transform}
+			if got := f.HasTransform(); got != tt.want {
+				t.Errorf("FunctionRef.HasTransform() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetLevelSet(t *testing.T) {
+	ls := &LevelSet{MeshID: 1}
+	tests := []struct {
+		name string
+		obj  *go3mf.Object
+		want *LevelSet
+	}{
+		{"empty", &go3mf.Object{}, nil},
+		{"other", &go3mf.Object{Any: go3mf.Any{spec.UnknownTokens{}}}, nil},
+		{"base", &go3mf.Object{Any: go3mf.Any{spec.UnknownTokens{}, ls}}, ls},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetLevelSet(tt.obj); got != tt.want {
+				t.Errorf("GetLevelSet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMeshAttr(t *testing.T) {
+	attr := &MeshAttr{VolumeID: 1}
+	tests := []struct {
+		name string
+		mesh *go3mf.Mesh
+		want *MeshAttr
+	}{
+		{"empty", &go3mf.Mesh{}, nil},
+		{"base", &go3mf.Mesh{AnyAttr: go3mf.AnyAttr{attr}}, attr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetMeshAttr(tt.mesh); got != tt.want {
+				t.Errorf("GetMeshAttr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		r    interface{ References() []uint32 }
+		want []uint32
+	}{
+		{"function", &FunctionFromImage3D{Image3DID: 1}, []uint32{1}},
+		{"volumeDataEmpty", new(VolumeData), nil},
+		{"volumeData", &VolumeData{
+			Composite:  &Composite{BaseMaterialID: 1, Mappings: []FunctionRef{{FunctionID: 2}, {FunctionID: 3}}},
+			Color:      &FunctionRef{FunctionID: 4},
+			Properties: []Property{{FunctionRef: FunctionRef{FunctionID: 5}}},
+		}, []uint32{1, 2, 3, 4, 5}},
+		{"levelSet", &LevelSet{FunctionRef: FunctionRef{FunctionID: 1}, MeshID: 2, VolumeID: 3}, []uint32{1, 2, 3}},
+		{"meshAttr", &MeshAttr{VolumeID: 4}, []uint32{4}},
+		{"implicit", &ImplicitFunction{Nodes: []Node{{ResourceID: 6}, {}}}, []uint32{6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(tt.r.References(), tt.want); diff != nil {
+				t.Errorf("References() = %v", diff)
+			}
+		})
+	}
+}